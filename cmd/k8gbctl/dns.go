@@ -0,0 +1,220 @@
+package main
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
+
+	netv1 "k8s.io/api/networking/v1"
+)
+
+// dnsFlags configure access to edge DNS; defaults are read from the same environment variables as the operator uses
+type dnsFlags struct {
+	edgeDNSServers string
+	edgeDNSZone    string
+	dnsZone        string
+	geoTags        string
+}
+
+func (f *dnsFlags) register(fs *flag.FlagSet) {
+	geoTags := strings.Trim(os.Getenv("CLUSTER_GEO_TAG")+","+os.Getenv("EXT_GSLB_CLUSTERS_GEO_TAGS"), ",")
+	fs.StringVar(&f.edgeDNSServers, "edge-dns-servers", os.Getenv("EDGE_DNS_SERVERS"), "comma-separated edge DNS servers (host[:port])")
+	fs.StringVar(&f.edgeDNSZone, "edge-dns-zone", os.Getenv("EDGE_DNS_ZONE"), "edge DNS zone")
+	fs.StringVar(&f.dnsZone, "dns-zone", os.Getenv("DNS_ZONE"), "DNS zone delegated to k8gb clusters")
+	fs.StringVar(&f.geoTags, "geo-tags", geoTags, "comma-separated geo tags of all k8gb clusters")
+}
+
+// nameservers returns NS name for every geo tag
+func (f *dnsFlags) nameservers() (config *depresolver.Config, geoTags []string, err error) {
+	for _, tag := range strings.Split(f.geoTags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			geoTags = append(geoTags, tag)
+		}
+	}
+	var servers []string
+	for _, server := range strings.Split(f.edgeDNSServers, ",") {
+		if server = strings.TrimSpace(server); server != "" {
+			servers = append(servers, server)
+		}
+	}
+	config = &depresolver.Config{
+		EdgeDNSServers:     depresolver.ParseEdgeDNSServers(servers),
+		EdgeDNSZone:        f.edgeDNSZone,
+		DNSZone:            f.dnsZone,
+		ExtClustersGeoTags: geoTags,
+	}
+	switch {
+	case len(config.EdgeDNSServers) == 0:
+		return nil, nil, fmt.Errorf("missing or invalid --edge-dns-servers")
+	case f.edgeDNSZone == "":
+		return nil, nil, fmt.Errorf("missing --edge-dns-zone")
+	case f.dnsZone == "":
+		return nil, nil, fmt.Errorf("missing --dns-zone")
+	case len(geoTags) == 0:
+		return nil, nil, fmt.Errorf("missing --geo-tags")
+	}
+	return config, geoTags, nil
+}
+
+// clusterTargets contains localtargets- records published by every k8gb cluster
+type clusterTargets struct {
+	geoTags     []string
+	nameservers map[string]string
+	targets     assistant.Targets
+	errors      assistant.QueryErrors
+}
+
+// queryTargets queries every cluster, the failure of one cluster doesn't hide targets of others
+func (f *dnsFlags) queryTargets(host string) (*clusterTargets, error) {
+	config, geoTags, err := f.nameservers()
+	if err != nil {
+		return nil, err
+	}
+	ct := &clusterTargets{
+		geoTags:     geoTags,
+		nameservers: config.GetExternalClusterNSNames(),
+	}
+	gslb := assistant.NewGslbAssistant(nil, "", config.EdgeDNSServers, metrics.Prometheus())
+	ct.targets, ct.errors = gslb.GetExternalTargets(context.TODO(), host, ct.nameservers)
+	ct.targets.Sort()
+	return ct, nil
+}
+
+// targets prints localtargets- records of host published by every cluster
+func (c *cli) targets(args []string) error {
+	var df dnsFlags
+	fs := flag.NewFlagSet("targets", flag.ContinueOnError)
+	df.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expecting host")
+	}
+	ct, err := df.queryTargets(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "GEOTAG\tNAMESERVER\tTARGETS\tERROR")
+	for _, tag := range ct.geoTags {
+		targets, queryErr := "", ""
+		if t, found := ct.targets[tag]; found {
+			targets = strings.Join(t.IPs, ",")
+		}
+		if e := ct.errors[tag]; e != nil {
+			queryErr = e.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", tag, ct.nameservers[tag], orNone(targets), orNone(queryErr))
+	}
+	return w.Flush()
+}
+
+// answer prints DNS answer for host combined from targets of every cluster
+func (c *cli) answer(args []string) error {
+	var df dnsFlags
+	fs := flag.NewFlagSet("answer", flag.ContinueOnError)
+	namespace := fs.String("n", "", "namespace of ingress, all namespaces when empty")
+	df.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expecting host")
+	}
+	host := fs.Arg(0)
+	ing, err := c.ingressByHost(*namespace, host)
+	if err != nil {
+		return err
+	}
+	spec, err := effectiveSpec(ing)
+	if err != nil {
+		return fmt.Errorf("ingress %s/%s: %w", ing.Namespace, ing.Name, err)
+	}
+	ct, err := df.queryTargets(host)
+	if err != nil {
+		return err
+	}
+	result, ips := combine(spec, ct.geoTags, ct.targets)
+	fmt.Fprintf(c.out, "Host:      %s\n", host)
+	fmt.Fprintf(c.out, "Ingress:   %s/%s\n", ing.Namespace, ing.Name)
+	fmt.Fprintf(c.out, "Strategy:  %s\n", spec.Type)
+	fmt.Fprintf(c.out, "TTL:       %ds\n", spec.DNSTtlSeconds)
+	if spec.Type == depresolver.FailoverStrategy {
+		fmt.Fprintf(c.out, "Order:     %s\n", strings.Join(result.FailoverOrder, ","))
+		fmt.Fprintf(c.out, "Active:    %s\n", orNone(result.ActiveGeoTag))
+	}
+	if len(spec.Weights) > 0 {
+		var weights []string
+		for tag, weight := range spec.Weights {
+			weights = append(weights, fmt.Sprintf("%s=%d", tag, weight))
+		}
+		sort.Strings(weights)
+		fmt.Fprintf(c.out, "Weights:   %s\n", strings.Join(weights, ","))
+	}
+	for _, tag := range ct.geoTags {
+		if e := ct.errors[tag]; e != nil {
+			fmt.Fprintf(c.out, "Warning:   %s: %s\n", tag, e)
+		}
+	}
+	fmt.Fprintf(c.out, "Targets:   %s\n", orNone(strings.Join(ips, ",")))
+	return nil
+}
+
+// combine applies strategy on targets of all clusters in the same way as the operator does. geoTags contains
+// all k8gb clusters; targets of every cluster are handled as external, so the answer doesn't depend on the cluster
+func combine(spec mapper.Spec, geoTags []string, targets assistant.Targets) (result strategy.Result, ips []string) {
+	result = strategy.Compute(strategy.Input{
+		Spec:               spec,
+		ClusterGeoTag:      geoTags[0],
+		ExtClustersGeoTags: geoTags[1:],
+		ExternalTargets:    targets,
+	})
+	ips = result.Targets.GetIPs()
+	sort.Strings(ips)
+	return result, ips
+}
+
+// ingressByHost returns k8gb annotated ingress having rule for host
+func (c *cli) ingressByHost(namespace, host string) (*netv1.Ingress, error) {
+	ingresses, err := c.annotatedIngresses(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, ing := range ingresses {
+		for _, rule := range ing.Spec.Rules {
+			if rule.Host == host {
+				return ing, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no k8gb annotated ingress found for host %s", host)
+}
@@ -0,0 +1,158 @@
+package main
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// list prints k8gb annotated ingresses
+func (c *cli) list(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	namespace := fs.String("n", "", "namespace of ingresses, all namespaces when empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ingresses, err := c.annotatedIngresses(*namespace)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(c.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tSTRATEGY\tPRIMARY\tGEOTAG\tHEALTH")
+	for _, ing := range ingresses {
+		strategy, primary := "", ""
+		spec, err := effectiveSpec(ing)
+		if err != nil {
+			strategy = "<invalid>"
+		} else {
+			strategy, primary = spec.Type, spec.PrimaryGeoTag
+		}
+		status, _, _ := mapper.ParseStatus(ing.Annotations)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", ing.Namespace, ing.Name, strategy, orNone(primary), orNone(status.GeoTag),
+			orNone(health(status)))
+	}
+	return w.Flush()
+}
+
+// status prints decoded k8gb.io/status annotation
+func (c *cli) status(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	namespace := fs.String("n", "default", "namespace of ingress")
+	output := fs.String("o", "yaml", "output format (yaml, json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expecting ingress name")
+	}
+	cl, err := c.client()
+	if err != nil {
+		return err
+	}
+	ing := &netv1.Ingress{}
+	if err = cl.Get(context.TODO(), types.NamespacedName{Namespace: *namespace, Name: fs.Arg(0)}, ing); err != nil {
+		return err
+	}
+	status, found, err := mapper.ParseStatus(ing.Annotations)
+	if err != nil {
+		return fmt.Errorf("can't decode %s: %w", mapper.AnnotationStatus, err)
+	}
+	if !found {
+		return fmt.Errorf("ingress %s/%s has no %s annotation", ing.Namespace, ing.Name, mapper.AnnotationStatus)
+	}
+	var b []byte
+	switch *output {
+	case "json":
+		b, err = json.MarshalIndent(status, "", "  ")
+		b = append(b, '\n')
+	case "yaml":
+		b, err = yaml.Marshal(status)
+	default:
+		return fmt.Errorf("unsupported output format %q", *output)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.out.Write(b)
+	return err
+}
+
+// annotatedIngresses returns ingresses with k8gb.io/strategy annotation sorted by namespace and name
+func (c *cli) annotatedIngresses(namespace string) ([]*netv1.Ingress, error) {
+	cl, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+	list := &netv1.IngressList{}
+	if err = cl.List(context.TODO(), list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	var ingresses []*netv1.Ingress
+	for i := range list.Items {
+		if _, found := list.Items[i].Annotations[mapper.AnnotationStrategy]; found {
+			ingresses = append(ingresses, &list.Items[i])
+		}
+	}
+	sort.Slice(ingresses, func(i, j int) bool {
+		if ingresses[i].Namespace != ingresses[j].Namespace {
+			return ingresses[i].Namespace < ingresses[j].Namespace
+		}
+		return ingresses[i].Name < ingresses[j].Name
+	})
+	return ingresses, nil
+}
+
+// effectiveSpec returns spec reported by the operator in k8gb.io/status, which includes namespace and cluster
+// defaults. Spec parsed from annotations is used until the operator reports the status
+func effectiveSpec(ing *netv1.Ingress) (mapper.Spec, error) {
+	if status, found, err := mapper.ParseStatus(ing.Annotations); found && err == nil && status.Spec.Type != "" {
+		return status.Spec, nil
+	}
+	return mapper.ParseSpec(ing.Annotations, mapper.Defaults{})
+}
+
+// health returns health of hosts sorted by host
+func health(status mapper.Status) string {
+	var hosts []string
+	for host, health := range status.ServiceHealth {
+		hosts = append(hosts, fmt.Sprintf("%s=%s", host, health))
+	}
+	sort.Strings(hosts)
+	return strings.Join(hosts, ",")
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,198 @@
+package main
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const k8gbctlFakeDNSPort = 7854
+
+func TestList(t *testing.T) {
+	// arrange
+	out, c := newFakeCli(
+		newIngress("demo", "failover", `{"k8gb.io/strategy":"failover","k8gb.io/primary-geotag":"eu"}`, "failover.cloud.example.com"),
+		newIngress("demo", "plain", `{}`, "plain.cloud.example.com"),
+		newIngress("apps", "rr", `{"k8gb.io/strategy":"roundRobin"}`, "rr.cloud.example.com"),
+	)
+	// act
+	err := c.run([]string{"list"})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "NAMESPACE  NAME      STRATEGY    PRIMARY  GEOTAG  HEALTH\n"+
+		"apps       rr        roundRobin  <none>   <none>  <none>\n"+
+		"demo       failover  failover    eu       <none>  <none>\n", out.String())
+}
+
+func TestStatus(t *testing.T) {
+	// arrange
+	status := `{"serviceHealth":{"demo.cloud.example.com":"Healthy"},"healthyRecords":null,"geoTag":"eu",` +
+		`"spec":{"primaryGeoTag":"eu","strategy":"failover","dnsTTLSeconds":30,"splitBrainThresholdSeconds":300,"weights":null},` +
+		`"specSources":null}`
+	annotations := fmt.Sprintf(`{"k8gb.io/strategy":"failover","k8gb.io/status":%q}`, status)
+	out, c := newFakeCli(newIngress("demo", "demo", annotations, "demo.cloud.example.com"))
+	// act
+	err := c.run([]string{"status", "-n", "demo", "demo"})
+	// assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "geoTag: eu\n")
+	assert.Contains(t, out.String(), "demo.cloud.example.com: Healthy\n")
+	assert.Contains(t, out.String(), "strategy: failover\n")
+	assert.Error(t, c.run([]string{"status", "-n", "demo", "missing"}))
+	assert.Error(t, c.run([]string{"status", "-n", "demo", "-o", "xml", "demo"}))
+}
+
+func TestTargetsAndAnswer(t *testing.T) {
+	// arrange
+	const host = "demo.cloud.example.com"
+	out, c := newFakeCli(newIngress("demo", "demo", `{"k8gb.io/strategy":"failover","k8gb.io/primary-geotag":"us"}`, host))
+	dnsArgs := []string{"--edge-dns-servers", fmt.Sprintf("localhost:%d", k8gbctlFakeDNSPort), "--edge-dns-zone", "example.com",
+		"--dns-zone", "cloud.example.com", "--geo-tags", "eu,us"}
+	settings := utils.FakeDNSSettings{FakeDNSPort: k8gbctlFakeDNSPort, EdgeDNSZoneFQDN: "example.com.", DNSZoneFQDN: "cloud.example.com."}
+	// act
+	// assert
+	utils.NewFakeDNS(settings).
+		AddARecord("localtargets-"+host+".", net.IPv4(10, 0, 0, 1)).
+		Start().
+		RunTestFunc(func() {
+			require.NoError(t, c.run(append([]string{"targets"}, append(dnsArgs, host)...)))
+			assert.Equal(t, "GEOTAG  NAMESERVER  TARGETS   ERROR\n"+
+				"eu      localhost   10.0.0.1  <none>\n"+
+				"us      localhost   10.0.0.1  <none>\n", out.String())
+			out.Reset()
+			require.NoError(t, c.run(append([]string{"answer"}, append(dnsArgs, host)...)))
+			assert.Contains(t, out.String(), "Ingress:   demo/demo\n")
+			assert.Contains(t, out.String(), "Order:     us,eu\n")
+			assert.Contains(t, out.String(), "Active:    us\n")
+			assert.Contains(t, out.String(), "Targets:   10.0.0.1\n")
+		}).RequireNoError(t)
+	assert.EqualError(t, c.run([]string{"answer", "unknown.cloud.example.com"}),
+		"no k8gb annotated ingress found for host unknown.cloud.example.com")
+	assert.EqualError(t, c.run([]string{"targets", "--edge-dns-servers", "", host}), "missing or invalid --edge-dns-servers")
+}
+
+func TestCombine(t *testing.T) {
+	var tests = []struct {
+		name           string
+		spec           mapper.Spec
+		targets        assistant.Targets
+		expectedIPs    []string
+		expectedActive string
+	}{
+		{name: "round robin returns all targets", spec: mapper.Spec{Type: "roundRobin"},
+			targets:     assistant.Targets{"eu": {IPs: []string{"10.0.0.2"}}, "us": {IPs: []string{"10.0.0.1"}}},
+			expectedIPs: []string{"10.0.0.1", "10.0.0.2"}},
+		{name: "failover returns primary", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "us"},
+			targets:     assistant.Targets{"eu": {IPs: []string{"10.0.0.2"}}, "us": {IPs: []string{"10.0.0.1"}}},
+			expectedIPs: []string{"10.0.0.1"}, expectedActive: "us"},
+		{name: "failover without primary returns secondary", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "us"},
+			targets:     assistant.Targets{"eu": {IPs: []string{"10.0.0.2"}}, "za": {IPs: []string{"10.0.0.3"}}},
+			expectedIPs: []string{"10.0.0.2"}, expectedActive: "eu"},
+		{name: "failover without targets", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "us"},
+			targets: assistant.Targets{}, expectedIPs: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			// act
+			result, ips := combine(test.spec, []string{"eu", "us", "za"}, test.targets)
+			// assert
+			assert.Equal(t, test.expectedIPs, ips)
+			assert.Equal(t, test.expectedActive, result.ActiveGeoTag)
+		})
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	// arrange
+	_, c := newFakeCli()
+	// act
+	// assert
+	assert.Error(t, c.run([]string{}))
+	assert.Error(t, c.run([]string{"delete"}))
+}
+
+func newFakeCli(objects ...runtime.Object) (*bytes.Buffer, *cli) {
+	out := &bytes.Buffer{}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(objects...).Build()
+	return out, &cli{out: out, client: func() (client.Client, error) { return cl, nil }}
+}
+
+func newIngress(namespace, name, annotations, host string) *netv1.Ingress {
+	ing := &netv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       netv1.IngressSpec{Rules: []netv1.IngressRule{{Host: host}}},
+	}
+	if err := json.Unmarshal([]byte(annotations), &ing.Annotations); err != nil {
+		panic(err)
+	}
+	return ing
+}
+
+func TestSimulate(t *testing.T) {
+	// arrange
+	scenario := `annotations:
+  k8gb.io/strategy: failover
+  k8gb.io/primary-geotag: eu
+clusters:
+- geoTag: eu
+  healthy: true
+  targets: [10.0.0.1]
+- geoTag: us
+  healthy: true
+  targets: [10.1.0.1]
+  unreachable: [eu]
+`
+	out, c := newFakeCli()
+	c.in = strings.NewReader(scenario)
+	// act
+	err := c.run([]string{"simulate"})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "CLUSTER  HEALTHY  UNREACHABLE  ACTIVE  TARGETS\n"+
+		"eu       true     <none>       eu      10.0.0.1\n"+
+		"us       true     eu           <none>  10.1.0.1\n"+
+		"\nClusters disagree, answer depends on the queried nameserver:\n"+
+		"  eu: 10.0.0.1\n"+
+		"  us: 10.1.0.1\n", out.String())
+	c.in = strings.NewReader("clusters: [{geoTag: eu, healthy: true, targets: [10.0.0.1]}]\nspec: {strategy: roundRobin}\n")
+	out.Reset()
+	require.NoError(t, c.run([]string{"simulate", "-o", "json"}))
+	assert.Contains(t, out.String(), `"targets": [`)
+	c.in = strings.NewReader("clusters: []\nunknown: true\n")
+	assert.Error(t, c.run([]string{"simulate"}))
+}
@@ -0,0 +1,91 @@
+package main
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+// k8gbctl inspects k8gb annotated ingresses and DNS records published by k8gb clusters. Installed on PATH
+// as kubectl-k8gb, it is available as kubectl plugin: kubectl k8gb list
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const usage = `k8gbctl inspects k8gb annotated ingresses and DNS records published by k8gb clusters
+
+Usage:
+  k8gbctl [--kubeconfig path] <command> [flags] [arguments]
+
+Commands:
+  list     list k8gb annotated ingresses with strategy and health of their hosts
+  status   show decoded k8gb.io/status annotation of ingress
+  targets  query localtargets- records of host from every cluster through edge DNS
+  answer   print DNS answer of host combined from targets of every cluster
+  simulate print DNS answers of every cluster for what-if scenario
+
+Use "k8gbctl <command> -h" for flags of the command.
+`
+
+// cli runs commands; Kubernetes client is created lazily, so commands which don't need it work without cluster
+type cli struct {
+	in     io.Reader
+	out    io.Writer
+	client func() (client.Client, error)
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+	c := &cli{in: os.Stdin, out: os.Stdout, client: newClient}
+	if err := c.run(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func (c *cli) run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n\n%s", usage)
+	}
+	commands := map[string]func([]string) error{
+		"list":     c.list,
+		"status":   c.status,
+		"targets":  c.targets,
+		"answer":   c.answer,
+		"simulate": c.simulate,
+	}
+	command, found := commands[args[0]]
+	if !found {
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], usage)
+	}
+	return command(args[1:])
+}
+
+func newClient() (client.Client, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: scheme.Scheme})
+}
@@ -0,0 +1,115 @@
+package main
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
+
+	"sigs.k8s.io/yaml"
+)
+
+// scenarioFile is simulation scenario; spec is taken from k8gb annotations when they are present
+type scenarioFile struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+	strategy.Scenario
+}
+
+// simulate prints DNS answers of every cluster for scenario read from file
+func (c *cli) simulate(args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	file := fs.String("f", "-", "scenario file (yaml or json), - reads standard input")
+	output := fs.String("o", "text", "output format (text, json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	scenario, err := c.readScenario(*file)
+	if err != nil {
+		return err
+	}
+	simulation, err := strategy.Simulate(scenario)
+	if err != nil {
+		return err
+	}
+	switch *output {
+	case "json":
+		b, err := json.MarshalIndent(simulation, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.out, string(b))
+		return err
+	case "text":
+		return c.printSimulation(scenario, simulation)
+	}
+	return fmt.Errorf("unsupported output format %q", *output)
+}
+
+func (c *cli) readScenario(file string) (scenario strategy.Scenario, err error) {
+	var b []byte
+	if file == "-" {
+		b, err = io.ReadAll(c.in)
+	} else {
+		b, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return scenario, err
+	}
+	// spec defaults are the same as defaults of annotations
+	sf := scenarioFile{Scenario: strategy.Scenario{Spec: mapper.Spec{DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300}}}
+	if err = yaml.UnmarshalStrict(b, &sf); err != nil {
+		return scenario, fmt.Errorf("can't parse scenario: %w", err)
+	}
+	if len(sf.Annotations) > 0 {
+		if sf.Spec, err = mapper.ParseSpec(sf.Annotations, mapper.Defaults{}); err != nil {
+			return scenario, err
+		}
+	}
+	return sf.Scenario, nil
+}
+
+func (c *cli) printSimulation(scenario strategy.Scenario, simulation *strategy.Simulation) error {
+	w := tabwriter.NewWriter(c.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tHEALTHY\tUNREACHABLE\tACTIVE\tTARGETS")
+	for i, answer := range simulation.Answers {
+		cluster := scenario.Clusters[i]
+		fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%s\n", answer.GeoTag, cluster.Healthy, orNone(strings.Join(cluster.Unreachable, ",")),
+			orNone(answer.ActiveGeoTag), orNone(strings.Join(answer.Targets, ",")))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if simulation.Agree() {
+		fmt.Fprintf(c.out, "\nAll clusters answer %s\n", orNone(strings.Join(simulation.Variants[0].Targets, ",")))
+		return nil
+	}
+	fmt.Fprintf(c.out, "\nClusters disagree, answer depends on the queried nameserver:\n")
+	for _, v := range simulation.Variants {
+		fmt.Fprintf(c.out, "  %s: %s\n", strings.Join(v.GeoTags, ","), orNone(strings.Join(v.Targets, ",")))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/audit"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
+)
+
+// recordTargets writes audit record when targets published for host change. Targets of host which is new
+// to the resource aren't recorded, there is nothing to compare them with
+func (r *AnnoReconciler) recordTargets(ctx context.Context, rs *mapper.LoopState, host string, health metrics.HealthStatus,
+	peers assistant.Targets, queryErrors assistant.QueryErrors, result strategy.Result) {
+	targets := result.Targets.GetIPs()
+	sort.Strings(targets)
+	previous, found := r.observe(rs, "targets", host, strings.Join(targets, ","))
+	if !found || previous == strings.Join(targets, ",") {
+		return
+	}
+	record := audit.Record{
+		Timestamp:     time.Now().UTC(),
+		Host:          host,
+		Namespace:     rs.NamespacedName.Namespace,
+		Ingress:       rs.NamespacedName.Name,
+		Strategy:      rs.Spec.Type,
+		OldTargets:    []string{},
+		NewTargets:    targets,
+		Health:        health.String(),
+		Peers:         make(map[string][]string, len(peers)),
+		FailoverOrder: result.FailoverOrder,
+		ActiveGeoTag:  result.ActiveGeoTag,
+		ClusterGeoTag: r.Config.ClusterGeoTag,
+		Instance:      audit.Instance(),
+		DryRun:        r.Config.DryRun,
+	}
+	if previous != "" {
+		record.OldTargets = strings.Split(previous, ",")
+	}
+	for geoTag, t := range peers {
+		record.Peers[geoTag] = t.IPs
+	}
+	for geoTag, err := range queryErrors {
+		if record.PeerErrors == nil {
+			record.PeerErrors = make(map[string]string, len(queryErrors))
+		}
+		record.PeerErrors[geoTag] = err.Error()
+	}
+	r.Auditor.Audit(ctx, record)
+}
@@ -0,0 +1,103 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/audit"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRecordTargets(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var records []audit.Record
+	a := mocks.NewMockAuditor(ctrl)
+	a.EXPECT().Audit(gomock.Any(), gomock.Any()).Do(func(_ context.Context, r audit.Record) { records = append(records, r) }).Times(2)
+	r := fakeEventsReconciler()
+	r.Auditor = a
+	rs := &mapper.LoopState{NamespacedName: eventsState.NamespacedName, Spec: mapper.Spec{Type: depresolver.FailoverStrategy}}
+	us := strategy.Result{Targets: assistant.Targets{"us": {IPs: []string{"10.1.0.2", "10.1.0.1"}}}, ActiveGeoTag: "us"}
+	eu := strategy.Result{Targets: assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}}, ActiveGeoTag: "eu",
+		FailoverOrder: mapper.PrimaryGeotag{"us", "eu"}}
+	peers := assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}}
+	queryErrors := assistant.QueryErrors{"za": fmt.Errorf("i/o timeout")}
+	// act
+	r.recordTargets(context.TODO(), rs, "demo.cloud.example.com", metrics.Healthy, peers, nil, us)
+	r.recordTargets(context.TODO(), rs, "demo.cloud.example.com", metrics.Healthy, peers, nil, us)
+	r.recordTargets(context.TODO(), rs, "demo.cloud.example.com", metrics.Unhealthy, peers, queryErrors, eu)
+	r.recordTargets(context.TODO(), rs, "demo.cloud.example.com", metrics.Unhealthy, assistant.NewTargets(), nil, strategy.Result{Targets: assistant.NewTargets()})
+	// assert
+	assert.Len(t, records, 2)
+	for i, expected := range []audit.Record{
+		{Host: "demo.cloud.example.com", Namespace: "test-gslb", Ingress: "demo", Strategy: "failover",
+			OldTargets: []string{"10.1.0.1", "10.1.0.2"}, NewTargets: []string{"10.0.0.1"}, Health: "Unhealthy",
+			Peers: map[string][]string{"eu": {"10.0.0.1"}}, PeerErrors: map[string]string{"za": "i/o timeout"},
+			FailoverOrder: []string{"us", "eu"}, ActiveGeoTag: "eu", ClusterGeoTag: "us", Instance: audit.Instance(), DryRun: true},
+		{Host: "demo.cloud.example.com", Namespace: "test-gslb", Ingress: "demo", Strategy: "failover",
+			OldTargets: []string{"10.0.0.1"}, NewTargets: []string{}, Health: "Unhealthy",
+			Peers: map[string][]string{}, ClusterGeoTag: "us", Instance: audit.Instance(), DryRun: true},
+	} {
+		assert.False(t, records[i].Timestamp.IsZero())
+		records[i].Timestamp = expected.Timestamp
+		assert.Equal(t, expected, records[i])
+	}
+}
+
+func TestRecordTargetsAfterRestart(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var records []audit.Record
+	a := mocks.NewMockAuditor(ctrl)
+	a.EXPECT().Audit(gomock.Any(), gomock.Any()).Do(func(_ context.Context, r audit.Record) { records = append(records, r) }).Times(1)
+	r := fakeEventsReconciler()
+	r.Auditor = a
+	status := mapper.Status{
+		ServiceHealth:  map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
+		HealthyRecords: map[string][]string{"demo.cloud.example.com": {"10.1.0.2", "10.1.0.1"}},
+	}
+	rs := &mapper.LoopState{NamespacedName: eventsState.NamespacedName, Spec: mapper.Spec{Type: depresolver.FailoverStrategy},
+		Ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "test-gslb",
+			Annotations: map[string]string{mapper.AnnotationStatus: status.String()}}}}
+	us := strategy.Result{Targets: assistant.Targets{"us": {IPs: []string{"10.1.0.1", "10.1.0.2"}}}, ActiveGeoTag: "us"}
+	eu := strategy.Result{Targets: assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}}, ActiveGeoTag: "eu"}
+	// act
+	r.recordTargets(context.TODO(), rs, "demo.cloud.example.com", metrics.Healthy, nil, nil, us)
+	r.transitions.forget(rs.NamespacedName)
+	r.recordTargets(context.TODO(), rs, "demo.cloud.example.com", metrics.Unhealthy, nil, nil, eu)
+	// assert
+	assert.Len(t, records, 1)
+	assert.Equal(t, []string{"10.1.0.1", "10.1.0.2"}, records[0].OldTargets)
+	assert.Equal(t, []string{"10.0.0.1"}, records[0].NewTargets)
+}
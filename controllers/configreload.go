@@ -0,0 +1,122 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"sync"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// EventReasonConfigReloaded is reported on the configuration ConfigMap when the configuration is applied
+	EventReasonConfigReloaded = "ConfigReloaded"
+	// EventReasonConfigRejected is reported on the configuration ConfigMap when the configuration is invalid
+	EventReasonConfigRejected = "ConfigRejected"
+)
+
+// configLock guards reloadable fields of the shared configuration. ConfigReloader holds the write lock while
+// applying a new configuration, loops read the configuration by snapshotConfig
+var configLock sync.RWMutex
+
+// snapshotConfig returns copy of the shared configuration. Loops take the snapshot at the beginning and pass it
+// down to providers by depresolver.WithConfig, so configLock is never held during I/O and a reload doesn't wait
+// for running reconciliations
+func snapshotConfig(config *depresolver.Config) *depresolver.Config {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	c := *config
+	return &c
+}
+
+// ConfigReloader watches CONFIG_MAP_NAME ConfigMap in k8gb namespace and applies reloadable settings
+// to the running operator. Invalid configuration is rejected and the previous configuration stays active
+type ConfigReloader struct {
+	client.Client
+	Config      *depresolver.Config
+	DepResolver depresolver.GslbResolver
+	Recorder    record.EventRecorder
+	Log         *zerolog.Logger
+	Metrics     metrics.Metrics
+}
+
+// Reconcile reads the ConfigMap and applies its data as overrides of environment variables. Deleted ConfigMap
+// restores the configuration from environment variables
+func (r *ConfigReloader) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, req.NamespacedName, cm)
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	config, err := r.DepResolver.ReloadOperatorConfig(cm.Data)
+	if err != nil {
+		r.Metrics.IncrementConfigReloadError(req.NamespacedName)
+		r.Log.Err(err).
+			Str("ConfigMap", req.NamespacedName.String()).
+			Msg("Configuration rejected, previous configuration stays active")
+		if cm.UID != "" {
+			r.Recorder.Eventf(cm, corev1.EventTypeWarning, EventReasonConfigRejected, "Configuration rejected: %s", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	configLock.Lock()
+	r.Config.ApplyReloadable(config)
+	configLock.Unlock()
+	logging.SetLevel(config.Log.Level)
+
+	r.Metrics.IncrementConfigReload(req.NamespacedName)
+	r.Log.Info().
+		Str("ConfigMap", req.NamespacedName.String()).
+		Strs("ExtClustersGeoTags", config.ExtClustersGeoTags).
+		Str("LogLevel", config.Log.Level.String()).
+		Bool("SplitBrainCheck", config.SplitBrainCheck).
+		Int("SplitBrainThresholdSeconds", config.SplitBrainThresholdSeconds).
+		Int("NSRecordTTL", config.NSRecordTTL).
+		Msg("Configuration reloaded")
+	if cm.UID != "" {
+		r.Recorder.Event(cm, corev1.EventTypeNormal, EventReasonConfigReloaded, "Configuration reloaded")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager watches the configuration ConfigMap only
+func (r *ConfigReloader) SetupWithManager(mgr ctrl.Manager) error {
+	name := types.NamespacedName{Namespace: r.Config.K8gbNamespace, Name: r.Config.ConfigMapName}
+	isConfigMap := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetNamespace() == name.Namespace && o.GetName() == name.Name
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("configmap").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(isConfigMap)).
+		Complete(r)
+}
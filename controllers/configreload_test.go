@@ -0,0 +1,182 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var configMapRequest = ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "k8gb", Name: "k8gb-config"}}
+
+func TestConfigReload(t *testing.T) {
+	// arrange
+	defer logging.SetLevel(logging.Level())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeConfigReloader(ctrl)
+	data := map[string]string{depresolver.NSRecordTTLKey: "60", depresolver.LogLevelKey: "trace"}
+	reloaded := *r.Config
+	reloaded.NSRecordTTL = 60
+	reloaded.Log.Level = zerolog.TraceLevel
+	reloaded.DNSZone = "cloud.example.org"
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), configMapRequest.NamespacedName, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, cm *corev1.ConfigMap, _ ...interface{}) error {
+			cm.UID = "uid"
+			cm.Data = data
+			return nil
+		})
+	r.DepResolver.(*mocks.MockGslbResolver).EXPECT().ReloadOperatorConfig(data).Return(&reloaded, nil).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementConfigReload(configMapRequest.NamespacedName).Times(1)
+	// act
+	result, err := r.Reconcile(context.TODO(), configMapRequest)
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, result.Requeue)
+	assert.Equal(t, 60, r.Config.NSRecordTTL)
+	assert.Equal(t, zerolog.TraceLevel, r.Config.Log.Level)
+	assert.Equal(t, "cloud.example.com", r.Config.DNSZone)
+	assert.Equal(t, zerolog.TraceLevel, logging.Level())
+	assert.Equal(t, "Normal ConfigReloaded Configuration reloaded", <-r.Recorder.(*record.FakeRecorder).Events)
+}
+
+func TestSnapshotConfigIsNotChangedByReload(t *testing.T) {
+	// arrange
+	config := &depresolver.Config{ExtClustersGeoTags: []string{"za"}, NSRecordTTL: 30}
+	snapshot := snapshotConfig(config)
+	// act
+	configLock.Lock()
+	config.ApplyReloadable(&depresolver.Config{ExtClustersGeoTags: []string{"uk"}, NSRecordTTL: 60})
+	configLock.Unlock()
+	// assert
+	assert.Equal(t, []string{"za"}, snapshot.ExtClustersGeoTags)
+	assert.Equal(t, 30, snapshot.NSRecordTTL)
+	assert.Equal(t, []string{"uk"}, snapshotConfig(config).ExtClustersGeoTags)
+}
+
+func TestConfigReloadRejected(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeConfigReloader(ctrl)
+	data := map[string]string{depresolver.NSRecordTTLKey: "0"}
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), configMapRequest.NamespacedName, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, cm *corev1.ConfigMap, _ ...interface{}) error {
+			cm.UID = "uid"
+			cm.Data = data
+			return nil
+		})
+	r.DepResolver.(*mocks.MockGslbResolver).EXPECT().ReloadOperatorConfig(data).
+		Return(nil, fmt.Errorf("'NS_RECORD_TTL' is less or equal to zero")).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementConfigReloadError(configMapRequest.NamespacedName).Times(1)
+	// act
+	result, err := r.Reconcile(context.TODO(), configMapRequest)
+	// assert
+	assert.NoError(t, err)
+	assert.False(t, result.Requeue)
+	assert.Equal(t, 30, r.Config.NSRecordTTL)
+	assert.Equal(t, "Warning ConfigRejected Configuration rejected: 'NS_RECORD_TTL' is less or equal to zero",
+		<-r.Recorder.(*record.FakeRecorder).Events)
+}
+
+func TestConfigReloadRecovers(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeConfigReloader(ctrl)
+	reloaded := *r.Config
+	reloaded.NSRecordTTL = 60
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), configMapRequest.NamespacedName, gomock.Any()).Return(nil).Times(2)
+	gomock.InOrder(
+		r.DepResolver.(*mocks.MockGslbResolver).EXPECT().ReloadOperatorConfig(gomock.Any()).
+			Return(nil, fmt.Errorf("'NS_RECORD_TTL' is less or equal to zero")),
+		r.DepResolver.(*mocks.MockGslbResolver).EXPECT().ReloadOperatorConfig(gomock.Any()).Return(&reloaded, nil),
+	)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementConfigReloadError(configMapRequest.NamespacedName).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementConfigReload(configMapRequest.NamespacedName).Times(1)
+	// act
+	_, rejectedErr := r.Reconcile(context.TODO(), configMapRequest)
+	rejectedTTL := r.Config.NSRecordTTL
+	_, reloadedErr := r.Reconcile(context.TODO(), configMapRequest)
+	// assert
+	assert.NoError(t, rejectedErr)
+	assert.Equal(t, 30, rejectedTTL)
+	assert.NoError(t, reloadedErr)
+	assert.Equal(t, 60, r.Config.NSRecordTTL)
+}
+
+func TestConfigReloadConfigMapDeleted(t *testing.T) {
+	// arrange
+	defer logging.SetLevel(logging.Level())
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeConfigReloader(ctrl)
+	fromEnvironment := *r.Config
+	fromEnvironment.NSRecordTTL = 15
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), configMapRequest.NamespacedName, gomock.Any()).
+		Return(errors.NewNotFound(schema.GroupResource{}, configMapRequest.Name))
+	r.DepResolver.(*mocks.MockGslbResolver).EXPECT().ReloadOperatorConfig(gomock.Nil()).Return(&fromEnvironment, nil).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementConfigReload(configMapRequest.NamespacedName).Times(1)
+	// act
+	_, err := r.Reconcile(context.TODO(), configMapRequest)
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 15, r.Config.NSRecordTTL)
+	assert.Len(t, r.Recorder.(*record.FakeRecorder).Events, 0)
+}
+
+func TestConfigReloadReadError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeConfigReloader(ctrl)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("read error"))
+	r.DepResolver.(*mocks.MockGslbResolver).EXPECT().ReloadOperatorConfig(gomock.Any()).Times(0)
+	// act
+	_, err := r.Reconcile(context.TODO(), configMapRequest)
+	// assert
+	assert.Error(t, err)
+}
+
+func fakeConfigReloader(ctrl *gomock.Controller) *ConfigReloader {
+	return &ConfigReloader{
+		Client: mocks.NewMockClient(ctrl),
+		Config: &depresolver.Config{K8gbNamespace: "k8gb", ConfigMapName: "k8gb-config", DNSZone: "cloud.example.com",
+			NSRecordTTL: 30, Log: depresolver.Log{Level: zerolog.InfoLevel}},
+		DepResolver: mocks.NewMockGslbResolver(ctrl),
+		Recorder:    record.NewFakeRecorder(10),
+		Log:         logging.Logger(),
+		Metrics:     mocks.NewMockMetrics(ctrl),
+	}
+}
@@ -0,0 +1,158 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	debugPath            = "/debug/gslb"
+	logLevelPath         = "/debug/loglevel"
+	debugShutdownTimeout = 5 * time.Second
+	// maxLogLevelBody is long enough for any zerolog level
+	maxLogLevelBody = 16
+)
+
+// DebugServer serves explanation of the last reconciliation of annotated resources on DEBUG_ADDRESS,
+// and controls the log level at runtime. The server is not authenticated, so DEBUG_ADDRESS is restricted
+// to loopback interface and the server is reachable by kubectl port-forward only:
+//
+//	GET /debug/gslb                     explanations of all annotated resources
+//	GET /debug/gslb/{namespace}/{name}  explanation of single annotated resource
+//	GET /debug/loglevel                 current log level
+//	PUT /debug/loglevel                 changes log level to the level in request body, e.g. debug
+type DebugServer struct {
+	Config     *depresolver.Config
+	Reconciler *AnnoReconciler
+	Log        *zerolog.Logger
+}
+
+// Start implements manager.Runnable
+func (s *DebugServer) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.Config.DebugAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: debugShutdownTimeout,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), debugShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+	s.Log.Info().Str("address", s.Config.DebugAddress).Msg("Starting debug server")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. The server runs on every replica,
+// standby replicas don't reconcile, so they don't have any explanation
+func (s *DebugServer) NeedLeaderElection() bool {
+	return false
+}
+
+// SetupWithManager adds debug server to the Manager.
+func (s *DebugServer) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(s)
+}
+
+// Handler returns handler of debug endpoints
+func (s *DebugServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(debugPath, s.list)
+	mux.HandleFunc(debugPath+"/", s.get)
+	mux.HandleFunc(logLevelPath, s.logLevel)
+	return mux
+}
+
+// logLevel reads or changes the level of all loggers except loggers of resources annotated by k8gb.io/debug.
+// The level is kept until the operator restarts or LOG_LEVEL is reloaded from the configuration ConfigMap
+func (s *DebugServer) logLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxLogLevelBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		level, err := zerolog.ParseLevel(strings.ToLower(strings.TrimSpace(string(body))))
+		if err != nil || level == zerolog.NoLevel {
+			http.Error(w, "invalid log level '"+string(body)+"'", http.StatusBadRequest)
+			return
+		}
+		logging.SetLevel(level)
+		s.Log.Info().Str("level", level.String()).Msg("Log level changed")
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.write(w, map[string]string{"level": logging.Level().String()})
+}
+
+func (s *DebugServer) list(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.write(w, s.Reconciler.explanations.list())
+}
+
+func (s *DebugServer) get(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, debugPath+"/"), "/")
+	if len(path) != 2 || path[0] == "" || path[1] == "" {
+		http.Error(w, "expecting "+debugPath+"/{namespace}/{name}", http.StatusNotFound)
+		return
+	}
+	explanation, found := s.Reconciler.explanations.get(types.NamespacedName{Namespace: path[0], Name: path[1]})
+	if !found {
+		http.Error(w, "no reconciliation of "+path[0]+"/"+path[1]+" observed", http.StatusNotFound)
+		return
+	}
+	s.write(w, explanation)
+}
+
+func (s *DebugServer) write(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		s.Log.Err(err).Msg("Unable to write debug response")
+	}
+}
@@ -0,0 +1,127 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugServer(t *testing.T) {
+	// arrange
+	r := &AnnoReconciler{}
+	r.explanations.store(Explanation{Namespace: "demo", Name: "failover",
+		Hosts: []HostExplanation{{Host: "failover.cloud.example.com", Targets: []string{"10.0.0.1"}}}})
+	r.explanations.store(Explanation{Namespace: "demo", Name: "roundrobin"})
+	server := httptest.NewServer((&DebugServer{Reconciler: r, Log: logging.Logger()}).Handler())
+	defer server.Close()
+	var tests = []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   interface{}
+	}{
+		{name: "list", method: http.MethodGet, path: "/debug/gslb", expectedStatus: http.StatusOK,
+			expectedBody: &[]Explanation{r.explanations.list()[0], r.explanations.list()[1]}},
+		{name: "get", method: http.MethodGet, path: "/debug/gslb/demo/failover", expectedStatus: http.StatusOK,
+			expectedBody: &Explanation{Namespace: "demo", Name: "failover",
+				Hosts: []HostExplanation{{Host: "failover.cloud.example.com", Targets: []string{"10.0.0.1"}}}}},
+		{name: "unknown ingress", method: http.MethodGet, path: "/debug/gslb/demo/unknown", expectedStatus: http.StatusNotFound},
+		{name: "invalid path", method: http.MethodGet, path: "/debug/gslb/demo", expectedStatus: http.StatusNotFound},
+		{name: "write is rejected", method: http.MethodPost, path: "/debug/gslb/demo/failover", expectedStatus: http.StatusMethodNotAllowed},
+		{name: "list write is rejected", method: http.MethodDelete, path: "/debug/gslb", expectedStatus: http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			req, err := http.NewRequest(test.method, server.URL+test.path, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			// assert
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			if test.expectedBody == nil {
+				return
+			}
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+			switch expected := test.expectedBody.(type) {
+			case *Explanation:
+				actual := &Explanation{}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(actual))
+				assert.Equal(t, expected, actual)
+			case *[]Explanation:
+				actual := &[]Explanation{}
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(actual))
+				assert.Equal(t, expected, actual)
+			}
+		})
+	}
+}
+
+func TestDebugServerLogLevel(t *testing.T) {
+	// arrange
+	defer logging.SetLevel(logging.Level())
+	logging.SetLevel(zerolog.InfoLevel)
+	server := httptest.NewServer((&DebugServer{Reconciler: &AnnoReconciler{}, Log: logging.Logger()}).Handler())
+	defer server.Close()
+	var tests = []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedLevel  zerolog.Level
+	}{
+		{name: "get", method: http.MethodGet, expectedStatus: http.StatusOK, expectedLevel: zerolog.InfoLevel},
+		{name: "set debug", method: http.MethodPut, body: "debug", expectedStatus: http.StatusOK, expectedLevel: zerolog.DebugLevel},
+		{name: "set trace with new line", method: http.MethodPut, body: "TRACE\n", expectedStatus: http.StatusOK, expectedLevel: zerolog.TraceLevel},
+		{name: "invalid level", method: http.MethodPut, body: "verbose", expectedStatus: http.StatusBadRequest, expectedLevel: zerolog.TraceLevel},
+		{name: "empty level", method: http.MethodPut, body: "", expectedStatus: http.StatusBadRequest, expectedLevel: zerolog.TraceLevel},
+		{name: "delete is rejected", method: http.MethodDelete, expectedStatus: http.StatusMethodNotAllowed, expectedLevel: zerolog.TraceLevel},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			req, err := http.NewRequest(test.method, server.URL+"/debug/loglevel", strings.NewReader(test.body))
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			// assert
+			assert.Equal(t, test.expectedStatus, resp.StatusCode)
+			assert.Equal(t, test.expectedLevel, logging.Level())
+			if test.expectedStatus != http.StatusOK {
+				return
+			}
+			actual := map[string]string{}
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+			assert.Equal(t, map[string]string{"level": test.expectedLevel.String()}, actual)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/dns"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	externaldns "sigs.k8s.io/external-dns/endpoint"
+)
+
+const decommissionRetryPeriod = time.Second
+
+// Decommissioner withdraws the cluster from Edge DNS when the operator terminates and decommission is requested
+// by DECOMMISSION_ON_SHUTDOWN or by k8gb.io/decommission annotation on k8gb namespace. It removes localtargets-
+// records from DNSEndpoints, cluster nameservers from delegated zone and cluster heartbeat, and waits until the
+// withdrawal is confirmed or DECOMMISSION_TIMEOUT_SECONDS expires
+type Decommissioner struct {
+	client.Client
+	APIReader   client.Reader
+	Config      *depresolver.Config
+	DNSProvider dns.Provider
+	Mapper      mapper.ProviderMapper
+	Log         *zerolog.Logger
+}
+
+// Start implements manager.Runnable. Withdrawal runs when the manager is stopping; leader election runnables
+// are stopped before caches, so the cache is still available and the leader lease is still held
+func (r *Decommissioner) Start(ctx context.Context) error {
+	<-ctx.Done()
+	timeout, cancel := context.WithTimeout(context.Background(), time.Duration(r.Config.DecommissionTimeoutSeconds)*time.Second)
+	defer cancel()
+	requested, err := decommissioned(timeout, r.APIReader, r.Config, ctx.Done())
+	if err != nil {
+		r.Log.Err(err).Msg("Unable to read decommission annotation")
+		return nil
+	}
+	if !requested {
+		return nil
+	}
+	r.Log.Info().
+		Str("DNSZone", r.Config.DNSZone).
+		Str("ClusterGeoTag", r.Config.ClusterGeoTag).
+		Msg("Decommissioning cluster")
+	ticker := time.NewTicker(decommissionRetryPeriod)
+	defer ticker.Stop()
+	for {
+		err = r.withdraw(timeout)
+		if err == nil {
+			r.Log.Info().Msg("Cluster withdrawn from Edge DNS")
+			return nil
+		}
+		r.Log.Debug().Err(err).Msg("Waiting for withdrawal confirmation")
+		select {
+		case <-timeout.Done():
+			err = fmt.Errorf("withdrawal not confirmed within %ds: %w", r.Config.DecommissionTimeoutSeconds, err)
+			r.Log.Err(err).Msg("Decommission failed")
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the leader writes to Edge DNS
+func (r *Decommissioner) NeedLeaderElection() bool {
+	return true
+}
+
+// SetupWithManager adds decommission hook to the Manager.
+func (r *Decommissioner) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(r)
+}
+
+// decommissioned is true when k8gb namespace is annotated by k8gb.io/decommission: "true", or when
+// DECOMMISSION_ON_SHUTDOWN is set and shutdown has started. Reconcilers don't publish the cluster then, so neither
+// reconciliation in flight during shutdown nor the replica which takes the lease while terminating reverts the withdrawal
+func decommissioned(ctx context.Context, reader client.Reader, config *depresolver.Config, shutdown <-chan struct{}) (bool, error) {
+	if config.DecommissionOnShutdown && shuttingDown(shutdown) {
+		return true, nil
+	}
+	ns := &corev1.Namespace{}
+	err := reader.Get(ctx, client.ObjectKey{Name: config.K8gbNamespace}, ns)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	return ns.GetAnnotations()[mapper.AnnotationDecommission] == "true", nil
+}
+
+// shuttingDown is true once shutdown is closed, nil shutdown is never closed
+func shuttingDown(shutdown <-chan struct{}) bool {
+	select {
+	case <-shutdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// withoutLocalTargets returns endpoints except localtargets- records, which announce the cluster to its peers
+func withoutLocalTargets(endpoints []*externaldns.Endpoint) []*externaldns.Endpoint {
+	remaining := make([]*externaldns.Endpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		if !strings.HasPrefix(e.DNSName, "localtargets-") {
+			remaining = append(remaining, e)
+		}
+	}
+	return remaining
+}
+
+// withdraw returns nil once nothing is left to remove; in dry run the changes are only reported. Removal of
+// localtargets- records is confirmed once the DNSEndpoint without them is stored, because CoreDNS serves
+// DNSEndpoints from the API directly and reports no status back
+func (r *Decommissioner) withdraw(ctx context.Context) error {
+	ctx = depresolver.WithConfig(ctx, snapshotConfig(r.Config))
+	states, err := r.Mapper.List(ctx)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, rs := range states {
+		ep := &externaldns.DNSEndpoint{}
+		err = r.Client.Get(ctx, rs.NamespacedName, ep)
+		if err != nil && errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		remaining := withoutLocalTargets(ep.Spec.Endpoints)
+		if len(remaining) == len(ep.Spec.Endpoints) {
+			continue
+		}
+		pending++
+		ep.Spec.Endpoints = remaining
+		err = r.DNSProvider.SaveDNSEndpoint(ctx, rs, ep)
+		if err != nil {
+			return err
+		}
+	}
+	err = r.DNSProvider.Withdraw(ctx, resourceNames(states))
+	if err != nil {
+		return err
+	}
+	if pending > 0 && !r.Config.DryRun {
+		return fmt.Errorf("%d DNSEndpoints waiting for confirmation", pending)
+	}
+	return nil
+}
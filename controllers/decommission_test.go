@@ -0,0 +1,228 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	externaldns "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDecommissionNotRequested(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeDecommissioner(ctrl)
+	r.APIReader.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Times(0)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().Withdraw(gomock.Any(), gomock.Any()).Times(0)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	// act
+	err := r.Start(ctx)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDecommissionRequestedByNamespaceAnnotation(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeDecommissioner(ctrl)
+	r.APIReader.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, ns *corev1.Namespace, _ ...interface{}) error {
+			ns.Annotations = map[string]string{mapper.AnnotationDecommission: "true"}
+			return nil
+		}).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Return(nil, nil).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().Withdraw(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	// act
+	err := r.Start(ctx)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDecommissionRemovesLocalTargets(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gslb := &externaldns.Endpoint{DNSName: "demo.cloud.example.com", RecordType: "A", Targets: []string{"10.0.0.1", "10.1.0.1"}}
+	local := &externaldns.Endpoint{DNSName: "localtargets-demo.cloud.example.com", RecordType: "A", Targets: []string{"10.0.0.1"}}
+	rs := &mapper.LoopState{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing"}}
+	r := fakeDecommissioner(ctrl)
+	r.Config.DecommissionOnShutdown = true
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Return([]*mapper.LoopState{rs}, nil).Times(2)
+	// first pass removes localtargets, second pass confirms they are gone
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), rs.NamespacedName, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, ep *externaldns.DNSEndpoint, _ ...interface{}) error {
+			ep.Spec.Endpoints = []*externaldns.Endpoint{local, gslb}
+			return nil
+		}).Times(1)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), rs.NamespacedName, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, ep *externaldns.DNSEndpoint, _ ...interface{}) error {
+			ep.Spec.Endpoints = []*externaldns.Endpoint{gslb}
+			return nil
+		}).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().SaveDNSEndpoint(gomock.Any(), rs, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *mapper.LoopState, ep *externaldns.DNSEndpoint) error {
+			assert.Equal(t, []*externaldns.Endpoint{gslb}, ep.Spec.Endpoints)
+			return nil
+		}).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().Withdraw(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	// act
+	err := r.Start(ctx)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDecommissionTimeout(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeDecommissioner(ctrl)
+	r.Config.DecommissionOnShutdown = true
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Return(nil, nil).MinTimes(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().Withdraw(gomock.Any(), gomock.Any()).Return(fmt.Errorf("not confirmed")).MinTimes(1)
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	// act
+	err := r.Start(ctx)
+	// assert
+	assert.Error(t, err)
+}
+
+func TestReconcileDecommissionedClusterWithoutLocalTargets(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeExplainReconciler(ctrl)
+	r.Config.K8gbNamespace = "k8gb"
+	rs := fakeExplainState(ctrl, mapper.Spec{Type: depresolver.RoundRobinStrategy, DNSTtlSeconds: 30},
+		map[string]metrics.HealthStatus{"roundrobin.cloud.example.com": metrics.Healthy})
+	rs.Mapper.(*mocks.MockMapper).EXPECT().UpdateStatusAnnotation(gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), rs.NamespacedName).Return(rs, mapper.ResultExists, nil).Times(1)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "k8gb"}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, ns *corev1.Namespace, _ ...interface{}) error {
+			ns.Annotations = map[string]string{mapper.AnnotationDecommission: "true"}
+			return nil
+		}).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(false).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().GetExternalTargets(gomock.Any(), gomock.Any()).
+		Return(assistant.NewTargets(), assistant.QueryErrors{}).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().SaveDNSEndpoint(gomock.Any(), rs, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *mapper.LoopState, ep *externaldns.DNSEndpoint) error {
+			assert.Len(t, ep.Spec.Endpoints, 1)
+			assert.Equal(t, "roundrobin.cloud.example.com", ep.Spec.Endpoints[0].DNSName)
+			return nil
+		}).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateRoundrobinStatus(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateIngressHostsPerStatusMetric(gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateHealthyRecordsMetric(gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateEndpointStatus(gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementReconciliation(gomock.Any()).AnyTimes()
+	// act
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: rs.NamespacedName})
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDecommissionedOnShutdown(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	reader := mocks.NewMockClient(ctrl)
+	config := &depresolver.Config{K8gbNamespace: "k8gb", DecommissionOnShutdown: true}
+	running := make(chan struct{})
+	terminating := make(chan struct{})
+	close(terminating)
+	// the annotation is read only until shutdown starts
+	reader.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "k8gb"}, gomock.Any()).Return(nil).Times(1)
+	// act
+	beforeShutdown, err1 := decommissioned(context.TODO(), reader, config, running)
+	onShutdown, err2 := decommissioned(context.TODO(), reader, config, terminating)
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.False(t, beforeShutdown)
+	assert.True(t, onShutdown)
+}
+
+func TestReconcileOnShutdownWithoutLocalTargets(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeExplainReconciler(ctrl)
+	r.Config.K8gbNamespace = "k8gb"
+	r.Config.DecommissionOnShutdown = true
+	shutdown := make(chan struct{})
+	close(shutdown)
+	r.Shutdown = shutdown
+	rs := fakeExplainState(ctrl, mapper.Spec{Type: depresolver.RoundRobinStrategy, DNSTtlSeconds: 30},
+		map[string]metrics.HealthStatus{"roundrobin.cloud.example.com": metrics.Healthy})
+	rs.Mapper.(*mocks.MockMapper).EXPECT().UpdateStatusAnnotation(gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), rs.NamespacedName).Return(rs, mapper.ResultExists, nil).Times(1)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "k8gb"}, gomock.Any()).Times(0)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(false).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().GetExternalTargets(gomock.Any(), gomock.Any()).
+		Return(assistant.NewTargets(), assistant.QueryErrors{}).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().SaveDNSEndpoint(gomock.Any(), rs, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *mapper.LoopState, ep *externaldns.DNSEndpoint) error {
+			assert.Len(t, ep.Spec.Endpoints, 1)
+			assert.Equal(t, "roundrobin.cloud.example.com", ep.Spec.Endpoints[0].DNSName)
+			return nil
+		}).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateRoundrobinStatus(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateIngressHostsPerStatusMetric(gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateHealthyRecordsMetric(gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateEndpointStatus(gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementReconciliation(gomock.Any()).AnyTimes()
+	// act
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: rs.NamespacedName})
+	// assert
+	assert.NoError(t, err)
+}
+
+func fakeDecommissioner(ctrl *gomock.Controller) *Decommissioner {
+	return &Decommissioner{
+		Client:      mocks.NewMockClient(ctrl),
+		APIReader:   mocks.NewMockClient(ctrl),
+		Config:      &depresolver.Config{K8gbNamespace: "k8gb", DecommissionTimeoutSeconds: 1},
+		DNSProvider: mocks.NewMockProvider(ctrl),
+		Mapper:      mocks.NewMockProviderMapper(ctrl),
+		Log:         logging.Logger(),
+	}
+}
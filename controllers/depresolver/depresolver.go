@@ -83,6 +83,50 @@ const (
 	FailoverStrategy = "failover"
 )
 
+const (
+	// NotifierJSONFormat posts notification as JSON document
+	NotifierJSONFormat = "json"
+	// NotifierSlackFormat posts notification as Slack compatible message
+	NotifierSlackFormat = "slack"
+)
+
+// NotifierFormats supported formats of notification payload
+var NotifierFormats = []string{NotifierJSONFormat, NotifierSlackFormat}
+
+const (
+	// AuditSinkNone disables audit log
+	AuditSinkNone = "none"
+	// AuditSinkStdout writes audit records to standard output as JSON lines
+	AuditSinkStdout = "stdout"
+	// AuditSinkFile writes audit records to rotating file as JSON lines
+	AuditSinkFile = "file"
+	// AuditSinkConfigMap keeps the latest audit records in ConfigMap in k8gb namespace
+	AuditSinkConfigMap = "configmap"
+)
+
+// AuditSinks supported sinks of audit records
+var AuditSinks = []string{AuditSinkNone, AuditSinkStdout, AuditSinkFile, AuditSinkConfigMap}
+
+const (
+	// MetricsExporterPrometheus exposes metrics for scraping on MetricsAddress
+	MetricsExporterPrometheus = "prometheus"
+	// MetricsExporterOtlp pushes metrics to OTLP/HTTP collector on OtelExporterOtlpEndpoint
+	MetricsExporterOtlp = "otlp"
+)
+
+// MetricsExporters supported metrics exporters
+var MetricsExporters = []string{MetricsExporterPrometheus, MetricsExporterOtlp}
+
+const (
+	// TracingProtocolHTTP sends spans to OTLP/HTTP collector
+	TracingProtocolHTTP = "http/protobuf"
+	// TracingProtocolGRPC sends spans to OTLP/gRPC collector
+	TracingProtocolGRPC = "grpc"
+)
+
+// TracingProtocols supported protocols of tracing exporter
+var TracingProtocols = []string{TracingProtocolHTTP, TracingProtocolGRPC}
+
 // Log configuration
 type Log struct {
 	// Level [panic, fatal, error,warn,info,debug,trace], defines level of logger, default: info
@@ -107,18 +151,83 @@ type Infoblox struct {
 	Port int `env:"INFOBLOX_WAPI_PORT, default=0"`
 	// Username
 	Username string `env:"INFOBLOX_WAPI_USERNAME"`
-	// Password
-	Password string `env:"INFOBLOX_WAPI_PASSWORD"`
+	// Password is excluded from JSON, so it doesn't leak into the config log
+	Password string `env:"INFOBLOX_WAPI_PASSWORD" json:"-"`
 	// HTTPRequestTimeout seconds
 	HTTPRequestTimeout int `env:"INFOBLOX_HTTP_REQUEST_TIMEOUT, default=20"`
 	// HTTPPoolConnections seconds
 	HTTPPoolConnections int `env:"INFOBLOX_HTTP_POOL_CONNECTIONS, default=10"`
 }
 
+// LeaderElection configuration; only the leader replica reconciles and writes to Edge DNS
+type LeaderElection struct {
+	// Enabled flag; when false, every replica acts as leader
+	Enabled bool `env:"LEADER_ELECTION_ENABLED, default=false"`
+	// ID name of the lease resource
+	ID string `env:"LEADER_ELECTION_ID, default=8020e9ff.absa.oss"`
+	// Namespace where the lease resource is created, default: POD_NAMESPACE
+	Namespace string `env:"LEADER_ELECTION_NAMESPACE"`
+	// LeaseDurationSeconds how long non-leader replicas wait before acquiring not renewed lease
+	LeaseDurationSeconds int `env:"LEADER_ELECTION_LEASE_DURATION_SECONDS, default=15"`
+	// RenewDeadlineSeconds how long the leader keeps trying to renew the lease before giving up
+	RenewDeadlineSeconds int `env:"LEADER_ELECTION_RENEW_DEADLINE_SECONDS, default=10"`
+	// RetryPeriodSeconds how long replicas wait between attempts to acquire or renew the lease
+	RetryPeriodSeconds int `env:"LEADER_ELECTION_RETRY_PERIOD_SECONDS, default=2"`
+}
+
+// RateLimiter configures how failed reconciliations are retried; defaults are the same as controller-runtime defaults
+type RateLimiter struct {
+	// BaseDelayMilliseconds first retry delay of failed request, doubled with every next failure
+	BaseDelayMilliseconds int `env:"RATE_LIMITER_BASE_DELAY_MILLISECONDS, default=5"`
+	// MaxDelaySeconds maximal retry delay of failed request
+	MaxDelaySeconds int `env:"RATE_LIMITER_MAX_DELAY_SECONDS, default=1000"`
+	// QPS overall rate of requests processed by the controller
+	QPS int `env:"RATE_LIMITER_QPS, default=10"`
+	// Burst of requests allowed above QPS
+	Burst int `env:"RATE_LIMITER_BURST, default=100"`
+}
+
+// Notifier configures notifications about failover sent to HTTP endpoints
+type Notifier struct {
+	// Endpoints HTTP endpoints receiving notifications separated by comma; notifications are disabled when empty
+	Endpoints []string `env:"NOTIFIER_ENDPOINTS, default=[]"`
+	// Format of notification payload; json or slack
+	Format string `env:"NOTIFIER_FORMAT, default=json"`
+	// Secret signs notification payload by HMAC-SHA256; payload is not signed when empty.
+	// Excluded from JSON, so it doesn't leak into the config log
+	Secret string `env:"NOTIFIER_SECRET" json:"-"`
+	// Retries how many times failed notification is retried
+	Retries int `env:"NOTIFIER_RETRIES, default=3"`
+	// TimeoutSeconds timeout of single notification request
+	TimeoutSeconds int `env:"NOTIFIER_TIMEOUT_SECONDS, default=5"`
+}
+
+// Audit configures audit log of changes of targets published for annotated hosts
+type Audit struct {
+	// Sink of audit records; none, stdout, file or configmap
+	Sink string `env:"AUDIT_SINK, default=none"`
+	// File path of audit log written by file sink
+	File string `env:"AUDIT_FILE, default=/tmp/k8gb-audit.log"`
+	// FileMaxSizeMB size of audit log file which triggers rotation
+	FileMaxSizeMB int `env:"AUDIT_FILE_MAX_SIZE_MB, default=10"`
+	// FileMaxBackups how many rotated audit log files are kept
+	FileMaxBackups int `env:"AUDIT_FILE_MAX_BACKUPS, default=3"`
+	// ConfigMapName name of ConfigMap in k8gb namespace written by configmap sink
+	ConfigMapName string `env:"AUDIT_CONFIG_MAP_NAME, default=k8gb-audit"`
+	// ConfigMapSize how many latest audit records are kept in ConfigMap
+	ConfigMapSize int `env:"AUDIT_CONFIG_MAP_SIZE, default=100"`
+}
+
 // Config is operator configuration returned by depResolver
 type Config struct {
 	// Reschedule of Reconcile loop to pickup external Gslb targets
 	ReconcileRequeueSeconds int `env:"RECONCILE_REQUEUE_SECONDS, default=30"`
+	// ReconcileRequeueJitterPercent randomly prolongs requeue interval by up to given percent, so ingresses don't query Edge DNS at the same time
+	ReconcileRequeueJitterPercent int `env:"RECONCILE_REQUEUE_JITTER_PERCENT, default=10"`
+	// MaxConcurrentReconciles number of ingresses reconciled in parallel
+	MaxConcurrentReconciles int `env:"MAX_CONCURRENT_RECONCILES, default=1"`
+	// RateLimiter configuration
+	RateLimiter RateLimiter
 	// ClusterGeoTag to determine specific location
 	ClusterGeoTag string `env:"CLUSTER_GEO_TAG"`
 	// ExtClustersGeoTags to identify clusters in other locations in format separated by comma. i.e.: "eu,uk,us"
@@ -143,12 +252,51 @@ type Config struct {
 	CoreDNSExposed bool `env:"COREDNS_EXPOSED, default=false"`
 	// Log configuration
 	Log Log
+	// LeaderElection configuration
+	LeaderElection LeaderElection
+	// WatchNamespaces restricts ingresses handled by k8gb to the namespaces separated by comma; all namespaces when empty
+	WatchNamespaces []string `env:"WATCH_NAMESPACES, default=[]"`
+	// IngressLabelSelector restricts ingresses handled by k8gb to ingresses matching label selector; e.g. "k8gb.io/owner=team-a"
+	IngressLabelSelector string `env:"INGRESS_LABEL_SELECTOR"`
+	// IngressClasses restricts ingresses handled by k8gb to ingress classes separated by comma; all classes when empty
+	IngressClasses []string `env:"INGRESS_CLASSES, default=[]"`
+	// ConfigMapName name of ConfigMap in k8gb namespace overriding reloadable settings at runtime; disabled when empty
+	ConfigMapName string `env:"CONFIG_MAP_NAME"`
+	// DefaultsConfigMapName name of ConfigMap in k8gb namespace with cluster-wide default k8gb annotations; disabled when empty
+	DefaultsConfigMapName string `env:"DEFAULTS_CONFIG_MAP_NAME"`
+	// NamespaceDefaultsEnabled flag; when true, k8gb annotations of ingress namespace are used as defaults
+	NamespaceDefaultsEnabled bool `env:"NAMESPACE_DEFAULTS_ENABLED, default=false"`
+	// Notifier configuration
+	Notifier Notifier
+	// Audit configuration
+	Audit Audit
+	// WebhookEnabled flag; when true, validating admission webhook rejects ingresses with invalid k8gb annotations
+	WebhookEnabled bool `env:"WEBHOOK_ENABLED, default=false"`
 	// MetricsAddress in format address:port where address can be empty, IP address, or hostname, default: 0.0.0.0:8080
 	MetricsAddress string `env:"METRICS_ADDRESS, default=0.0.0.0:8080"`
+	// HealthProbeAddress serves /healthz and /readyz in format address:port, default: 0.0.0.0:8081
+	HealthProbeAddress string `env:"HEALTH_PROBE_ADDRESS, default=0.0.0.0:8081"`
+	// DebugAddress serves explanation of the last reconciliations and log level control in format address:port;
+	// must bind to loopback interface. Disabled when empty
+	DebugAddress string `env:"DEBUG_ADDRESS"`
 	// extDNSEnabled hidden. EdgeDNSType defines all enabled Enabled types
 	extDNSEnabled bool `env:"EXTDNS_ENABLED, default=false"`
 	// SplitBrainCheck flag decides whether split brain TXT records will be stored in edge DNS
 	SplitBrainCheck bool `env:"SPLIT_BRAIN_CHECK, default=false"`
+	// SplitBrainThresholdSeconds defines how old the heartbeat TXT record of external cluster can be,
+	// before the cluster is filtered out from delegated zone
+	SplitBrainThresholdSeconds int `env:"SPLIT_BRAIN_THRESHOLD_SECONDS, default=300"`
+	// ZoneDelegationRequeueSeconds interval of the loop maintaining zone delegation and heartbeat in edge DNS
+	ZoneDelegationRequeueSeconds int `env:"ZONE_DELEGATION_REQUEUE_SECONDS, default=30"`
+	// NSRecordTTL TTL of zone delegation records and heartbeat TXT record
+	NSRecordTTL int `env:"NS_RECORD_TTL, default=30"`
+	// DryRun flag; when true, k8gb computes and reports DNS changes without applying them
+	DryRun bool `env:"DRY_RUN, default=false"`
+	// DecommissionOnShutdown flag; when true, cluster is withdrawn from Edge DNS when the operator terminates.
+	// Decommission can be requested also by k8gb.io/decommission annotation on k8gb namespace
+	DecommissionOnShutdown bool `env:"DECOMMISSION_ON_SHUTDOWN, default=false"`
+	// DecommissionTimeoutSeconds how long the operator waits for confirmation of withdrawal before exiting
+	DecommissionTimeoutSeconds int `env:"DECOMMISSION_TIMEOUT_SECONDS, default=60"`
 	// TracingEnabled flag decides whether to use a real otlp tracer or a noop one
 	TracingEnabled bool `env:"TRACING_ENABLED, default=false"`
 	// TracingSamplingRatio how many traces should be kept and sent (1.0 - all, 0.0 - none)
@@ -156,13 +304,38 @@ type Config struct {
 	// OtelExporterOtlpEndpoint where the traces should be sent to (in case of otel collector deployed on the same pod as sidecar -> localhost:4318)
 	// otel collector itself can be configured via a configmap to send it somewhere else
 	OtelExporterOtlpEndpoint string `env:"OTEL_EXPORTER_OTLP_ENDPOINT, default=localhost:4318"`
+	// TracingProtocol [http/protobuf, grpc] of the exporter sending spans to OtelExporterOtlpEndpoint
+	TracingProtocol string `env:"TRACING_PROTOCOL, default=http/protobuf"`
+	// TracingInsecure flag; when true, spans are sent without TLS
+	TracingInsecure bool `env:"TRACING_INSECURE, default=true"`
+	// TracingCAFile path to PEM encoded CA certificate verifying the collector; system roots are used when empty
+	TracingCAFile string `env:"TRACING_CA_FILE"`
+	// TracingClientCertFile path to PEM encoded client certificate presented to the collector
+	TracingClientCertFile string `env:"TRACING_CLIENT_CERT_FILE"`
+	// TracingClientKeyFile path to PEM encoded private key of TracingClientCertFile
+	TracingClientKeyFile string `env:"TRACING_CLIENT_KEY_FILE"`
+	// TracingHeaders sent with each export request, e.g. vendor auth tokens. Calculated from tracingHeaders.
+	// Excluded from JSON, so it doesn't leak into the config log
+	TracingHeaders map[string]string `json:"-"`
+	// TracingResourceAttributes added to the resource of all spans. Calculated from tracingResourceAttributes
+	TracingResourceAttributes map[string]string
+	// tracingHeaders is binding source for TracingHeaders; key=value pairs separated by comma
+	tracingHeaders string `env:"TRACING_HEADERS"`
+	// tracingResourceAttributes is binding source for TracingResourceAttributes; key=value pairs separated by comma
+	tracingResourceAttributes string `env:"TRACING_RESOURCE_ATTRIBUTES"`
+	// MetricsExporter [prometheus, otlp]; otlp pushes metrics to OtelExporterOtlpEndpoint instead of exposing them for scraping
+	MetricsExporter string `env:"METRICS_EXPORTER, default=prometheus"`
+	// MetricsExportIntervalSeconds how often metrics are pushed when MetricsExporter is otlp
+	MetricsExportIntervalSeconds int `env:"METRICS_EXPORT_INTERVAL_SECONDS, default=30"`
 }
 
 // DependencyResolver resolves configuration for GSLB
 type DependencyResolver struct {
-	config      *Config
-	onceConfig  sync.Once
-	errorConfig error
+	config             *Config
+	onceConfig         sync.Once
+	errorConfig        error
+	configFile         string
+	configFileWarnings []string
 }
 
 // NewDependencyResolver returns a new depresolver.DependencyResolver
@@ -170,3 +343,11 @@ func NewDependencyResolver() *DependencyResolver {
 	resolver := new(DependencyResolver)
 	return resolver
 }
+
+// NewDependencyResolverWithConfigFile returns a new depresolver.DependencyResolver reading configuration file
+// from path. Environment variables override values from the file. CONFIG_FILE is used when path is empty
+func NewDependencyResolverWithConfigFile(path string) *DependencyResolver {
+	resolver := new(DependencyResolver)
+	resolver.configFile = path
+	return resolver
+}
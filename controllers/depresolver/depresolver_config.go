@@ -21,6 +21,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/AbsaOSS/env-binder/env"
 	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // Environment variables keys
@@ -45,19 +47,66 @@ const (
 	InfobloxPortKey            = "INFOBLOX_WAPI_PORT"
 	InfobloxUsernameKey        = "INFOBLOX_WAPI_USERNAME"
 	// #nosec G101; ignore false positive gosec; see: https://securego.io/docs/rules/g101.html
-	InfobloxPasswordKey            = "INFOBLOX_WAPI_PASSWORD"
-	InfobloxHTTPRequestTimeoutKey  = "INFOBLOX_HTTP_REQUEST_TIMEOUT"
-	InfobloxHTTPPoolConnectionsKey = "INFOBLOX_HTTP_POOL_CONNECTIONS"
-	K8gbNamespaceKey               = "POD_NAMESPACE"
-	CoreDNSExposedKey              = "COREDNS_EXPOSED"
-	LogLevelKey                    = "LOG_LEVEL"
-	LogFormatKey                   = "LOG_FORMAT"
-	LogNoColorKey                  = "NO_COLOR"
-	SplitBrainCheckKey             = "SPLIT_BRAIN_CHECK"
-	TracingEnabled                 = "TRACING_ENABLED"
-	OtelExporterOtlpEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
-	TracingSamplingRatio           = "TRACING_SAMPLING_RATIO"
-	MetricsAddressKey              = "METRICS_ADDRESS"
+	InfobloxPasswordKey             = "INFOBLOX_WAPI_PASSWORD"
+	InfobloxHTTPRequestTimeoutKey   = "INFOBLOX_HTTP_REQUEST_TIMEOUT"
+	InfobloxHTTPPoolConnectionsKey  = "INFOBLOX_HTTP_POOL_CONNECTIONS"
+	K8gbNamespaceKey                = "POD_NAMESPACE"
+	CoreDNSExposedKey               = "COREDNS_EXPOSED"
+	LogLevelKey                     = "LOG_LEVEL"
+	LogFormatKey                    = "LOG_FORMAT"
+	LogNoColorKey                   = "NO_COLOR"
+	SplitBrainCheckKey              = "SPLIT_BRAIN_CHECK"
+	SplitBrainThresholdSecondsKey   = "SPLIT_BRAIN_THRESHOLD_SECONDS"
+	ZoneDelegationRequeueSecondsKey = "ZONE_DELEGATION_REQUEUE_SECONDS"
+	NSRecordTTLKey                  = "NS_RECORD_TTL"
+	DryRunKey                       = "DRY_RUN"
+	DecommissionOnShutdownKey       = "DECOMMISSION_ON_SHUTDOWN"
+	DecommissionTimeoutSecondsKey   = "DECOMMISSION_TIMEOUT_SECONDS"
+	LeaderElectionEnabledKey        = "LEADER_ELECTION_ENABLED"
+	LeaderElectionIDKey             = "LEADER_ELECTION_ID"
+	LeaderElectionNamespaceKey      = "LEADER_ELECTION_NAMESPACE"
+	LeaseDurationSecondsKey         = "LEADER_ELECTION_LEASE_DURATION_SECONDS"
+	RenewDeadlineSecondsKey         = "LEADER_ELECTION_RENEW_DEADLINE_SECONDS"
+	RetryPeriodSecondsKey           = "LEADER_ELECTION_RETRY_PERIOD_SECONDS"
+	WatchNamespacesKey              = "WATCH_NAMESPACES"
+	IngressLabelSelectorKey         = "INGRESS_LABEL_SELECTOR"
+	IngressClassesKey               = "INGRESS_CLASSES"
+	RequeueJitterPercentKey         = "RECONCILE_REQUEUE_JITTER_PERCENT"
+	MaxConcurrentReconcilesKey      = "MAX_CONCURRENT_RECONCILES"
+	RateLimiterBaseDelayKey         = "RATE_LIMITER_BASE_DELAY_MILLISECONDS"
+	RateLimiterMaxDelayKey          = "RATE_LIMITER_MAX_DELAY_SECONDS"
+	RateLimiterQPSKey               = "RATE_LIMITER_QPS"
+	RateLimiterBurstKey             = "RATE_LIMITER_BURST"
+	ConfigMapNameKey                = "CONFIG_MAP_NAME"
+	WebhookEnabledKey               = "WEBHOOK_ENABLED"
+	DefaultsConfigMapNameKey        = "DEFAULTS_CONFIG_MAP_NAME"
+	NamespaceDefaultsEnabledKey     = "NAMESPACE_DEFAULTS_ENABLED"
+	NotifierEndpointsKey            = "NOTIFIER_ENDPOINTS"
+	NotifierFormatKey               = "NOTIFIER_FORMAT"
+	NotifierSecretKey               = "NOTIFIER_SECRET" // #nosec G101; false positive, the key isn't a credential
+	NotifierRetriesKey              = "NOTIFIER_RETRIES"
+	NotifierTimeoutSecondsKey       = "NOTIFIER_TIMEOUT_SECONDS"
+	AuditSinkKey                    = "AUDIT_SINK"
+	AuditFileKey                    = "AUDIT_FILE"
+	AuditFileMaxSizeMBKey           = "AUDIT_FILE_MAX_SIZE_MB"
+	AuditFileMaxBackupsKey          = "AUDIT_FILE_MAX_BACKUPS"
+	AuditConfigMapNameKey           = "AUDIT_CONFIG_MAP_NAME"
+	AuditConfigMapSizeKey           = "AUDIT_CONFIG_MAP_SIZE"
+	TracingEnabled                  = "TRACING_ENABLED"
+	OtelExporterOtlpEndpoint        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	TracingSamplingRatio            = "TRACING_SAMPLING_RATIO"
+	MetricsExporterKey              = "METRICS_EXPORTER"
+	TracingProtocolKey              = "TRACING_PROTOCOL"
+	TracingInsecureKey              = "TRACING_INSECURE"
+	TracingCAFileKey                = "TRACING_CA_FILE"
+	TracingClientCertFileKey        = "TRACING_CLIENT_CERT_FILE"
+	TracingClientKeyFileKey         = "TRACING_CLIENT_KEY_FILE"
+	TracingHeadersKey               = "TRACING_HEADERS"
+	TracingResourceAttributesKey    = "TRACING_RESOURCE_ATTRIBUTES"
+	MetricsExportIntervalSecondsKey = "METRICS_EXPORT_INTERVAL_SECONDS"
+	MetricsAddressKey               = "METRICS_ADDRESS"
+	HealthProbeAddressKey           = "HEALTH_PROBE_ADDRESS"
+	DebugAddressKey                 = "DEBUG_ADDRESS"
 )
 
 // Deprecated environment variables keys
@@ -72,33 +121,74 @@ const (
 // ResolveOperatorConfig executes once. It reads operator's configuration
 // from environment variables into &Config and validates
 func (dr *DependencyResolver) ResolveOperatorConfig() (*Config, error) {
-	var recognizedDNSTypes []EdgeDNSType
 	dr.onceConfig.Do(func() {
+		dr.config, dr.errorConfig = dr.resolveConfig(nil)
+	})
+	return dr.config, dr.errorConfig
+}
 
-		dr.config = &Config{}
+// resolveConfig reads operator's configuration from environment variables overridden by overrides
+// into &Config and validates
+func (dr *DependencyResolver) resolveConfig(overrides map[string]string) (config *Config, err error) {
+	var recognizedDNSTypes []EdgeDNSType
+	config = &Config{}
 
-		// binding
-		dr.errorConfig = env.Bind(dr.config)
-		if dr.errorConfig != nil {
-			return
+	// values from configuration file are used when environment variable is not set, overrides take precedence
+	// over both. The environment is never modified, values are bound over the environment instead
+	fileValues, err := dr.readConfigFile()
+	if err != nil {
+		return config, err
+	}
+	values := map[string]string{}
+	for k, v := range fileValues {
+		if _, found := os.LookupEnv(k); !found {
+			values[k] = v
 		}
+	}
+	for k, v := range overrides {
+		values[k] = v
+	}
+	lookup := func(key string) (string, bool) {
+		if v, found := values[key]; found {
+			return v, true
+		}
+		return os.LookupEnv(key)
+	}
 
-		// calculation
-		fallbackDNS := fmt.Sprintf("%s:%v", dr.config.fallbackEdgeDNSServerName, dr.config.fallbackEdgeDNSServerPort)
-		edgeDNSServerList := env.GetEnvAsArrayOfStringsOrFallback(EdgeDNSServersKey, []string{fallbackDNS})
-		dr.config.EdgeDNSServers = parseEdgeDNSServers(edgeDNSServerList)
-		dr.config.ExtClustersGeoTags = excludeGeoTag(dr.config.ExtClustersGeoTags, dr.config.ClusterGeoTag)
-		dr.config.Log.Level, _ = zerolog.ParseLevel(strings.ToLower(dr.config.Log.level))
-		dr.config.Log.Format = parseLogOutputFormat(strings.ToLower(dr.config.Log.format))
-		dr.config.EdgeDNSType, recognizedDNSTypes = getEdgeDNSType(dr.config)
-
-		// validation
-		dr.errorConfig = dr.validateConfig(dr.config, recognizedDNSTypes)
-	})
-	return dr.config, dr.errorConfig
+	// binding
+	err = env.Bind(config)
+	if err != nil {
+		return config, err
+	}
+	err = bindValues(config, values)
+	if err != nil {
+		return config, err
+	}
+
+	// calculation
+	fallbackDNS := fmt.Sprintf("%s:%v", config.fallbackEdgeDNSServerName, config.fallbackEdgeDNSServerPort)
+	edgeDNSServers, found := lookup(EdgeDNSServersKey)
+	edgeDNSServerList := []string{fallbackDNS}
+	if found {
+		edgeDNSServerList = splitValues(edgeDNSServers)
+	}
+	config.EdgeDNSServers = ParseEdgeDNSServers(edgeDNSServerList)
+	config.ExtClustersGeoTags = excludeGeoTag(config.ExtClustersGeoTags, config.ClusterGeoTag)
+	config.Log.Level, _ = zerolog.ParseLevel(strings.ToLower(config.Log.level))
+	config.Log.Format = parseLogOutputFormat(strings.ToLower(config.Log.format))
+	config.EdgeDNSType, recognizedDNSTypes = getEdgeDNSType(config)
+	config.TracingHeaders, _ = parseKeyValuePairs(config.tracingHeaders)
+	config.TracingResourceAttributes, _ = parseKeyValuePairs(config.tracingResourceAttributes)
+	if config.LeaderElection.Namespace == "" {
+		config.LeaderElection.Namespace = config.K8gbNamespace
+	}
+
+	// validation
+	err = dr.validateConfig(config, recognizedDNSTypes, edgeDNSServers)
+	return config, err
 }
 
-func (dr *DependencyResolver) validateConfig(config *Config, recognizedDNSTypes []EdgeDNSType) (err error) {
+func (dr *DependencyResolver) validateConfig(config *Config, recognizedDNSTypes []EdgeDNSType, edgeDNSServers string) (err error) {
 	const dnsNameMax = 253
 	const dnsLabelMax = 63
 	if config.Log.Level == zerolog.NoLevel {
@@ -120,6 +210,69 @@ func (dr *DependencyResolver) validateConfig(config *Config, recognizedDNSTypes
 	if err != nil {
 		return err
 	}
+	err = field(ZoneDelegationRequeueSecondsKey, config.ZoneDelegationRequeueSeconds).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(NSRecordTTLKey, config.NSRecordTTL).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(SplitBrainThresholdSecondsKey, config.SplitBrainThresholdSeconds).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(DecommissionTimeoutSecondsKey, config.DecommissionTimeoutSeconds).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = validateIngressSelection(config)
+	if err != nil {
+		return err
+	}
+	err = validateConcurrency(config)
+	if err != nil {
+		return err
+	}
+	err = validateNotifier(config.Notifier)
+	if err != nil {
+		return err
+	}
+	err = validateAudit(config)
+	if err != nil {
+		return err
+	}
+	err = validateMetricsExporter(config)
+	if err != nil {
+		return err
+	}
+	if config.TracingEnabled {
+		err = validateTracing(config)
+		if err != nil {
+			return err
+		}
+	}
+	if config.ConfigMapName != "" {
+		err = field(ConfigMapNameKey, config.ConfigMapName).matchRegexp(k8sNameRegex).err
+		if err != nil {
+			return err
+		}
+	}
+	if config.DefaultsConfigMapName != "" {
+		err = field(DefaultsConfigMapNameKey, config.DefaultsConfigMapName).matchRegexp(k8sNameRegex).err
+		if err != nil {
+			return err
+		}
+		if config.DefaultsConfigMapName == config.ConfigMapName {
+			return fmt.Errorf("'%s' and '%s' can't refer to the same ConfigMap", DefaultsConfigMapNameKey, ConfigMapNameKey)
+		}
+	}
+	if config.LeaderElection.Enabled {
+		err = validateLeaderElection(config.LeaderElection)
+		if err != nil {
+			return err
+		}
+	}
 	err = field(ClusterGeoTagKey, config.ClusterGeoTag).isNotEmpty().matchRegexp(geoTagRegex).err
 	if err != nil {
 		return err
@@ -135,11 +288,11 @@ func (dr *DependencyResolver) validateConfig(config *Config, recognizedDNSTypes
 			return err
 		}
 	}
-	err = field(EdgeDNSServersKey, os.Getenv(EdgeDNSServersKey)).isNotEmpty().matchRegexp(hostNamesWithPortsRegex1).err
+	err = field(EdgeDNSServersKey, edgeDNSServers).isNotEmpty().matchRegexp(hostNamesWithPortsRegex1).err
 	if err != nil {
 		return err
 	}
-	err = field(EdgeDNSServersKey, os.Getenv(EdgeDNSServersKey)).isNotEmpty().matchRegexp(hostNamesWithPortsRegex2).err
+	err = field(EdgeDNSServersKey, edgeDNSServers).isNotEmpty().matchRegexp(hostNamesWithPortsRegex2).err
 	if err != nil {
 		return err
 	}
@@ -193,19 +346,45 @@ func (dr *DependencyResolver) validateConfig(config *Config, recognizedDNSTypes
 		}
 	}
 
-	mHost, mPort, err := parseMetricsAddr(config.MetricsAddress)
+	err = validateAddress(MetricsAddressKey, config.MetricsAddress)
 	if err != nil {
-		return fmt.Errorf("invalid %s: expecting MetricsAddress in form {host}:port (%s)", MetricsAddressKey, err)
+		return err
 	}
-	err = field(MetricsAddressKey, mHost).matchRegexps(hostNameRegex, ipAddressRegex).err
+	err = validateAddress(HealthProbeAddressKey, config.HealthProbeAddress)
 	if err != nil {
 		return err
 	}
-	err = field(MetricsAddressKey, mPort).isLessOrEqualTo(65535).isHigherThan(1024).err
+	if config.HealthProbeAddress == config.MetricsAddress {
+		return fmt.Errorf("invalid %s: must differ from %s", HealthProbeAddressKey, MetricsAddressKey)
+	}
+	if config.DebugAddress != "" {
+		err = validateAddress(DebugAddressKey, config.DebugAddress)
+		if err != nil {
+			return err
+		}
+		if config.DebugAddress == config.MetricsAddress || config.DebugAddress == config.HealthProbeAddress {
+			return fmt.Errorf("invalid %s: must differ from %s and %s", DebugAddressKey, MetricsAddressKey, HealthProbeAddressKey)
+		}
+		// debug server is not authenticated and changes log level, so it must not be reachable from outside the pod
+		host, _, _ := parseMetricsAddr(config.DebugAddress)
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return fmt.Errorf("invalid %s: must bind to loopback interface, e.g. 127.0.0.1:8082", DebugAddressKey)
+		}
+	}
+	return nil
+}
+
+// validateAddress validates server address in form {host}:port
+func validateAddress(key, address string) error {
+	host, port, err := parseMetricsAddr(address)
+	if err != nil {
+		return fmt.Errorf("invalid %s: expecting address in form {host}:port (%s)", key, err)
+	}
+	err = field(key, host).matchRegexps(hostNameRegex, ipAddressRegex).err
 	if err != nil {
 		return err
 	}
-	return nil
+	return field(key, port).isLessOrEqualTo(65535).isHigherThan(1024).err
 }
 
 func validateLocalhostNotAmongDNSServers(config *Config) error {
@@ -293,7 +472,8 @@ func parseMetricsAddr(metricsAddr string) (host string, port int, err error) {
 	return
 }
 
-func parseEdgeDNSServers(serverList []string) (r []utils.DNSServer) {
+// ParseEdgeDNSServers parses list of host[:port] values; port defaults to 53, IPv6 and invalid values are skipped
+func ParseEdgeDNSServers(serverList []string) (r []utils.DNSServer) {
 	r = []utils.DNSServer{}
 	var host, portStr string
 	var err error
@@ -378,16 +558,32 @@ func (c *Config) GetClusterNSName() string {
 	return getNsName(c.ClusterGeoTag, c.DNSZone, c.EdgeDNSZone, c.EdgeDNSServers[0].Host)
 }
 
-func (c *Config) GetExternalClusterHeartbeatFQDNs(gslbName string) (m map[string]string) {
+func (c *Config) GetExternalClusterHeartbeatFQDNs() (m map[string]string) {
+	m = make(map[string]string, len(c.ExtClustersGeoTags))
+	for _, tag := range c.ExtClustersGeoTags {
+		m[tag] = getHeartbeatFQDN(tag, c.DNSZone, c.EdgeDNSZone)
+	}
+	return
+}
+
+func (c *Config) GetClusterHeartbeatFQDN() string {
+	return getHeartbeatFQDN(c.ClusterGeoTag, c.DNSZone, c.EdgeDNSZone)
+}
+
+// GetExternalClusterLegacyHeartbeatFQDNs returns heartbeats of external clusters published for annotated resource
+// by previous releases. TODO: remove per-resource heartbeats in the next release
+func (c *Config) GetExternalClusterLegacyHeartbeatFQDNs(resource string) (m map[string]string) {
 	m = make(map[string]string, len(c.ExtClustersGeoTags))
 	for _, tag := range c.ExtClustersGeoTags {
-		m[tag] = getHeartbeatFQDN(gslbName, tag, c.EdgeDNSZone)
+		m[tag] = getLegacyHeartbeatFQDN(resource, tag, c.EdgeDNSZone)
 	}
 	return
 }
 
-func (c *Config) GetClusterHeartbeatFQDN(gslbName string) string {
-	return getHeartbeatFQDN(gslbName, c.ClusterGeoTag, c.EdgeDNSZone)
+// GetClusterLegacyHeartbeatFQDN returns heartbeat of the cluster published for annotated resource, so peers running
+// previous release don't consider the cluster dead. TODO: remove per-resource heartbeats in the next release
+func (c *Config) GetClusterLegacyHeartbeatFQDN(resource string) string {
+	return getLegacyHeartbeatFQDN(resource, c.ClusterGeoTag, c.EdgeDNSZone)
 }
 
 // getNsName returns NS for geo tag.
@@ -403,18 +599,225 @@ func getNsName(tag, dnsZone, edgeDNSZone, edgeDNSServer string) string {
 		return edgeDNSServer
 	}
 	const prefix = "gslb-ns"
-	d := strings.TrimSuffix(dnsZone, "."+edgeDNSZone)
-	domainX := strings.ReplaceAll(d, ".", "-")
-	return fmt.Sprintf("%s-%s-%s.%s", prefix, tag, domainX, edgeDNSZone)
+	return fmt.Sprintf("%s-%s-%s.%s", prefix, tag, getZoneLabel(dnsZone, edgeDNSZone), edgeDNSZone)
 }
 
 // getHeartbeatFQDN returns heartbeat for geo tag.
-// The values is combination of EdgeDNSZone and (Ext)ClusterGeoTag, and GSLB name see:
+// The values is combination of DNSZone, EdgeDNSZone and (Ext)ClusterGeoTag, see:
+// DNS_ZONE k8gb-test.gslb.cloud.example.com
 // EDGE_DNS_ZONE: cloud.example.com
 // CLUSTER_GEOTAG: us
-// gslb.Name: test-gslb-1
-// will generate "test-gslb-1-heartbeat-us.cloud.example.com"
+// will generate "k8gb-test-gslb-heartbeat-us.cloud.example.com"
 // The function is private and expects only valid inputs.
-func getHeartbeatFQDN(name, geoTag, edgeDNSZone string) string {
-	return fmt.Sprintf("%s-heartbeat-%s.%s", name, geoTag, edgeDNSZone)
+func getHeartbeatFQDN(geoTag, dnsZone, edgeDNSZone string) string {
+	return fmt.Sprintf("%s-heartbeat-%s.%s", getZoneLabel(dnsZone, edgeDNSZone), geoTag, edgeDNSZone)
+}
+
+// getLegacyHeartbeatFQDN returns heartbeat for geo tag published per annotated resource by previous releases, see:
+// EDGE_DNS_ZONE: cloud.example.com
+// CLUSTER_GEOTAG: us
+// resource name: test-gslb-1
+// will generate "test-gslb-1-heartbeat-us.cloud.example.com"
+func getLegacyHeartbeatFQDN(resource, geoTag, edgeDNSZone string) string {
+	return fmt.Sprintf("%s-heartbeat-%s.%s", resource, geoTag, edgeDNSZone)
+}
+
+// getZoneLabel returns DNSZone without EdgeDNSZone suffix, where dots are replaced by dashes;
+// e.g. "k8gb-test-gslb" for DNS_ZONE k8gb-test.gslb.cloud.example.com and EDGE_DNS_ZONE cloud.example.com
+func getZoneLabel(dnsZone, edgeDNSZone string) string {
+	d := strings.TrimSuffix(dnsZone, "."+edgeDNSZone)
+	return strings.ReplaceAll(d, ".", "-")
+}
+
+func validateLeaderElection(le LeaderElection) (err error) {
+	err = field(LeaderElectionIDKey, le.ID).isNotEmpty().err
+	if err != nil {
+		return err
+	}
+	err = field(LeaderElectionNamespaceKey, le.Namespace).isNotEmpty().matchRegexp(k8sNamespaceRegex).err
+	if err != nil {
+		return err
+	}
+	err = field(RetryPeriodSecondsKey, le.RetryPeriodSeconds).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(RenewDeadlineSecondsKey, le.RenewDeadlineSeconds).isHigherThan(le.RetryPeriodSeconds).err
+	if err != nil {
+		return err
+	}
+	err = field(LeaseDurationSecondsKey, le.LeaseDurationSeconds).isHigherThan(le.RenewDeadlineSeconds).err
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateConcurrency(config *Config) (err error) {
+	err = field(RequeueJitterPercentKey, config.ReconcileRequeueJitterPercent).isHigherOrEqualToZero().isLessOrEqualTo(100).err
+	if err != nil {
+		return err
+	}
+	err = field(MaxConcurrentReconcilesKey, config.MaxConcurrentReconciles).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	rl := config.RateLimiter
+	err = field(RateLimiterBaseDelayKey, rl.BaseDelayMilliseconds).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(RateLimiterMaxDelayKey, rl.MaxDelaySeconds).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(RateLimiterQPSKey, rl.QPS).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	err = field(RateLimiterBurstKey, rl.Burst).isHigherThanZero().err
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateNotifier(n Notifier) (err error) {
+	err = field(NotifierEndpointsKey, n.Endpoints).hasUniqueItems().err
+	if err != nil {
+		return err
+	}
+	for i, endpoint := range n.Endpoints {
+		u, err := url.ParseRequestURI(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("'%s[%v]' must be absolute http or https URL, got '%s'", NotifierEndpointsKey, i, endpoint)
+		}
+	}
+	if !utils.Contains(NotifierFormats, n.Format) {
+		return fmt.Errorf("'%s' must be one of %v, got '%s'", NotifierFormatKey, NotifierFormats, n.Format)
+	}
+	err = field(NotifierRetriesKey, n.Retries).isHigherOrEqualToZero().err
+	if err != nil {
+		return err
+	}
+	return field(NotifierTimeoutSecondsKey, n.TimeoutSeconds).isHigherThanZero().err
+}
+
+// maxAuditConfigMapSize keeps ConfigMap with audit records far below 1MiB limit of Kubernetes objects
+const maxAuditConfigMapSize = 1000
+
+func validateAudit(config *Config) (err error) {
+	a := config.Audit
+	if !utils.Contains(AuditSinks, a.Sink) {
+		return fmt.Errorf("'%s' must be one of %v, got '%s'", AuditSinkKey, AuditSinks, a.Sink)
+	}
+	switch a.Sink {
+	case AuditSinkFile:
+		err = field(AuditFileKey, a.File).isNotEmpty().err
+		if err != nil {
+			return err
+		}
+		err = field(AuditFileMaxSizeMBKey, a.FileMaxSizeMB).isHigherThanZero().err
+		if err != nil {
+			return err
+		}
+		return field(AuditFileMaxBackupsKey, a.FileMaxBackups).isHigherOrEqualToZero().err
+	case AuditSinkConfigMap:
+		err = field(AuditConfigMapNameKey, a.ConfigMapName).matchRegexp(k8sNameRegex).err
+		if err != nil {
+			return err
+		}
+		if a.ConfigMapName == config.ConfigMapName || a.ConfigMapName == config.DefaultsConfigMapName {
+			return fmt.Errorf("'%s' can't refer to the same ConfigMap as '%s' or '%s'", AuditConfigMapNameKey,
+				ConfigMapNameKey, DefaultsConfigMapNameKey)
+		}
+		return field(AuditConfigMapSizeKey, a.ConfigMapSize).isHigherThanZero().isLessOrEqualTo(maxAuditConfigMapSize).err
+	}
+	return nil
+}
+
+func validateMetricsExporter(config *Config) (err error) {
+	if !utils.Contains(MetricsExporters, config.MetricsExporter) {
+		return fmt.Errorf("'%s' must be one of %v, got '%s'", MetricsExporterKey, MetricsExporters, config.MetricsExporter)
+	}
+	if config.MetricsExporter == MetricsExporterOtlp {
+		err = field(OtelExporterOtlpEndpoint, config.OtelExporterOtlpEndpoint).isNotEmpty().err
+		if err != nil {
+			return err
+		}
+	}
+	return field(MetricsExportIntervalSecondsKey, config.MetricsExportIntervalSeconds).isHigherThanZero().err
+}
+
+func validateTracing(config *Config) (err error) {
+	if !utils.Contains(TracingProtocols, config.TracingProtocol) {
+		return fmt.Errorf("'%s' must be one of %v, got '%s'", TracingProtocolKey, TracingProtocols, config.TracingProtocol)
+	}
+	err = field(OtelExporterOtlpEndpoint, config.OtelExporterOtlpEndpoint).isNotEmpty().err
+	if err != nil {
+		return err
+	}
+	if config.TracingInsecure && (config.TracingCAFile != "" || config.TracingClientCertFile != "") {
+		return fmt.Errorf("'%s' and '%s' require '%s' to be false", TracingCAFileKey, TracingClientCertFileKey, TracingInsecureKey)
+	}
+	if (config.TracingClientCertFile == "") != (config.TracingClientKeyFile == "") {
+		return fmt.Errorf("'%s' and '%s' must be set together", TracingClientCertFileKey, TracingClientKeyFileKey)
+	}
+	_, err = parseKeyValuePairs(config.tracingHeaders)
+	if err != nil {
+		return fmt.Errorf("invalid '%s': %w", TracingHeadersKey, err)
+	}
+	_, err = parseKeyValuePairs(config.tracingResourceAttributes)
+	if err != nil {
+		return fmt.Errorf("invalid '%s': %w", TracingResourceAttributesKey, err)
+	}
+	return nil
+}
+
+// parseKeyValuePairs parses comma separated "key=value" pairs into map. Returns nil map when there are no pairs.
+// Unlike lists bound by env-binder, spaces inside of values are preserved, e.g. "authorization=Bearer <token>"
+func parseKeyValuePairs(pairs string) (m map[string]string, err error) {
+	for _, item := range strings.Split(pairs, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		k, v, found := strings.Cut(item, "=")
+		k = strings.TrimSpace(k)
+		if !found || k == "" {
+			return nil, fmt.Errorf("'%s' is not in key=value format", item)
+		}
+		if m == nil {
+			m = map[string]string{}
+		}
+		m[k] = strings.TrimSpace(v)
+	}
+	return m, nil
+}
+
+func validateIngressSelection(config *Config) (err error) {
+	err = field(WatchNamespacesKey, config.WatchNamespaces).hasUniqueItems().err
+	if err != nil {
+		return err
+	}
+	for i, ns := range config.WatchNamespaces {
+		err = field(fmt.Sprintf("%s[%v]", WatchNamespacesKey, i), ns).isNotEmpty().matchRegexp(k8sNamespaceRegex).err
+		if err != nil {
+			return err
+		}
+	}
+	_, err = labels.Parse(config.IngressLabelSelector)
+	if err != nil {
+		return fmt.Errorf("invalid '%s': %w", IngressLabelSelectorKey, err)
+	}
+	err = field(IngressClassesKey, config.IngressClasses).hasUniqueItems().err
+	if err != nil {
+		return err
+	}
+	for i, class := range config.IngressClasses {
+		err = field(fmt.Sprintf("%s[%v]", IngressClassesKey, i), class).isNotEmpty().err
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,191 @@
+package depresolver
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	encjson "encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ConfigFileKey environment variable with path to YAML or JSON configuration file
+const ConfigFileKey = "CONFIG_FILE"
+
+// configFileKeys maps keys of the configuration file to environment variables. Nested keys are separated by dot
+var configFileKeys = map[string]string{
+	"reconcileRequeueSeconds":             ReconcileRequeueSecondsKey,
+	"reconcileRequeueJitterPercent":       RequeueJitterPercentKey,
+	"maxConcurrentReconciles":             MaxConcurrentReconcilesKey,
+	"rateLimiter.baseDelayMilliseconds":   RateLimiterBaseDelayKey,
+	"rateLimiter.maxDelaySeconds":         RateLimiterMaxDelayKey,
+	"rateLimiter.qps":                     RateLimiterQPSKey,
+	"rateLimiter.burst":                   RateLimiterBurstKey,
+	"notifier.endpoints":                  NotifierEndpointsKey,
+	"notifier.format":                     NotifierFormatKey,
+	"notifier.secret":                     NotifierSecretKey,
+	"notifier.retries":                    NotifierRetriesKey,
+	"notifier.timeoutSeconds":             NotifierTimeoutSecondsKey,
+	"audit.sink":                          AuditSinkKey,
+	"audit.file":                          AuditFileKey,
+	"audit.fileMaxSizeMB":                 AuditFileMaxSizeMBKey,
+	"audit.fileMaxBackups":                AuditFileMaxBackupsKey,
+	"audit.configMapName":                 AuditConfigMapNameKey,
+	"audit.configMapSize":                 AuditConfigMapSizeKey,
+	"clusterGeoTag":                       ClusterGeoTagKey,
+	"extClustersGeoTags":                  ExtClustersGeoTagsKey,
+	"edgeDNSServers":                      EdgeDNSServersKey,
+	"edgeDNSZone":                         EdgeDNSZoneKey,
+	"dnsZone":                             DNSZoneKey,
+	"k8gbNamespace":                       K8gbNamespaceKey,
+	"infoblox.host":                       InfobloxGridHostKey,
+	"infoblox.version":                    InfobloxVersionKey,
+	"infoblox.port":                       InfobloxPortKey,
+	"infoblox.username":                   InfobloxUsernameKey,
+	"infoblox.password":                   InfobloxPasswordKey,
+	"infoblox.httpRequestTimeout":         InfobloxHTTPRequestTimeoutKey,
+	"infoblox.httpPoolConnections":        InfobloxHTTPPoolConnectionsKey,
+	"coreDNSExposed":                      CoreDNSExposedKey,
+	"log.level":                           LogLevelKey,
+	"log.format":                          LogFormatKey,
+	"log.noColor":                         LogNoColorKey,
+	"leaderElection.enabled":              LeaderElectionEnabledKey,
+	"leaderElection.id":                   LeaderElectionIDKey,
+	"leaderElection.namespace":            LeaderElectionNamespaceKey,
+	"leaderElection.leaseDurationSeconds": LeaseDurationSecondsKey,
+	"leaderElection.renewDeadlineSeconds": RenewDeadlineSecondsKey,
+	"leaderElection.retryPeriodSeconds":   RetryPeriodSecondsKey,
+	"watchNamespaces":                     WatchNamespacesKey,
+	"ingressLabelSelector":                IngressLabelSelectorKey,
+	"ingressClasses":                      IngressClassesKey,
+	"configMapName":                       ConfigMapNameKey,
+	"webhookEnabled":                      WebhookEnabledKey,
+	"defaultsConfigMapName":               DefaultsConfigMapNameKey,
+	"namespaceDefaultsEnabled":            NamespaceDefaultsEnabledKey,
+	"metricsAddress":                      MetricsAddressKey,
+	"healthProbeAddress":                  HealthProbeAddressKey,
+	"debugAddress":                        DebugAddressKey,
+	"extDNSEnabled":                       ExtDNSEnabledKey,
+	"splitBrainCheck":                     SplitBrainCheckKey,
+	"splitBrainThresholdSeconds":          SplitBrainThresholdSecondsKey,
+	"zoneDelegationRequeueSeconds":        ZoneDelegationRequeueSecondsKey,
+	"nsRecordTTL":                         NSRecordTTLKey,
+	"dryRun":                              DryRunKey,
+	"decommissionOnShutdown":              DecommissionOnShutdownKey,
+	"decommissionTimeoutSeconds":          DecommissionTimeoutSecondsKey,
+	"tracing.enabled":                     TracingEnabled,
+	"tracing.samplingRatio":               TracingSamplingRatio,
+	"tracing.endpoint":                    OtelExporterOtlpEndpoint,
+	"tracing.protocol":                    TracingProtocolKey,
+	"tracing.insecure":                    TracingInsecureKey,
+	"tracing.caFile":                      TracingCAFileKey,
+	"tracing.clientCertFile":              TracingClientCertFileKey,
+	"tracing.clientKeyFile":               TracingClientKeyFileKey,
+	"tracing.headers":                     TracingHeadersKey,
+	"tracing.resourceAttributes":          TracingResourceAttributesKey,
+	"metrics.exporter":                    MetricsExporterKey,
+	"metrics.exportIntervalSeconds":       MetricsExportIntervalSecondsKey,
+}
+
+// readConfigFile reads configuration file and returns its values keyed by environment variables.
+// Returns nil when no configuration file is set. Unknown keys are recorded and reported by GetConfigFileWarnings
+func (dr *DependencyResolver) readConfigFile() (values map[string]string, err error) {
+	path := dr.configFile
+	if path == "" {
+		path = os.Getenv(ConfigFileKey)
+	}
+	dr.configFileWarnings = nil
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	// YAML is superset of JSON, so both formats are converted to JSON first
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file '%s': %w", path, err)
+	}
+	var content map[string]interface{}
+	err = encjson.Unmarshal(data, &content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing config file '%s': %w", path, err)
+	}
+	values = map[string]string{}
+	var unknown []string
+	for k, v := range flattenConfigFile("", content) {
+		envKey, found := configFileKeys[k]
+		if !found {
+			unknown = append(unknown, k)
+			continue
+		}
+		values[envKey] = v
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		dr.configFileWarnings = append(dr.configFileWarnings, fmt.Sprintf("Unknown key '%s' in config file '%s' is ignored", k, path))
+	}
+	return values, nil
+}
+
+// flattenConfigFile converts nested content to dot separated keys. Lists are converted to comma separated
+// values, the same way as they are passed by environment variables
+func flattenConfigFile(prefix string, content map[string]interface{}) (m map[string]string) {
+	m = map[string]string{}
+	for k, v := range content {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch value := v.(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			for nk, nv := range flattenConfigFile(key, value) {
+				m[nk] = nv
+			}
+		case []interface{}:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, configFileValue(item))
+			}
+			m[key] = strings.Join(items, ",")
+		default:
+			m[key] = configFileValue(value)
+		}
+	}
+	return m
+}
+
+func configFileValue(v interface{}) string {
+	if f, ok := v.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// GetConfigFileWarnings returns unknown keys found in configuration file
+func (dr *DependencyResolver) GetConfigFileWarnings() []string {
+	return dr.configFileWarnings
+}
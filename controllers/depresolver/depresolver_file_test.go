@@ -0,0 +1,139 @@
+package depresolver
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveConfigFromYAMLFile(t *testing.T) {
+	// arrange
+	defer cleanup()
+	resolver := NewDependencyResolverWithConfigFile("testdata/config.yaml")
+	// act
+	config, err := resolver.ResolveOperatorConfig()
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, predefinedConfig, *config)
+	assert.Empty(t, resolver.GetConfigFileWarnings())
+	_, found := os.LookupEnv(DNSZoneKey)
+	assert.False(t, found, "values from config file must not leak into environment")
+}
+
+func TestResolveConfigFromFileInEnvironmentVariable(t *testing.T) {
+	// arrange
+	defer cleanup()
+	defer os.Unsetenv(ConfigFileKey)
+	_ = os.Setenv(ConfigFileKey, "testdata/config.yaml")
+	// act
+	config, err := NewDependencyResolver().ResolveOperatorConfig()
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, predefinedConfig, *config)
+}
+
+func TestResolveConfigEnvironmentOverridesFile(t *testing.T) {
+	// arrange
+	defer cleanup()
+	_ = os.Setenv(ClusterGeoTagKey, "za")
+	_ = os.Setenv(ExtClustersGeoTagsKey, "us,eu")
+	_ = os.Setenv(InfobloxPortKey, "8443")
+	expected := predefinedConfig
+	expected.ClusterGeoTag = "za"
+	expected.ExtClustersGeoTags = []string{"us", "eu"}
+	expected.Infoblox.Port = 8443
+	// act
+	config, err := NewDependencyResolverWithConfigFile("testdata/config.yaml").ResolveOperatorConfig()
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *config)
+	assert.Equal(t, "za", os.Getenv(ClusterGeoTagKey))
+}
+
+func TestReloadOperatorConfigOverridesFile(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.NSRecordTTL = 60
+	// act
+	config, err := NewDependencyResolverWithConfigFile("testdata/config.yaml").
+		ReloadOperatorConfig(map[string]string{NSRecordTTLKey: "60"})
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *config)
+	_, found := os.LookupEnv(NSRecordTTLKey)
+	assert.False(t, found)
+}
+
+func TestResolveConfigFromJSONFileWithUnknownKeys(t *testing.T) {
+	// arrange
+	defer cleanup()
+	resolver := NewDependencyResolverWithConfigFile("testdata/config.json")
+	// act
+	config, err := resolver.ResolveOperatorConfig()
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "us", config.ClusterGeoTag)
+	assert.Equal(t, []string{"za", "eu"}, config.ExtClustersGeoTags)
+	assert.Equal(t, 443, config.Infoblox.Port)
+	assert.Equal(t, DNSTypeInfoblox, config.EdgeDNSType)
+	assert.Equal(t, []string{
+		"Unknown key 'edgeDNSServer' in config file 'testdata/config.json' is ignored",
+		"Unknown key 'infoblox.timeout' in config file 'testdata/config.json' is ignored",
+	}, resolver.GetConfigFileWarnings())
+}
+
+func TestResolveConfigFromInvalidFile(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+	}{
+		{name: "invalid yaml", content: "clusterGeoTag: [us"},
+		{name: "not an object", content: "- us\n- eu"},
+		{name: "invalid value", content: "clusterGeoTag: us\nnsRecordTTL: 0"},
+		{name: "invalid type", content: "clusterGeoTag: us\nnsRecordTTL: thirty"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			configureEnvVar(predefinedConfig)
+			_ = os.Unsetenv(NSRecordTTLKey)
+			path := t.TempDir() + "/config.yaml"
+			assert.NoError(t, os.WriteFile(path, []byte(test.content), 0600))
+			// act
+			_, err := NewDependencyResolverWithConfigFile(path).ResolveOperatorConfig()
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolveConfigFromMissingFile(t *testing.T) {
+	// arrange
+	defer cleanup()
+	configureEnvVar(predefinedConfig)
+	// act
+	_, err := NewDependencyResolverWithConfigFile("testdata/missing.yaml").ResolveOperatorConfig()
+	// assert
+	assert.Error(t, err)
+}
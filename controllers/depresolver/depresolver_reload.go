@@ -0,0 +1,139 @@
+package depresolver
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/k8gb-io/k8gb-light/controllers/utils"
+)
+
+// ReloadableKeys lists environment variables which can be overridden at runtime by the configuration ConfigMap.
+// Other settings are read once at startup and require restart of the operator
+var ReloadableKeys = []string{ExtClustersGeoTagsKey, LogLevelKey, SplitBrainCheckKey, SplitBrainThresholdSecondsKey, NSRecordTTLKey}
+
+// ReloadOperatorConfig reads operator's configuration from environment variables overridden by overrides
+// into a new Config and validates it. Only ReloadableKeys can be overridden. The environment is never
+// modified, so removing a key from overrides restores the value from environment on next reload
+func (dr *DependencyResolver) ReloadOperatorConfig(overrides map[string]string) (*Config, error) {
+	var unknown []string
+	for k := range overrides {
+		if !utils.Contains(ReloadableKeys, k) {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("keys [%s] can't be reloaded, allowed keys [%s]",
+			strings.Join(unknown, ","), strings.Join(ReloadableKeys, ","))
+	}
+
+	return dr.resolveConfig(overrides)
+}
+
+// bindValues binds values into fields of config tagged by the same env name, the same way as env.Bind binds
+// environment variables. It reads values which don't come from the environment without modifying it
+func bindValues(config *Config, values map[string]string) error {
+	return bindStruct(reflect.ValueOf(config).Elem(), values)
+}
+
+func bindStruct(s reflect.Value, values map[string]string) (err error) {
+	for i := 0; i < s.NumField(); i++ {
+		f := s.Field(i)
+		if f.Kind() == reflect.Struct {
+			if err = bindStruct(f, values); err != nil {
+				return err
+			}
+			continue
+		}
+		key := strings.TrimSpace(strings.Split(s.Type().Field(i).Tag.Get("env"), ",")[0])
+		value, found := values[key]
+		if key == "" || !found {
+			continue
+		}
+		// env.Bind binds unexported fields too
+		f = reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem() // #nosec G103
+		switch f.Interface().(type) {
+		case string:
+			f.SetString(value)
+		case bool:
+			var b bool
+			if b, err = strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("can't parse %s value '%s' to bool", key, value)
+			}
+			f.SetBool(b)
+		case int, float64:
+			var n float64
+			if n, err = strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("can't parse %s value '%s' to number", key, value)
+			}
+			if f.Kind() == reflect.Int {
+				f.SetInt(int64(n))
+				continue
+			}
+			f.SetFloat(n)
+		case []string:
+			f.Set(reflect.ValueOf(splitValues(value)))
+		default:
+			return fmt.Errorf("unsupported type %s of %s", f.Type(), key)
+		}
+	}
+	return nil
+}
+
+// splitValues splits comma separated list and removes all whitespaces the same way as env.Bind does
+func splitValues(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(strings.ReplaceAll(value, " ", ""), ",")
+}
+
+type configKey struct{}
+
+// WithConfig returns copy of ctx carrying configuration c. Loops pass snapshot of the shared configuration
+// down to providers, so reloadable settings don't change in the middle of reconciliation
+func WithConfig(ctx context.Context, c *Config) context.Context {
+	return context.WithValue(ctx, configKey{}, c)
+}
+
+// ConfigFromContext returns configuration carried by ctx, or fallback when ctx carries none
+func ConfigFromContext(ctx context.Context, fallback *Config) *Config {
+	if c, ok := ctx.Value(configKey{}).(*Config); ok {
+		return c
+	}
+	return fallback
+}
+
+// ApplyReloadable copies settings which can be changed at runtime from source. The caller is responsible
+// for synchronisation with readers of the configuration
+func (c *Config) ApplyReloadable(source *Config) {
+	c.ExtClustersGeoTags = source.ExtClustersGeoTags
+	c.Log.Level = source.Log.Level
+	c.Log.level = source.Log.level
+	c.SplitBrainCheck = source.SplitBrainCheck
+	c.SplitBrainThresholdSeconds = source.SplitBrainThresholdSeconds
+	c.NSRecordTTL = source.NSRecordTTL
+}
@@ -0,0 +1,154 @@
+package depresolver
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadOperatorConfig(t *testing.T) {
+	// arrange
+	defer cleanup()
+	configureEnvVar(predefinedConfig)
+	expected := predefinedConfig
+	expected.ExtClustersGeoTags = []string{"za", "eu", "uk"}
+	expected.Log.Level = zerolog.TraceLevel
+	expected.Log.level = "trace"
+	expected.NSRecordTTL = 60
+	// act
+	config, err := NewDependencyResolver().ReloadOperatorConfig(map[string]string{
+		ExtClustersGeoTagsKey: "za,eu,uk",
+		LogLevelKey:           "trace",
+		NSRecordTTLKey:        "60",
+	})
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, *config)
+	assert.Equal(t, "za,eu", os.Getenv(ExtClustersGeoTagsKey))
+	assert.Equal(t, "debug", os.Getenv(LogLevelKey))
+	assert.Equal(t, "30", os.Getenv(NSRecordTTLKey))
+}
+
+func TestReloadOperatorConfigRestoresUnsetEnvironment(t *testing.T) {
+	// arrange
+	defer cleanup()
+	configureEnvVar(predefinedConfig)
+	_ = os.Unsetenv(NSRecordTTLKey)
+	// act
+	config, err := NewDependencyResolver().ReloadOperatorConfig(map[string]string{NSRecordTTLKey: "60"})
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 60, config.NSRecordTTL)
+	_, found := os.LookupEnv(NSRecordTTLKey)
+	assert.False(t, found)
+}
+
+func TestReloadOperatorConfigWithInvalidValue(t *testing.T) {
+	// arrange
+	defer cleanup()
+	configureEnvVar(predefinedConfig)
+	// act
+	_, err := NewDependencyResolver().ReloadOperatorConfig(map[string]string{SplitBrainThresholdSecondsKey: "0"})
+	// assert
+	assert.Error(t, err)
+	assert.Equal(t, "300", os.Getenv(SplitBrainThresholdSecondsKey))
+}
+
+func TestReloadOperatorConfigWithNotReloadableKeys(t *testing.T) {
+	// arrange
+	defer cleanup()
+	configureEnvVar(predefinedConfig)
+	// act
+	config, err := NewDependencyResolver().ReloadOperatorConfig(map[string]string{
+		NSRecordTTLKey:   "60",
+		DNSZoneKey:       "cloud.example.org",
+		ClusterGeoTagKey: "uk",
+	})
+	// assert
+	assert.EqualError(t, err, "keys [CLUSTER_GEO_TAG,DNS_ZONE] can't be reloaded, allowed keys "+
+		"[EXT_GSLB_CLUSTERS_GEO_TAGS,LOG_LEVEL,SPLIT_BRAIN_CHECK,SPLIT_BRAIN_THRESHOLD_SECONDS,NS_RECORD_TTL]")
+	assert.Nil(t, config)
+}
+
+func TestApplyReloadable(t *testing.T) {
+	// arrange
+	config := predefinedConfig
+	source := predefinedConfig
+	source.ExtClustersGeoTags = []string{"uk"}
+	source.Log.Level = zerolog.ErrorLevel
+	source.SplitBrainCheck = false
+	source.SplitBrainThresholdSeconds = 600
+	source.NSRecordTTL = 90
+	source.DNSZone = "cloud.example.org"
+	expected := source
+	expected.DNSZone = predefinedConfig.DNSZone
+	// act
+	config.ApplyReloadable(&source)
+	// assert
+	assert.Equal(t, expected, config)
+}
+
+func TestReloadOperatorConfigWithMalformedValue(t *testing.T) {
+	var tests = []struct {
+		name      string
+		overrides map[string]string
+	}{
+		{name: "bool", overrides: map[string]string{SplitBrainCheckKey: "maybe"}},
+		{name: "int", overrides: map[string]string{NSRecordTTLKey: "sixty"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			configureEnvVar(predefinedConfig)
+			// act
+			_, err := NewDependencyResolver().ReloadOperatorConfig(test.overrides)
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReloadOperatorConfigWithList(t *testing.T) {
+	// arrange
+	defer cleanup()
+	configureEnvVar(predefinedConfig)
+	// act
+	config, err := NewDependencyResolver().ReloadOperatorConfig(map[string]string{ExtClustersGeoTagsKey: "za, eu, uk"})
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"za", "eu", "uk"}, config.ExtClustersGeoTags)
+}
+
+func TestConfigFromContext(t *testing.T) {
+	// arrange
+	config := predefinedConfig
+	fallback := predefinedConfig
+	// act
+	carried := ConfigFromContext(WithConfig(context.TODO(), &config), &fallback)
+	missing := ConfigFromContext(context.TODO(), &fallback)
+	// assert
+	assert.Same(t, &config, carried)
+	assert.Same(t, &fallback, missing)
+}
@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	encjson "encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -46,23 +47,76 @@ const (
 )
 
 var predefinedConfig = Config{
-	ReconcileRequeueSeconds: 30,
-	ClusterGeoTag:           "us",
-	ExtClustersGeoTags:      []string{"za", "eu"},
-	EdgeDNSType:             DNSTypeInfoblox,
+	ReconcileRequeueSeconds:       30,
+	ReconcileRequeueJitterPercent: 20,
+	MaxConcurrentReconciles:       4,
+	RateLimiter: RateLimiter{
+		BaseDelayMilliseconds: 10,
+		MaxDelaySeconds:       300,
+		QPS:                   50,
+		Burst:                 200,
+	},
+	Notifier: Notifier{
+		Endpoints:      []string{"https://hooks.example.com/k8gb"},
+		Format:         "slack",
+		Secret:         "s3cr3t",
+		Retries:        5,
+		TimeoutSeconds: 10,
+	},
+	Audit: Audit{
+		Sink:           "configmap",
+		File:           "/var/log/k8gb/audit.log",
+		FileMaxSizeMB:  20,
+		FileMaxBackups: 5,
+		ConfigMapName:  "k8gb-audit",
+		ConfigMapSize:  50,
+	},
+	ClusterGeoTag:      "us",
+	ExtClustersGeoTags: []string{"za", "eu"},
+	EdgeDNSType:        DNSTypeInfoblox,
 	EdgeDNSServers: []utils.DNSServer{
 		{
 			Host: "dns.cloud.example.com",
 			Port: 53,
 		},
 	},
-	fallbackEdgeDNSServerName: "",
-	fallbackEdgeDNSServerPort: 53,
-	EdgeDNSZone:               "example.com",
-	DNSZone:                   defaultEdgeDNSZone,
-	K8gbNamespace:             "k8gb",
-	SplitBrainCheck:           true,
-	MetricsAddress:            "0.0.0.0:8080",
+	fallbackEdgeDNSServerName:    "",
+	fallbackEdgeDNSServerPort:    53,
+	EdgeDNSZone:                  "example.com",
+	DNSZone:                      defaultEdgeDNSZone,
+	K8gbNamespace:                "k8gb",
+	SplitBrainCheck:              true,
+	SplitBrainThresholdSeconds:   300,
+	ZoneDelegationRequeueSeconds: 30,
+	NSRecordTTL:                  30,
+	DecommissionTimeoutSeconds:   60,
+	MetricsAddress:               "0.0.0.0:8080",
+	MetricsExporter:              "prometheus",
+	MetricsExportIntervalSeconds: 15,
+	TracingProtocol:              "grpc",
+	TracingCAFile:                "/etc/k8gb/tracing/ca.crt",
+	TracingClientCertFile:        "/etc/k8gb/tracing/tls.crt",
+	TracingClientKeyFile:         "/etc/k8gb/tracing/tls.key",
+	TracingHeaders:               map[string]string{"x-api-key": "s3cr3t"},
+	TracingResourceAttributes:    map[string]string{"deployment.environment": "test", "k8s.cluster.name": "us-1"},
+	tracingHeaders:               "x-api-key=s3cr3t",
+	tracingResourceAttributes:    "deployment.environment=test,k8s.cluster.name=us-1",
+	HealthProbeAddress:           "0.0.0.0:8081",
+	DebugAddress:                 "127.0.0.1:8082",
+	WatchNamespaces:              []string{"team-a", "team-b"},
+	IngressLabelSelector:         "k8gb.io/owner in (team-a,team-b)",
+	IngressClasses:               []string{"nginx"},
+	ConfigMapName:                "k8gb-config",
+	DefaultsConfigMapName:        "k8gb-defaults",
+	NamespaceDefaultsEnabled:     true,
+	LeaderElection: LeaderElection{
+		Enabled:              true,
+		ID:                   "8020e9ff.absa.oss",
+		Namespace:            "k8gb",
+		LeaseDurationSeconds: 15,
+		RenewDeadlineSeconds: 10,
+		RetryPeriodSeconds:   2,
+	},
 	Infoblox: Infoblox{
 		"Infoblox.host.com",
 		"0.0.3",
@@ -1007,8 +1061,427 @@ func TestResolveConfigSplitBrainCheckEnabled(t *testing.T) {
 	arrangeVariablesAndAssert(t, expected, assert.NoError)
 }
 
+func TestResolveConfigWithZeroSplitBrainThresholdSeconds(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.SplitBrainThresholdSeconds = 0
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigWithZeroZoneDelegationRequeueSeconds(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.ZoneDelegationRequeueSeconds = 0
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigWithNegativeNSRecordTTL(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.NSRecordTTL = -1
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigDryRunEnabled(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.DryRun = true
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestResolveConfigDecommissionOnShutdown(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.DecommissionOnShutdown = true
+	expected.DecommissionTimeoutSeconds = 120
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestResolveConfigDecommissionDefaultTimeout(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, DecommissionTimeoutSecondsKey)
+}
+
+func TestResolveConfigWithZeroDecommissionTimeout(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.DecommissionTimeoutSeconds = 0
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigLeaderElectionDisabled(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.LeaderElection.Enabled = false
+	expected.LeaderElection.RenewDeadlineSeconds = 100
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestResolveConfigLeaderElectionNamespaceDefaultsToPodNamespace(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, LeaderElectionNamespaceKey)
+}
+
+func TestResolveConfigLeaderElectionWithDefaultValues(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, LeaderElectionIDKey, LeaseDurationSecondsKey,
+		RenewDeadlineSecondsKey, RetryPeriodSecondsKey)
+}
+
+func TestResolveConfigLeaderElectionWithInvalidNamespace(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.LeaderElection.Namespace = "Invalid_Namespace"
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigLeaderElectionWithInvalidDurations(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		leaseDurationSeconds int
+		renewDeadlineSeconds int
+		retryPeriodSeconds   int
+	}{
+		{name: "renew deadline equal to lease duration", leaseDurationSeconds: 10, renewDeadlineSeconds: 10, retryPeriodSeconds: 2},
+		{name: "renew deadline higher than lease duration", leaseDurationSeconds: 10, renewDeadlineSeconds: 15, retryPeriodSeconds: 2},
+		{name: "retry period equal to renew deadline", leaseDurationSeconds: 15, renewDeadlineSeconds: 10, retryPeriodSeconds: 10},
+		{name: "zero retry period", leaseDurationSeconds: 15, renewDeadlineSeconds: 10, retryPeriodSeconds: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			expected.LeaderElection.LeaseDurationSeconds = test.leaseDurationSeconds
+			expected.LeaderElection.RenewDeadlineSeconds = test.renewDeadlineSeconds
+			expected.LeaderElection.RetryPeriodSeconds = test.retryPeriodSeconds
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestResolveConfigWithDefaultConcurrency(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.ReconcileRequeueJitterPercent = 10
+	expected.MaxConcurrentReconciles = 1
+	expected.RateLimiter = RateLimiter{BaseDelayMilliseconds: 5, MaxDelaySeconds: 1000, QPS: 10, Burst: 100}
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, RequeueJitterPercentKey, MaxConcurrentReconcilesKey,
+		RateLimiterBaseDelayKey, RateLimiterMaxDelayKey, RateLimiterQPSKey, RateLimiterBurstKey)
+}
+
+func TestResolveConfigWithInvalidConcurrency(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config func(c *Config)
+	}{
+		{name: "negative jitter", config: func(c *Config) { c.ReconcileRequeueJitterPercent = -1 }},
+		{name: "jitter higher than 100 percent", config: func(c *Config) { c.ReconcileRequeueJitterPercent = 101 }},
+		{name: "zero concurrent reconciles", config: func(c *Config) { c.MaxConcurrentReconciles = 0 }},
+		{name: "zero base delay", config: func(c *Config) { c.RateLimiter.BaseDelayMilliseconds = 0 }},
+		{name: "zero max delay", config: func(c *Config) { c.RateLimiter.MaxDelaySeconds = 0 }},
+		{name: "zero qps", config: func(c *Config) { c.RateLimiter.QPS = 0 }},
+		{name: "negative burst", config: func(c *Config) { c.RateLimiter.Burst = -1 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			test.config(&expected)
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestResolveConfigWithDefaultNotifier(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.Notifier = Notifier{Endpoints: []string{}, Format: "json", Retries: 3, TimeoutSeconds: 5}
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, NotifierEndpointsKey, NotifierFormatKey,
+		NotifierSecretKey, NotifierRetriesKey, NotifierTimeoutSecondsKey)
+}
+
+func TestResolveConfigWithInvalidNotifier(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config func(c *Config)
+	}{
+		{name: "relative endpoint", config: func(c *Config) { c.Notifier.Endpoints = []string{"/k8gb"} }},
+		{name: "unsupported scheme", config: func(c *Config) { c.Notifier.Endpoints = []string{"ftp://hooks.example.com"} }},
+		{name: "redundant endpoints", config: func(c *Config) {
+			c.Notifier.Endpoints = []string{"https://hooks.example.com", "https://hooks.example.com"}
+		}},
+		{name: "unknown format", config: func(c *Config) { c.Notifier.Format = "xml" }},
+		{name: "negative retries", config: func(c *Config) { c.Notifier.Retries = -1 }},
+		{name: "zero timeout", config: func(c *Config) { c.Notifier.TimeoutSeconds = 0 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			test.config(&expected)
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestResolveConfigWithDefaultAudit(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.Audit = Audit{Sink: "none", File: "/tmp/k8gb-audit.log", FileMaxSizeMB: 10, FileMaxBackups: 3,
+		ConfigMapName: "k8gb-audit", ConfigMapSize: 100}
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, AuditSinkKey, AuditFileKey, AuditFileMaxSizeMBKey,
+		AuditFileMaxBackupsKey, AuditConfigMapNameKey, AuditConfigMapSizeKey)
+}
+
+func TestResolveConfigWithInvalidAudit(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config func(c *Config)
+	}{
+		{name: "unknown sink", config: func(c *Config) { c.Audit.Sink = "syslog" }},
+		{name: "empty file", config: func(c *Config) { c.Audit.Sink = "file"; c.Audit.File = "" }},
+		{name: "zero file size", config: func(c *Config) { c.Audit.Sink = "file"; c.Audit.FileMaxSizeMB = 0 }},
+		{name: "negative file backups", config: func(c *Config) { c.Audit.Sink = "file"; c.Audit.FileMaxBackups = -1 }},
+		{name: "invalid ConfigMap name", config: func(c *Config) { c.Audit.ConfigMapName = "K8GB_AUDIT" }},
+		{name: "reused ConfigMap", config: func(c *Config) { c.Audit.ConfigMapName = c.ConfigMapName }},
+		{name: "zero ConfigMap size", config: func(c *Config) { c.Audit.ConfigMapSize = 0 }},
+		{name: "too big ConfigMap size", config: func(c *Config) { c.Audit.ConfigMapSize = 1001 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			test.config(&expected)
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestResolveConfigWithDefaultMetricsExporter(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.MetricsExporter = "prometheus"
+	expected.MetricsExportIntervalSeconds = 30
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, MetricsExporterKey, MetricsExportIntervalSecondsKey)
+}
+
+func TestResolveConfigWithOtlpMetricsExporter(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.MetricsExporter = "otlp"
+	expected.OtelExporterOtlpEndpoint = "otel-collector.monitoring:4318"
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestResolveConfigWithInvalidMetricsExporter(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config func(c *Config)
+	}{
+		{name: "unknown exporter", config: func(c *Config) { c.MetricsExporter = "statsd" }},
+		{name: "otlp without endpoint", config: func(c *Config) { c.MetricsExporter = "otlp"; c.OtelExporterOtlpEndpoint = "" }},
+		{name: "zero export interval", config: func(c *Config) { c.MetricsExportIntervalSeconds = 0 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			test.config(&expected)
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestConfigJSONOmitsCredentials(t *testing.T) {
+	// arrange
+	config := predefinedConfig
+	config.Infoblox.Password = "infoblox-password"
+	config.Notifier.Secret = "notifier-secret"
+	config.TracingHeaders = map[string]string{"Authorization": "tracing-token"}
+	// act
+	b, err := encjson.Marshal(config)
+	// assert
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "infoblox-password")
+	assert.NotContains(t, string(b), "notifier-secret")
+	assert.NotContains(t, string(b), "tracing-token")
+}
+
+func TestResolveConfigWithDefaultTracingExporter(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.TracingEnabled = true
+	expected.OtelExporterOtlpEndpoint = "localhost:4318"
+	expected.TracingProtocol = "http/protobuf"
+	expected.TracingInsecure = true
+	expected.TracingCAFile = ""
+	expected.TracingClientCertFile = ""
+	expected.TracingClientKeyFile = ""
+	expected.TracingHeaders = nil
+	expected.TracingResourceAttributes = nil
+	expected.tracingHeaders = ""
+	expected.tracingResourceAttributes = ""
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, OtelExporterOtlpEndpoint, TracingProtocolKey, TracingInsecureKey,
+		TracingCAFileKey, TracingClientCertFileKey, TracingClientKeyFileKey, TracingHeadersKey, TracingResourceAttributesKey)
+}
+
+func TestResolveConfigWithGRPCTracingExporter(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.TracingEnabled = true
+	expected.OtelExporterOtlpEndpoint = "otel-collector.monitoring:4317"
+	expected.tracingHeaders = "authorization=Bearer abc=, x-tenant = team-a ,"
+	expected.TracingHeaders = map[string]string{"authorization": "Bearer abc=", "x-tenant": "team-a"}
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestResolveConfigWithInvalidTracingExporter(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config func(c *Config)
+	}{
+		{name: "unknown protocol", config: func(c *Config) { c.TracingProtocol = "thrift" }},
+		{name: "empty endpoint", config: func(c *Config) { c.OtelExporterOtlpEndpoint = "" }},
+		{name: "CA with insecure", config: func(c *Config) { c.TracingInsecure = true; c.TracingClientCertFile = ""; c.TracingClientKeyFile = "" }},
+		{name: "client cert with insecure", config: func(c *Config) { c.TracingInsecure = true; c.TracingCAFile = "" }},
+		{name: "client cert without key", config: func(c *Config) { c.TracingClientKeyFile = "" }},
+		{name: "client key without cert", config: func(c *Config) { c.TracingClientCertFile = "" }},
+		{name: "header without value", config: func(c *Config) { c.tracingHeaders = "x-api-key"; c.TracingHeaders = nil }},
+		{name: "resource attribute without key", config: func(c *Config) { c.tracingResourceAttributes = "=test"; c.TracingResourceAttributes = nil }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			expected.TracingEnabled = true
+			expected.OtelExporterOtlpEndpoint = "otel-collector.monitoring:4317"
+			test.config(&expected)
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestResolveConfigIgnoresTracingExporterWhenDisabled(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.TracingProtocol = "thrift"
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestResolveConfigWithoutConfigMap(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.ConfigMapName = ""
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, ConfigMapNameKey)
+}
+
+func TestResolveConfigWithInvalidConfigMapName(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.ConfigMapName = "K8gb_Config"
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigWithDefaultIngressSelection(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.WatchNamespaces = []string{}
+	expected.IngressLabelSelector = ""
+	expected.IngressClasses = []string{}
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, WatchNamespacesKey, IngressLabelSelectorKey, IngressClassesKey)
+}
+
+func TestResolveConfigWithInvalidIngressSelection(t *testing.T) {
+	var tests = []struct {
+		name   string
+		config func(c *Config)
+	}{
+		{name: "invalid watch namespace", config: func(c *Config) { c.WatchNamespaces = []string{"team-a", "Team_B"} }},
+		{name: "duplicate watch namespace", config: func(c *Config) { c.WatchNamespaces = []string{"team-a", "team-a"} }},
+		{name: "invalid label selector", config: func(c *Config) { c.IngressLabelSelector = "k8gb.io/owner in team-a" }},
+		{name: "duplicate ingress class", config: func(c *Config) { c.IngressClasses = []string{"nginx", "nginx"} }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			defer cleanup()
+			expected := predefinedConfig
+			test.config(&expected)
+			// act,assert
+			arrangeVariablesAndAssert(t, expected, assert.Error)
+		})
+	}
+}
+
+func TestResolveConfigWithDefaultZoneDelegationValues(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError,
+		SplitBrainThresholdSecondsKey, ZoneDelegationRequeueSecondsKey, NSRecordTTLKey)
+}
+
 func TestHeartBeatWithMultipleExtClusterGeoTag(t *testing.T) {
-	const geoTag = "test-gslb-1"
 	// arrange
 	defer cleanup()
 	customConfig := predefinedConfig
@@ -1024,17 +1497,16 @@ func TestHeartBeatWithMultipleExtClusterGeoTag(t *testing.T) {
 
 	// assert
 	assert.NoError(t, err)
-	assert.Len(t, config.GetExternalClusterHeartbeatFQDNs(geoTag), 2)
-	assert.Equal(t, "test-gslb-1-heartbeat-us-west-1.cloud.example.com", config.GetClusterHeartbeatFQDN(geoTag))
+	assert.Len(t, config.GetExternalClusterHeartbeatFQDNs(), 2)
+	assert.Equal(t, "k8gb-test-preprod-gslb-heartbeat-us-west-1.cloud.example.com", config.GetClusterHeartbeatFQDN())
 
-	for k, v := range map[string]string{defaultClusterGeoTagUs2: "test-gslb-1-heartbeat-us-east-1.cloud.example.com",
-		defaultClusterGeoTagEu: "test-gslb-1-heartbeat-eu-central-1.cloud.example.com"} {
-		assert.Equal(t, config.GetExternalClusterHeartbeatFQDNs(geoTag)[k], v)
+	for k, v := range map[string]string{defaultClusterGeoTagUs2: "k8gb-test-preprod-gslb-heartbeat-us-east-1.cloud.example.com",
+		defaultClusterGeoTagEu: "k8gb-test-preprod-gslb-heartbeat-eu-central-1.cloud.example.com"} {
+		assert.Equal(t, config.GetExternalClusterHeartbeatFQDNs()[k], v)
 	}
 }
 
 func TestHeartBeatWithOneExtClusterGeoTag(t *testing.T) {
-	const geoTag = "test-gslb-1"
 	// arrange
 	defer cleanup()
 	customConfig := predefinedConfig
@@ -1050,9 +1522,21 @@ func TestHeartBeatWithOneExtClusterGeoTag(t *testing.T) {
 
 	// assert
 	assert.NoError(t, err)
-	assert.Len(t, config.GetExternalClusterHeartbeatFQDNs(geoTag), 1)
-	assert.Equal(t, "test-gslb-1-heartbeat-us-west-1.cloud.example.com", config.GetClusterHeartbeatFQDN(geoTag))
-	assert.Equal(t, config.GetExternalClusterHeartbeatFQDNs(geoTag)[defaultClusterGeoTagUs2], "test-gslb-1-heartbeat-us-east-1.cloud.example.com")
+	assert.Len(t, config.GetExternalClusterHeartbeatFQDNs(), 1)
+	assert.Equal(t, "k8gb-test-preprod-gslb-heartbeat-us-west-1.cloud.example.com", config.GetClusterHeartbeatFQDN())
+	assert.Equal(t, config.GetExternalClusterHeartbeatFQDNs()[defaultClusterGeoTagUs2], "k8gb-test-preprod-gslb-heartbeat-us-east-1.cloud.example.com")
+}
+
+func TestLegacyHeartBeat(t *testing.T) {
+	// arrange
+	config := &Config{ClusterGeoTag: defaultClusterGeoTagUs1, ExtClustersGeoTags: []string{defaultClusterGeoTagUs2},
+		EdgeDNSZone: defaultEdgeDNSZone, DNSZone: defaultDNSZone}
+	// act
+	cluster := config.GetClusterLegacyHeartbeatFQDN("test-gslb")
+	external := config.GetExternalClusterLegacyHeartbeatFQDNs("test-gslb")
+	// assert
+	assert.Equal(t, "test-gslb-heartbeat-us-west-1.cloud.example.com", cluster)
+	assert.Equal(t, map[string]string{defaultClusterGeoTagUs2: "test-gslb-heartbeat-us-east-1.cloud.example.com"}, external)
 }
 
 func TestNsServerNamesWithMultipleExtClusterGeoTag(t *testing.T) {
@@ -1317,6 +1801,120 @@ func TestMetricsAddressWithValidHostName(t *testing.T) {
 	arrangeVariablesAndAssert(t, expected, assert.NoError)
 }
 
+func TestHealthProbeAddress(t *testing.T) {
+	var tests = []struct {
+		address string
+		assert  assert.ErrorAssertionFunc
+	}{
+		{address: "0.0.0.0:9440", assert: assert.NoError},
+		{address: ":8081", assert: assert.NoError},
+		{address: "0.0.0.0:1024", assert: assert.Error},
+		{address: "0.0.0.0:808x", assert: assert.Error},
+		{address: "invalid", assert: assert.Error},
+		{address: "0.0.0.0:8080", assert: assert.Error},
+	}
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			defer cleanup()
+			expected := predefinedConfig
+			expected.HealthProbeAddress = test.address
+			arrangeVariablesAndAssert(t, expected, test.assert)
+		})
+	}
+}
+
+func TestDebugAddress(t *testing.T) {
+	var tests = []struct {
+		address string
+		assert  assert.ErrorAssertionFunc
+	}{
+		{address: "", assert: assert.NoError},
+		{address: "127.0.0.1:9440", assert: assert.NoError},
+		{address: "localhost:9440", assert: assert.NoError},
+		{address: "0.0.0.0:9440", assert: assert.Error},
+		{address: "10.0.0.1:9440", assert: assert.Error},
+		{address: "127.0.0.1:1024", assert: assert.Error},
+		{address: "invalid", assert: assert.Error},
+		{address: "0.0.0.0:8080", assert: assert.Error},
+		{address: "0.0.0.0:8081", assert: assert.Error},
+	}
+	for _, test := range tests {
+		t.Run(test.address, func(t *testing.T) {
+			defer cleanup()
+			expected := predefinedConfig
+			expected.DebugAddress = test.address
+			arrangeVariablesAndAssert(t, expected, test.assert)
+		})
+	}
+}
+
+func TestResolveConfigWithoutDefaultsConfigMapName(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.DefaultsConfigMapName = ""
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.NoError, DefaultsConfigMapNameKey)
+}
+
+func TestResolveConfigWithInvalidDefaultsConfigMapName(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.DefaultsConfigMapName = "K8gb_Defaults"
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestResolveConfigWithDefaultsConfigMapNameEqualToConfigMapName(t *testing.T) {
+	// arrange
+	defer cleanup()
+	expected := predefinedConfig
+	expected.DefaultsConfigMapName = expected.ConfigMapName
+	// act,assert
+	arrangeVariablesAndAssert(t, expected, assert.Error)
+}
+
+func TestWebhookEnabled(t *testing.T) {
+	defer cleanup()
+	expected := predefinedConfig
+	expected.WebhookEnabled = true
+	arrangeVariablesAndAssert(t, expected, assert.NoError)
+}
+
+func TestValidateGeoTags(t *testing.T) {
+	var tests = []struct {
+		name    string
+		geoTags []string
+		isValid bool
+	}{
+		{name: "cluster geo tag", geoTags: []string{"us"}, isValid: true},
+		{name: "cluster and external geo tags", geoTags: []string{"za", "us"}, isValid: true},
+		{name: "no geo tags", geoTags: []string{}, isValid: true},
+		{name: "unknown geo tag", geoTags: []string{"us", "cz"}, isValid: false},
+		{name: "empty geo tag", geoTags: []string{""}, isValid: false},
+		{name: "invalid geo tag", geoTags: []string{"u s"}, isValid: false},
+		{name: "redundant geo tags", geoTags: []string{"us", "us"}, isValid: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			config := &Config{ClusterGeoTag: "us", ExtClustersGeoTags: []string{"za", "eu"}}
+			// act
+			err := config.ValidateGeoTags("geo", test.geoTags)
+			// assert
+			assert.Equal(t, test.isValid, err == nil)
+		})
+	}
+}
+
+func TestValidateNumbers(t *testing.T) {
+	assert.NoError(t, ValidateHigherThanZero("ttl", 1))
+	assert.Error(t, ValidateHigherThanZero("ttl", 0))
+	assert.NoError(t, ValidateHigherOrEqualToZero("weight", 0))
+	assert.Error(t, ValidateHigherOrEqualToZero("weight", -1))
+}
+
 // arrangeVariablesAndAssert sets string environment variables and asserts `expected` argument with
 // ResolveOperatorConfig() output. The last parameter unsets the values
 func arrangeVariablesAndAssert(t *testing.T, expected Config,
@@ -1340,8 +1938,17 @@ func cleanup() {
 	for _, s := range []string{ReconcileRequeueSecondsKey, ClusterGeoTagKey, ExtClustersGeoTagsKey, EdgeDNSZoneKey, DNSZoneKey, EdgeDNSServersKey,
 		ExtDNSEnabledKey, InfobloxGridHostKey, InfobloxVersionKey, InfobloxPortKey, InfobloxUsernameKey,
 		InfobloxPasswordKey, K8gbNamespaceKey, CoreDNSExposedKey, InfobloxHTTPRequestTimeoutKey,
-		InfobloxHTTPPoolConnectionsKey, LogLevelKey, LogFormatKey, LogNoColorKey, MetricsAddressKey, SplitBrainCheckKey, TracingEnabled,
-		TracingSamplingRatio, OtelExporterOtlpEndpoint} {
+		InfobloxHTTPPoolConnectionsKey, LogLevelKey, LogFormatKey, LogNoColorKey, MetricsAddressKey, HealthProbeAddressKey, DebugAddressKey, SplitBrainCheckKey, SplitBrainThresholdSecondsKey,
+		ZoneDelegationRequeueSecondsKey, NSRecordTTLKey, DryRunKey, LeaderElectionEnabledKey, LeaderElectionIDKey,
+		DecommissionOnShutdownKey, DecommissionTimeoutSecondsKey,
+		LeaderElectionNamespaceKey, LeaseDurationSecondsKey, RenewDeadlineSecondsKey, RetryPeriodSecondsKey, TracingEnabled,
+		WatchNamespacesKey, IngressLabelSelectorKey, IngressClassesKey, RequeueJitterPercentKey,
+		MaxConcurrentReconcilesKey, RateLimiterBaseDelayKey, RateLimiterMaxDelayKey, RateLimiterQPSKey,
+		RateLimiterBurstKey, ConfigMapNameKey, WebhookEnabledKey, DefaultsConfigMapNameKey, NamespaceDefaultsEnabledKey, NotifierEndpointsKey, NotifierFormatKey,
+		NotifierSecretKey, NotifierRetriesKey, NotifierTimeoutSecondsKey, AuditSinkKey, AuditFileKey, AuditFileMaxSizeMBKey,
+		AuditFileMaxBackupsKey, AuditConfigMapNameKey, AuditConfigMapSizeKey, TracingSamplingRatio, OtelExporterOtlpEndpoint,
+		MetricsExporterKey, MetricsExportIntervalSecondsKey, TracingProtocolKey, TracingInsecureKey, TracingCAFileKey,
+		TracingClientCertFileKey, TracingClientKeyFileKey, TracingHeadersKey, TracingResourceAttributesKey} {
 		if os.Unsetenv(s) != nil {
 			panic(fmt.Errorf("cleanup %s", s))
 		}
@@ -1371,8 +1978,55 @@ func configureEnvVar(config Config) {
 	_ = os.Setenv(LogFormatKey, config.Log.Format.String())
 	_ = os.Setenv(LogNoColorKey, strconv.FormatBool(config.Log.NoColor))
 	_ = os.Setenv(MetricsAddressKey, config.MetricsAddress)
+	_ = os.Setenv(HealthProbeAddressKey, config.HealthProbeAddress)
+	_ = os.Setenv(DebugAddressKey, config.DebugAddress)
 	_ = os.Setenv(SplitBrainCheckKey, strconv.FormatBool(config.SplitBrainCheck))
+	_ = os.Setenv(SplitBrainThresholdSecondsKey, strconv.Itoa(config.SplitBrainThresholdSeconds))
+	_ = os.Setenv(ZoneDelegationRequeueSecondsKey, strconv.Itoa(config.ZoneDelegationRequeueSeconds))
+	_ = os.Setenv(NSRecordTTLKey, strconv.Itoa(config.NSRecordTTL))
+	_ = os.Setenv(DryRunKey, strconv.FormatBool(config.DryRun))
+	_ = os.Setenv(DecommissionOnShutdownKey, strconv.FormatBool(config.DecommissionOnShutdown))
+	_ = os.Setenv(DecommissionTimeoutSecondsKey, strconv.Itoa(config.DecommissionTimeoutSeconds))
+	_ = os.Setenv(LeaderElectionEnabledKey, strconv.FormatBool(config.LeaderElection.Enabled))
+	_ = os.Setenv(LeaderElectionIDKey, config.LeaderElection.ID)
+	_ = os.Setenv(LeaderElectionNamespaceKey, config.LeaderElection.Namespace)
+	_ = os.Setenv(LeaseDurationSecondsKey, strconv.Itoa(config.LeaderElection.LeaseDurationSeconds))
+	_ = os.Setenv(RenewDeadlineSecondsKey, strconv.Itoa(config.LeaderElection.RenewDeadlineSeconds))
+	_ = os.Setenv(RetryPeriodSecondsKey, strconv.Itoa(config.LeaderElection.RetryPeriodSeconds))
+	_ = os.Setenv(RequeueJitterPercentKey, strconv.Itoa(config.ReconcileRequeueJitterPercent))
+	_ = os.Setenv(MaxConcurrentReconcilesKey, strconv.Itoa(config.MaxConcurrentReconciles))
+	_ = os.Setenv(RateLimiterBaseDelayKey, strconv.Itoa(config.RateLimiter.BaseDelayMilliseconds))
+	_ = os.Setenv(RateLimiterMaxDelayKey, strconv.Itoa(config.RateLimiter.MaxDelaySeconds))
+	_ = os.Setenv(RateLimiterQPSKey, strconv.Itoa(config.RateLimiter.QPS))
+	_ = os.Setenv(RateLimiterBurstKey, strconv.Itoa(config.RateLimiter.Burst))
+	_ = os.Setenv(NotifierEndpointsKey, strings.Join(config.Notifier.Endpoints, ","))
+	_ = os.Setenv(NotifierFormatKey, config.Notifier.Format)
+	_ = os.Setenv(NotifierSecretKey, config.Notifier.Secret)
+	_ = os.Setenv(NotifierRetriesKey, strconv.Itoa(config.Notifier.Retries))
+	_ = os.Setenv(NotifierTimeoutSecondsKey, strconv.Itoa(config.Notifier.TimeoutSeconds))
+	_ = os.Setenv(AuditSinkKey, config.Audit.Sink)
+	_ = os.Setenv(AuditFileKey, config.Audit.File)
+	_ = os.Setenv(AuditFileMaxSizeMBKey, strconv.Itoa(config.Audit.FileMaxSizeMB))
+	_ = os.Setenv(AuditFileMaxBackupsKey, strconv.Itoa(config.Audit.FileMaxBackups))
+	_ = os.Setenv(AuditConfigMapNameKey, config.Audit.ConfigMapName)
+	_ = os.Setenv(AuditConfigMapSizeKey, strconv.Itoa(config.Audit.ConfigMapSize))
+	_ = os.Setenv(ConfigMapNameKey, config.ConfigMapName)
+	_ = os.Setenv(WebhookEnabledKey, strconv.FormatBool(config.WebhookEnabled))
+	_ = os.Setenv(DefaultsConfigMapNameKey, config.DefaultsConfigMapName)
+	_ = os.Setenv(NamespaceDefaultsEnabledKey, strconv.FormatBool(config.NamespaceDefaultsEnabled))
+	_ = os.Setenv(WatchNamespacesKey, strings.Join(config.WatchNamespaces, ","))
+	_ = os.Setenv(IngressLabelSelectorKey, config.IngressLabelSelector)
+	_ = os.Setenv(IngressClassesKey, strings.Join(config.IngressClasses, ","))
 	_ = os.Setenv(TracingEnabled, strconv.FormatBool(config.TracingEnabled))
 	_ = os.Setenv(TracingSamplingRatio, strconv.FormatFloat(config.TracingSamplingRatio, 'f', 2, 64))
 	_ = os.Setenv(OtelExporterOtlpEndpoint, config.OtelExporterOtlpEndpoint)
+	_ = os.Setenv(TracingProtocolKey, config.TracingProtocol)
+	_ = os.Setenv(TracingInsecureKey, strconv.FormatBool(config.TracingInsecure))
+	_ = os.Setenv(TracingCAFileKey, config.TracingCAFile)
+	_ = os.Setenv(TracingClientCertFileKey, config.TracingClientCertFile)
+	_ = os.Setenv(TracingClientKeyFileKey, config.TracingClientKeyFile)
+	_ = os.Setenv(TracingHeadersKey, config.tracingHeaders)
+	_ = os.Setenv(TracingResourceAttributesKey, config.tracingResourceAttributes)
+	_ = os.Setenv(MetricsExporterKey, config.MetricsExporter)
+	_ = os.Setenv(MetricsExportIntervalSecondsKey, strconv.Itoa(config.MetricsExportIntervalSeconds))
 }
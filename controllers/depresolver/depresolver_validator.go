@@ -42,6 +42,8 @@ const (
 	versionNumberRegex = "^(v){0,1}(0|(?:[1-9]\\d*))(?:\\.(0|(?:[1-9]\\d*))(?:\\.(0|(?:[1-9]\\d*)))?(?:\\-([\\w][\\w\\.\\-_]*))?)?$"
 	// k8sNamespaceRegex matches valid kubernetes namespace
 	k8sNamespaceRegex = "^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// k8sNameRegex matches valid kubernetes resource name
+	k8sNameRegex = "^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$"
 )
 
 // validator wrapper against field to be verified
@@ -186,3 +188,34 @@ func (v *validator) hasUniqueItems() *validator {
 func isNotEmpty(s string) bool {
 	return strings.ReplaceAll(s, " ", "") != ""
 }
+
+// ValidateGeoTags returns error if geoTags contain duplicates, invalid values or geo tags which are not
+// configured by CLUSTER_GEO_TAG or EXT_GSLB_CLUSTERS_GEO_TAGS
+func (c *Config) ValidateGeoTags(name string, geoTags []string) error {
+	err := field(name, geoTags).hasUniqueItems().err
+	if err != nil {
+		return err
+	}
+	clusters := utils.MergeWithSlice(c.ExtClustersGeoTags, c.ClusterGeoTag)
+	known := utils.AsMap(clusters)
+	for i, geoTag := range geoTags {
+		err = field(fmt.Sprintf("%s[%v]", name, i), geoTag).isNotEmpty().matchRegexp(geoTagRegex).err
+		if err != nil {
+			return err
+		}
+		if !known[geoTag] {
+			return fmt.Errorf("'%s' contains unknown geo tag '%s', allowed geo tags %v", name, geoTag, clusters)
+		}
+	}
+	return nil
+}
+
+// ValidateHigherThanZero returns error if value is less or equal to zero
+func ValidateHigherThanZero(name string, value int) error {
+	return field(name, value).isHigherThanZero().err
+}
+
+// ValidateHigherOrEqualToZero returns error if value is less than zero
+func ValidateHigherOrEqualToZero(name string, value int) error {
+	return field(name, value).isHigherOrEqualToZero().err
+}
@@ -22,4 +22,7 @@ type GslbResolver interface {
 	// ResolveOperatorConfig executes once. It reads operator's configuration
 	// from environment variables into &Config and validates
 	ResolveOperatorConfig() (*Config, error)
+	// ReloadOperatorConfig reads operator's configuration from environment variables
+	// overridden by reloadable keys and validates
+	ReloadOperatorConfig(overrides map[string]string) (*Config, error)
 }
@@ -19,42 +19,54 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
-	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	externaldns "sigs.k8s.io/external-dns/endpoint"
 )
 
-func (r *AnnoReconciler) getDNSEndpoint(rs *mapper.LoopState) (*externaldns.DNSEndpoint, error) {
+// getDNSEndpoint computes DNSEndpoint of the resource. Observations of every host are returned for recording
+// once the DNSEndpoint is saved
+func (r *AnnoReconciler) getDNSEndpoint(ctx context.Context, rs *mapper.LoopState) (*externaldns.DNSEndpoint, []observation, error) {
+	log := logging.FromContext(ctx)
 
 	var gslbHosts []*externaldns.Endpoint
+	var observations []observation
 	var ttl = externaldns.TTL(rs.Spec.DNSTtlSeconds)
 
-	localTargets, err := rs.GetExposedIPs()
+	localTargets, err := rs.GetExposedIPs(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	status := rs.GetStatus()
+	explanation := Explanation{
+		Namespace:    rs.NamespacedName.Namespace,
+		Name:         rs.NamespacedName.Name,
+		Reconciled:   time.Now().UTC(),
+		Spec:         rs.Spec,
+		LocalTargets: localTargets,
+	}
+	status := rs.GetStatus(ctx)
 	for host, health := range status.ServiceHealth {
-		var finalTargets = assistant.NewTargets()
+		var hostExplanation = HostExplanation{Host: host, Health: health.String()}
 
 		if !strings.Contains(host, r.Config.EdgeDNSZone) {
-			return nil, fmt.Errorf("ingress host %s does not match delegated zone %s", host, r.Config.EdgeDNSZone)
+			return nil, nil, fmt.Errorf("ingress host %s does not match delegated zone %s", host, r.Config.EdgeDNSZone)
 		}
 
-		isPrimary := false
 		isHealthy := health == metrics.Healthy
 
 		if isHealthy {
-			finalTargets.Append(r.Config.ClusterGeoTag, localTargets)
 			localTargetsHost := fmt.Sprintf("localtargets-%s", host)
 			dnsRecord := &externaldns.Endpoint{
 				DNSName:    localTargetsHost,
@@ -66,46 +78,55 @@ func (r *AnnoReconciler) getDNSEndpoint(rs *mapper.LoopState) (*externaldns.DNSE
 		}
 
 		// Check if host is alive on external Gslb
-		externalTargets := r.DNSProvider.GetExternalTargets(host)
-		if len(externalTargets) > 0 {
-			switch rs.Spec.Type {
-			case depresolver.RoundRobinStrategy, depresolver.GeoStrategy:
-				externalTargets.Sort()
-				finalTargets.AppendTargets(externalTargets)
-			case depresolver.FailoverStrategy:
-				// If cluster is Primary and Healthy return only own targets
-				// If cluster is Primary and Unhealthy return first Secondary Healthy cluster
-				var topGeoTag string
-				finalTargets.AppendTargets(externalTargets)
-				primaryGeoTagList := rs.GetFailoverOrderedGeotagList(r.Config.ClusterGeoTag, r.Config.ExtClustersGeoTags)
-				finalTargets, topGeoTag = finalTargets.FailoverProjection(primaryGeoTagList)
-				isPrimary = topGeoTag == r.Config.ClusterGeoTag
-				if isPrimary {
-					if !isHealthy {
-						r.Log.Info().
-							Str("gslb", rs.NamespacedName.Name).
-							Str("cluster", rs.Spec.PrimaryGeoTag).
-							Strs("targets", finalTargets.GetIPs()).
-							Str("workload", metrics.Unhealthy.String()).
-							Msg("Executing failover strategy for primary cluster")
-					}
-				} else {
-					r.Log.Info().
+		externalTargets, queryErrors := r.DNSProvider.GetExternalTargets(ctx, host)
+		hostExplanation.Peers = newPeerExplanations(externalTargets, queryErrors)
+		result := strategy.Compute(strategy.Input{
+			Spec:               rs.Spec,
+			ClusterGeoTag:      r.Config.ClusterGeoTag,
+			ExtClustersGeoTags: depresolver.ConfigFromContext(ctx, r.Config).ExtClustersGeoTags,
+			Healthy:            isHealthy,
+			LocalTargets:       localTargets,
+			ExternalTargets:    externalTargets,
+		})
+		finalTargets := result.Targets
+		observations = append(observations, observation{host: host, health: health, peers: externalTargets,
+			queryErrors: queryErrors, result: result})
+		log.Debug().
+			Str("host", host).
+			Str("health", health.String()).
+			Strs("localTargets", localTargets).
+			Interface("peers", hostExplanation.Peers).
+			Strs("targets", finalTargets.GetIPs()).
+			Msg("Computed targets of host")
+		if len(externalTargets) == 0 {
+			log.Info().
+				Str("host", host).
+				Msg("No external targets have been found for host")
+		} else if rs.Spec.Type == depresolver.FailoverStrategy {
+			// If cluster is Primary and Healthy return only own targets
+			// If cluster is Primary and Unhealthy return first Secondary Healthy cluster
+			hostExplanation.FailoverOrder, hostExplanation.ActiveGeoTag = result.FailoverOrder, result.ActiveGeoTag
+			if result.IsPrimary {
+				if !isHealthy {
+					log.Info().
 						Str("gslb", rs.NamespacedName.Name).
 						Str("cluster", rs.Spec.PrimaryGeoTag).
 						Strs("targets", finalTargets.GetIPs()).
-						Str("workload", metrics.Healthy.String()).
-						Msg("Executing failover strategy for secondary cluster")
+						Str("workload", metrics.Unhealthy.String()).
+						Msg("Executing failover strategy for primary cluster")
 				}
+			} else {
+				log.Info().
+					Str("gslb", rs.NamespacedName.Name).
+					Str("cluster", rs.Spec.PrimaryGeoTag).
+					Strs("targets", finalTargets.GetIPs()).
+					Str("workload", metrics.Healthy.String()).
+					Msg("Executing failover strategy for secondary cluster")
 			}
-		} else {
-			r.Log.Info().
-				Str("host", host).
-				Msg("No external targets have been found for host")
 		}
 
-		r.updateRuntimeStatus(rs, isPrimary, health, finalTargets.GetIPs())
-		r.Log.Info().
+		r.updateRuntimeStatus(rs, result.IsPrimary, health, finalTargets.GetIPs())
+		log.Info().
 			Str("gslb", rs.NamespacedName.Name).
 			Strs("targets", finalTargets.GetIPs()).
 			Msg("Final target list")
@@ -116,15 +137,13 @@ func (r *AnnoReconciler) getDNSEndpoint(rs *mapper.LoopState) (*externaldns.DNSE
 				RecordTTL:  ttl,
 				RecordType: "A",
 				Targets:    finalTargets.GetIPs(),
-				Labels: externaldns.Labels{
-					"strategy": rs.Spec.Type,
-				},
-			}
-			for k, v := range r.getLabels(rs, finalTargets) {
-				dnsRecord.Labels[k] = v
+				Labels:     result.Labels,
 			}
+			hostExplanation.Labels = dnsRecord.Labels
 			gslbHosts = append(gslbHosts, dnsRecord)
 		}
+		hostExplanation.Targets = finalTargets.GetIPs()
+		explanation.Hosts = append(explanation.Hosts, hostExplanation)
 	}
 	dnsEndpointSpec := externaldns.DNSEndpointSpec{
 		Endpoints: gslbHosts,
@@ -142,25 +161,10 @@ func (r *AnnoReconciler) getDNSEndpoint(rs *mapper.LoopState) (*externaldns.DNSE
 
 	err = controllerutil.SetControllerReference(rs.Ingress, dnsEndpoint, r.Scheme)
 	if err != nil {
-		return nil, err
-	}
-	return dnsEndpoint, err
-}
-
-// getLabels map of where key identifies region and weight, value identifies IP.
-func (r *AnnoReconciler) getLabels(rs *mapper.LoopState, targets assistant.Targets) (labels map[string]string) {
-	labels = make(map[string]string, 0)
-	for k, v := range rs.Spec.Weights {
-		t, found := targets[k]
-		if !found {
-			continue
-		}
-		for i, ip := range t.IPs {
-			l := fmt.Sprintf("weight-%s-%v-%v", k, i, v)
-			labels[l] = ip
-		}
+		return nil, nil, err
 	}
-	return labels
+	r.explanations.store(explanation)
+	return dnsEndpoint, observations, err
 }
 
 func (r *AnnoReconciler) updateRuntimeStatus(
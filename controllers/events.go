@@ -0,0 +1,296 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/notifier"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Reasons of events emitted on annotated resources
+const (
+	EventReasonHealthy               = "Healthy"
+	EventReasonUnhealthy             = "Unhealthy"
+	EventReasonFailover              = "Failover"
+	EventReasonFailback              = "Failback"
+	EventReasonPeerClusterLost       = "PeerClusterLost"
+	EventReasonPeerClusterFound      = "PeerClusterFound"
+	EventReasonZoneDelegationUpdated = "ZoneDelegationUpdated"
+	EventReasonDNSUpdateFailed       = "DNSUpdateFailed"
+	EventReasonInvalidAnnotations    = "InvalidAnnotations"
+)
+
+// transitions remembers the last observed value of tracked properties of every resource, so events are
+// emitted on transitions only and the steady state doesn't produce any events. The zero value is ready to use
+type transitions struct {
+	lock   sync.Mutex
+	values map[types.NamespacedName]map[string]string
+}
+
+// observe stores value of property and returns the previous value; found is false on the first observation
+func (t *transitions) observe(nn types.NamespacedName, property, value string) (previous string, found bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.values == nil {
+		t.values = make(map[types.NamespacedName]map[string]string)
+	}
+	if t.values[nn] == nil {
+		t.values[nn] = make(map[string]string)
+	}
+	previous, found = t.values[nn][property]
+	t.values[nn][property] = value
+	return previous, found
+}
+
+// value returns the last observed value of property
+func (t *transitions) value(nn types.NamespacedName, property string) (value string, found bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	value, found = t.values[nn][property]
+	return value, found
+}
+
+// forget drops everything observed for the resource
+func (t *transitions) forget(nn types.NamespacedName) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.values, nn)
+}
+
+// observation holds values computed for host by reconciliation
+type observation struct {
+	host        string
+	health      metrics.HealthStatus
+	peers       assistant.Targets
+	queryErrors assistant.QueryErrors
+	result      strategy.Result
+}
+
+// record emits events, notifications and audit records of transitions observed by reconciliation. It is called
+// once the DNSEndpoint and status are saved, so changes which weren't published are never reported
+func (r *AnnoReconciler) record(ctx context.Context, rs *mapper.LoopState, observations []observation) {
+	for _, o := range observations {
+		r.recordHealth(rs, o.host, o.health)
+		r.recordPeers(rs, o.host, o.peers)
+		r.recordHealthyClusters(rs, o.host, o.health == metrics.Healthy, o.peers)
+		r.recordTargets(ctx, rs, o.host, o.health, o.peers, o.queryErrors, o.result)
+		if rs.Spec.Type == depresolver.FailoverStrategy && len(o.peers) > 0 {
+			r.recordFailover(rs, o.host, o.result.FailoverOrder[0], o.result.ActiveGeoTag)
+		}
+	}
+}
+
+// storeObservations stores peers and active clusters observed by reconciliation in status, so the status annotation
+// carries everything needed to detect transitions after the operator restarts
+func storeObservations(rs *mapper.LoopState, observations []observation) {
+	rs.Status.Peers = make(map[string][]string, len(observations))
+	rs.Status.ActiveGeoTags = nil
+	for _, o := range observations {
+		rs.Status.Peers[o.host] = geoTags(o.peers)
+		if rs.Spec.Type == depresolver.FailoverStrategy && len(o.peers) > 0 {
+			if rs.Status.ActiveGeoTags == nil {
+				rs.Status.ActiveGeoTags = make(map[string]string, len(observations))
+			}
+			rs.Status.ActiveGeoTags[o.host] = o.result.ActiveGeoTag
+		}
+	}
+}
+
+// observe stores value of property of host and returns the previous value. Property which wasn't observed since
+// the operator start is compared with the value persisted in status annotation by the last reconciliation; found
+// is false when there is nothing to compare with
+func (r *AnnoReconciler) observe(rs *mapper.LoopState, property, host, value string) (previous string, found bool) {
+	previous, found = r.transitions.observe(rs.NamespacedName, property+"/"+host, value)
+	if found {
+		return previous, found
+	}
+	return persisted(rs, property, host)
+}
+
+// persisted returns value of property of host from status annotation of the resource
+func persisted(rs *mapper.LoopState, property, host string) (value string, found bool) {
+	if rs.Ingress == nil {
+		return "", false
+	}
+	status, found, err := mapper.ParseStatus(rs.Ingress.GetAnnotations())
+	if !found || err != nil {
+		return "", false
+	}
+	switch property {
+	case "health":
+		health, found := status.ServiceHealth[host]
+		return health.String(), found
+	case "targets":
+		if _, found = status.ServiceHealth[host]; !found {
+			return "", false
+		}
+		targets := append([]string{}, status.HealthyRecords[host]...)
+		sort.Strings(targets)
+		return strings.Join(targets, ","), true
+	case "peers":
+		peers, found := status.Peers[host]
+		return strings.Join(peers, ","), found
+	case "healthy":
+		peers, found := status.Peers[host]
+		if !found {
+			return "", false
+		}
+		clusters := append([]string{}, peers...)
+		if status.ServiceHealth[host] == metrics.Healthy {
+			clusters = append(clusters, status.GeoTag)
+		}
+		sort.Strings(clusters)
+		return strings.Join(clusters, ","), true
+	case "active":
+		active, found := status.ActiveGeoTags[host]
+		return active, found
+	}
+	return "", false
+}
+
+func geoTags(targets assistant.Targets) []string {
+	tags := make([]string, 0, len(targets))
+	for geoTag := range targets {
+		tags = append(tags, geoTag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// recordHealth emits event when health of host changes. Host which isn't healthy is reported also
+// when it is observed for the first time
+func (r *AnnoReconciler) recordHealth(rs *mapper.LoopState, host string, health metrics.HealthStatus) {
+	previous, found := r.observe(rs, "health", host, health.String())
+	if previous == health.String() || (!found && health == metrics.Healthy) {
+		return
+	}
+	if health == metrics.Healthy {
+		r.Recorder.Eventf(rs.Ingress, corev1.EventTypeNormal, EventReasonHealthy, "Host %s is %s", host, health)
+		return
+	}
+	r.Recorder.Eventf(rs.Ingress, corev1.EventTypeWarning, EventReasonUnhealthy, "Host %s is %s", host, health)
+}
+
+// recordFailover emits event and sends notification when failover strategy moves host to another cluster
+func (r *AnnoReconciler) recordFailover(rs *mapper.LoopState, host, primary, active string) {
+	previous, found := r.observe(rs, "active", host, active)
+	if !found || previous == active {
+		return
+	}
+	n := r.notification(rs, host, notifier.ReasonFailover)
+	n.OldActiveGeoTag, n.NewActiveGeoTag = previous, active
+	if active == primary {
+		n.Reason = notifier.ReasonFailback
+		r.Recorder.Eventf(rs.Ingress, corev1.EventTypeNormal, EventReasonFailback,
+			"Host %s failed back from cluster %s to primary cluster %s", host, previous, active)
+	} else {
+		r.Recorder.Eventf(rs.Ingress, corev1.EventTypeWarning, EventReasonFailover,
+			"Host %s failed over from cluster %s to cluster %s", host, previous, active)
+	}
+	r.Notifier.Notify(n)
+}
+
+// recordHealthyClusters sends notification when the set of healthy clusters serving host changes
+func (r *AnnoReconciler) recordHealthyClusters(rs *mapper.LoopState, host string, isHealthy bool, targets assistant.Targets) {
+	clusters := geoTags(targets)
+	if isHealthy {
+		clusters = append(clusters, r.Config.ClusterGeoTag)
+	}
+	sort.Strings(clusters)
+	previous, found := r.observe(rs, "healthy", host, strings.Join(clusters, ","))
+	if !found || previous == strings.Join(clusters, ",") {
+		return
+	}
+	n := r.notification(rs, host, notifier.ReasonHealthyClustersChanged)
+	n.NewHealthyGeoTags = clusters
+	if previous != "" {
+		n.OldHealthyGeoTags = strings.Split(previous, ",")
+	}
+	r.Notifier.Notify(n)
+}
+
+func (r *AnnoReconciler) notification(rs *mapper.LoopState, host, reason string) notifier.Notification {
+	return notifier.Notification{
+		Host:          host,
+		Namespace:     rs.NamespacedName.Namespace,
+		Ingress:       rs.NamespacedName.Name,
+		Reason:        reason,
+		ClusterGeoTag: r.Config.ClusterGeoTag,
+		DryRun:        r.Config.DryRun,
+		Timestamp:     time.Now().UTC(),
+	}
+}
+
+// recordPeers emits events when peer clusters stop or start serving host
+func (r *AnnoReconciler) recordPeers(rs *mapper.LoopState, host string, targets assistant.Targets) {
+	peers := geoTags(targets)
+	previous, found := r.observe(rs, "peers", host, strings.Join(peers, ","))
+	if !found {
+		return
+	}
+	current := map[string]bool{}
+	for _, geoTag := range peers {
+		current[geoTag] = true
+	}
+	before := map[string]bool{}
+	for _, geoTag := range strings.Split(previous, ",") {
+		if geoTag == "" {
+			continue
+		}
+		before[geoTag] = true
+		if !current[geoTag] {
+			r.Recorder.Eventf(rs.Ingress, corev1.EventTypeWarning, EventReasonPeerClusterLost,
+				"Cluster %s stopped serving host %s", geoTag, host)
+		}
+	}
+	for _, geoTag := range peers {
+		if !before[geoTag] {
+			r.Recorder.Eventf(rs.Ingress, corev1.EventTypeNormal, EventReasonPeerClusterFound,
+				"Cluster %s serves host %s", geoTag, host)
+		}
+	}
+}
+
+// recordError emits warning on obj when error occurs or changes; nil error resets the property, so the same error
+// occurring again is reported
+func (r *AnnoReconciler) recordError(nn types.NamespacedName, obj runtime.Object, property, reason string, err error) {
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	previous, _ := r.transitions.observe(nn, property, message)
+	if err == nil || previous == message {
+		return
+	}
+	r.Recorder.Event(obj, corev1.EventTypeWarning, reason, message)
+}
@@ -0,0 +1,313 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/audit"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/notifier"
+	"github.com/k8gb-io/k8gb-light/controllers/strategy"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var eventsState = &mapper.LoopState{
+	Ingress:        &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "test-gslb"}},
+	NamespacedName: types.NamespacedName{Name: "demo", Namespace: "test-gslb"},
+}
+
+func TestTransitions(t *testing.T) {
+	// arrange
+	var tr transitions
+	nn := types.NamespacedName{Name: "demo", Namespace: "test-gslb"}
+	// act
+	_, found1 := tr.observe(nn, "health", "Healthy")
+	previous2, found2 := tr.observe(nn, "health", "Unhealthy")
+	tr.forget(nn)
+	_, found3 := tr.observe(nn, "health", "Healthy")
+	// assert
+	assert.False(t, found1)
+	assert.True(t, found2)
+	assert.Equal(t, "Healthy", previous2)
+	assert.False(t, found3)
+}
+
+func TestRecordHealth(t *testing.T) {
+	// arrange
+	r := fakeEventsReconciler()
+	// act
+	r.recordHealth(eventsState, "demo.cloud.example.com", metrics.Healthy)
+	r.recordHealth(eventsState, "demo.cloud.example.com", metrics.Healthy)
+	r.recordHealth(eventsState, "demo.cloud.example.com", metrics.Unhealthy)
+	r.recordHealth(eventsState, "demo.cloud.example.com", metrics.Unhealthy)
+	r.recordHealth(eventsState, "demo.cloud.example.com", metrics.Healthy)
+	r.recordHealth(eventsState, "roundrobin.cloud.example.com", metrics.NotFound)
+	// assert
+	assert.Equal(t, []string{
+		"Warning Unhealthy Host demo.cloud.example.com is Unhealthy",
+		"Normal Healthy Host demo.cloud.example.com is Healthy",
+		"Warning Unhealthy Host roundrobin.cloud.example.com is NotFound",
+	}, events(r))
+}
+
+func TestRecordFailover(t *testing.T) {
+	// arrange
+	r := fakeEventsReconciler()
+	// act
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "eu")
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "us")
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "us")
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "eu")
+	// assert
+	assert.Equal(t, []string{
+		"Warning Failover Host demo.cloud.example.com failed over from cluster eu to cluster us",
+		"Normal Failback Host demo.cloud.example.com failed back from cluster us to primary cluster eu",
+	}, events(r))
+}
+
+func TestRecordFailoverNotification(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var notifications []notifier.Notification
+	n := mocks.NewMockNotifier(ctrl)
+	n.EXPECT().Notify(gomock.Any()).Do(func(n notifier.Notification) { notifications = append(notifications, n) }).Times(2)
+	r := fakeEventsReconciler()
+	r.Notifier = n
+	// act
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "eu")
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "us")
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "us")
+	r.recordFailover(eventsState, "demo.cloud.example.com", "eu", "eu")
+	// assert
+	assert.Len(t, notifications, 2)
+	for i, expected := range []notifier.Notification{
+		{Host: "demo.cloud.example.com", Namespace: "test-gslb", Ingress: "demo", Reason: notifier.ReasonFailover,
+			OldActiveGeoTag: "eu", NewActiveGeoTag: "us", ClusterGeoTag: "us", DryRun: true},
+		{Host: "demo.cloud.example.com", Namespace: "test-gslb", Ingress: "demo", Reason: notifier.ReasonFailback,
+			OldActiveGeoTag: "us", NewActiveGeoTag: "eu", ClusterGeoTag: "us", DryRun: true},
+	} {
+		assert.False(t, notifications[i].Timestamp.IsZero())
+		notifications[i].Timestamp = expected.Timestamp
+		assert.Equal(t, expected, notifications[i])
+	}
+}
+
+func TestRecordHealthyClusters(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var notifications []notifier.Notification
+	n := mocks.NewMockNotifier(ctrl)
+	n.EXPECT().Notify(gomock.Any()).Do(func(n notifier.Notification) { notifications = append(notifications, n) }).Times(3)
+	r := fakeEventsReconciler()
+	r.Notifier = n
+	eu := assistant.NewTargets()
+	eu.Append("eu", []string{"10.0.0.1"})
+	// act
+	r.recordHealthyClusters(eventsState, "demo.cloud.example.com", true, eu)
+	r.recordHealthyClusters(eventsState, "demo.cloud.example.com", true, eu)
+	r.recordHealthyClusters(eventsState, "demo.cloud.example.com", false, eu)
+	r.recordHealthyClusters(eventsState, "demo.cloud.example.com", false, assistant.NewTargets())
+	r.recordHealthyClusters(eventsState, "demo.cloud.example.com", true, eu)
+	// assert
+	assert.Len(t, notifications, 3)
+	for i, expected := range [][2][]string{
+		{{"eu", "us"}, {"eu"}},
+		{{"eu"}, {}},
+		{nil, {"eu", "us"}},
+	} {
+		assert.Equal(t, notifier.ReasonHealthyClustersChanged, notifications[i].Reason)
+		assert.Equal(t, expected[0], notifications[i].OldHealthyGeoTags)
+		assert.Equal(t, expected[1], notifications[i].NewHealthyGeoTags)
+	}
+}
+
+func TestRecordPeers(t *testing.T) {
+	// arrange
+	r := fakeEventsReconciler()
+	za := &assistant.Target{IPs: []string{"10.0.0.1"}}
+	us := &assistant.Target{IPs: []string{"10.0.0.2"}}
+	// act
+	r.recordPeers(eventsState, "demo.cloud.example.com", assistant.Targets{"za": za, "us": us})
+	r.recordPeers(eventsState, "demo.cloud.example.com", assistant.Targets{"us": us, "za": za})
+	r.recordPeers(eventsState, "demo.cloud.example.com", assistant.Targets{"us": us})
+	r.recordPeers(eventsState, "demo.cloud.example.com", assistant.Targets{})
+	r.recordPeers(eventsState, "demo.cloud.example.com", assistant.Targets{"za": za})
+	// assert
+	assert.Equal(t, []string{
+		"Warning PeerClusterLost Cluster za stopped serving host demo.cloud.example.com",
+		"Warning PeerClusterLost Cluster us stopped serving host demo.cloud.example.com",
+		"Normal PeerClusterFound Cluster za serves host demo.cloud.example.com",
+	}, events(r))
+}
+
+func TestRecordError(t *testing.T) {
+	// arrange
+	r := fakeEventsReconciler()
+	nn := eventsState.NamespacedName
+	// act
+	r.recordError(nn, eventsState.Ingress, "dns", EventReasonDNSUpdateFailed, fmt.Errorf("connection refused"))
+	r.recordError(nn, eventsState.Ingress, "dns", EventReasonDNSUpdateFailed, fmt.Errorf("connection refused"))
+	r.recordError(nn, eventsState.Ingress, "dns", EventReasonDNSUpdateFailed, fmt.Errorf("timeout"))
+	r.recordError(nn, eventsState.Ingress, "dns", EventReasonDNSUpdateFailed, nil)
+	r.recordError(nn, eventsState.Ingress, "dns", EventReasonDNSUpdateFailed, fmt.Errorf("timeout"))
+	// assert
+	assert.Equal(t, []string{
+		"Warning DNSUpdateFailed connection refused",
+		"Warning DNSUpdateFailed timeout",
+		"Warning DNSUpdateFailed timeout",
+	}, events(r))
+}
+
+func TestReconcileInvalidAnnotationsEvent(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ing := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "test-gslb",
+		Annotations: map[string]string{mapper.AnnotationStrategy: depresolver.FailoverStrategy}}}
+	r := fakeClient(ctrl, depresolver.Config{})
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), eventsState.NamespacedName, gomock.Any()).SetArg(2, *ing).Return(nil).Times(4)
+	// act
+	_, _ = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: eventsState.NamespacedName})
+	_, _ = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: eventsState.NamespacedName})
+	// assert
+	assert.Len(t, recorder.Events, 1)
+	assert.Equal(t, "Warning InvalidAnnotations invalid k8gb annotations: failover strategy requires annotation k8gb.io/primary-geotag",
+		<-recorder.Events)
+}
+
+func TestReconcileRecordsAfterSave(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeExplainReconciler(ctrl)
+	r.Recorder = record.NewFakeRecorder(10)
+	span := mocks.NewMockSpan(ctrl)
+	span.EXPECT().End(gomock.Any()).Return().AnyTimes()
+	span.EXPECT().RecordError(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	span.EXPECT().SetStatus(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	tracer := mocks.NewMockTracer(ctrl)
+	tracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), span).AnyTimes()
+	r.Tracer = tracer
+	rs := fakeExplainState(ctrl, mapper.Spec{Type: depresolver.RoundRobinStrategy, DNSTtlSeconds: 30},
+		map[string]metrics.HealthStatus{"roundrobin.cloud.example.com": metrics.Unhealthy})
+	rs.Mapper.(*mocks.MockMapper).EXPECT().UpdateStatusAnnotation(gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), rs.NamespacedName).Return(rs, mapper.ResultExists, nil).Times(2)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(false).Times(2)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().GetExternalTargets(gomock.Any(), gomock.Any()).
+		Return(assistant.NewTargets(), assistant.QueryErrors{}).Times(2)
+	gomock.InOrder(
+		r.DNSProvider.(*mocks.MockProvider).EXPECT().SaveDNSEndpoint(gomock.Any(), rs, gomock.Any()).Return(fmt.Errorf("connection refused")),
+		r.DNSProvider.(*mocks.MockProvider).EXPECT().SaveDNSEndpoint(gomock.Any(), rs, gomock.Any()).Return(nil),
+	)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateRoundrobinStatus(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateIngressHostsPerStatusMetric(gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateHealthyRecordsMetric(gomock.Any(), gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateEndpointStatus(gomock.Any()).AnyTimes()
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementReconciliation(gomock.Any()).AnyTimes()
+	// act
+	_, _ = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: rs.NamespacedName})
+	failed := events(r)
+	_, _ = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: rs.NamespacedName})
+	saved := events(r)
+	// assert
+	assert.Equal(t, []string{"Warning DNSUpdateFailed connection refused"}, failed)
+	assert.Equal(t, []string{"Warning Unhealthy Host roundrobin.cloud.example.com is Unhealthy"}, saved)
+}
+
+func TestRecordAfterRestart(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	var notifications []notifier.Notification
+	n := mocks.NewMockNotifier(ctrl)
+	n.EXPECT().Notify(gomock.Any()).Do(func(n notifier.Notification) { notifications = append(notifications, n) }).Times(2)
+	r := fakeEventsReconciler()
+	r.Notifier = n
+	status := mapper.Status{
+		ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
+		GeoTag:        "us",
+		Peers:         map[string][]string{"demo.cloud.example.com": {"eu"}},
+		ActiveGeoTags: map[string]string{"demo.cloud.example.com": "us"},
+	}
+	rs := &mapper.LoopState{NamespacedName: eventsState.NamespacedName, Spec: mapper.Spec{Type: depresolver.FailoverStrategy},
+		Ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "test-gslb",
+			Annotations: map[string]string{mapper.AnnotationStatus: status.String()}}}}
+	peers := assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}}
+	// act
+	r.record(context.TODO(), rs, []observation{{host: "demo.cloud.example.com", health: metrics.Unhealthy, peers: peers,
+		result: strategy.Result{FailoverOrder: mapper.PrimaryGeotag{"us", "eu"}, ActiveGeoTag: "eu"}}})
+	// assert
+	assert.Equal(t, []string{"Warning Unhealthy Host demo.cloud.example.com is Unhealthy",
+		"Warning Failover Host demo.cloud.example.com failed over from cluster us to cluster eu"}, events(r))
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, notifier.ReasonHealthyClustersChanged, notifications[0].Reason)
+	assert.Equal(t, []string{"eu", "us"}, notifications[0].OldHealthyGeoTags)
+	assert.Equal(t, notifier.ReasonFailover, notifications[1].Reason)
+	assert.Equal(t, "us", notifications[1].OldActiveGeoTag)
+}
+
+func TestStoreObservations(t *testing.T) {
+	// arrange
+	rs := &mapper.LoopState{Spec: mapper.Spec{Type: depresolver.FailoverStrategy}}
+	peers := assistant.Targets{"za": {IPs: []string{"10.2.0.1"}}, "eu": {IPs: []string{"10.0.0.1"}}}
+	// act
+	storeObservations(rs, []observation{
+		{host: "a.cloud.example.com", peers: peers, result: strategy.Result{ActiveGeoTag: "eu"}},
+		{host: "b.cloud.example.com", peers: assistant.NewTargets(), result: strategy.Result{ActiveGeoTag: "us"}},
+	})
+	// assert
+	assert.Equal(t, map[string][]string{"a.cloud.example.com": {"eu", "za"}, "b.cloud.example.com": {}}, rs.Status.Peers)
+	assert.Equal(t, map[string]string{"a.cloud.example.com": "eu"}, rs.Status.ActiveGeoTags)
+}
+
+func fakeEventsReconciler() *AnnoReconciler {
+	return &AnnoReconciler{
+		Recorder: record.NewFakeRecorder(10),
+		Notifier: notifier.NewEmptyNotifier(),
+		Auditor:  audit.NewEmptyAuditor(),
+		Config:   &depresolver.Config{ClusterGeoTag: "us", DryRun: true},
+	}
+}
+
+func events(r *AnnoReconciler) (events []string) {
+	recorder := r.Recorder.(*record.FakeRecorder)
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	return events
+}
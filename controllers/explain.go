@@ -0,0 +1,128 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Explanation describes how the last reconciliation of the annotated resource computed its DNS records
+type Explanation struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Reconciled is time of the reconciliation
+	Reconciled time.Time `json:"reconciled"`
+	// Spec parsed from annotations and defaults
+	Spec mapper.Spec `json:"spec"`
+	// LocalTargets are IPs exposed by the local cluster
+	LocalTargets []string `json:"localTargets"`
+	// Hosts sorted by name
+	Hosts []HostExplanation `json:"hosts"`
+}
+
+// HostExplanation describes how the DNS record of the host was computed
+type HostExplanation struct {
+	Host string `json:"host"`
+	// Health of the host in the local cluster
+	Health string `json:"health"`
+	// Peers are external clusters keyed by geo tag
+	Peers map[string]PeerExplanation `json:"peers"`
+	// FailoverOrder is the order of clusters considered by failover strategy
+	FailoverOrder []string `json:"failoverOrder,omitempty"`
+	// ActiveGeoTag is the cluster selected by failover strategy
+	ActiveGeoTag string `json:"activeGeoTag,omitempty"`
+	// Targets are IPs of the final DNS record
+	Targets []string `json:"targets"`
+	// Labels of the final DNS record
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PeerExplanation describes targets of the host resolved from external cluster
+type PeerExplanation struct {
+	Targets []string `json:"targets,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func newPeerExplanations(targets assistant.Targets, errs assistant.QueryErrors) map[string]PeerExplanation {
+	peers := make(map[string]PeerExplanation, len(targets)+len(errs))
+	for geoTag, target := range targets {
+		peers[geoTag] = PeerExplanation{Targets: target.IPs}
+	}
+	for geoTag, err := range errs {
+		peer := peers[geoTag]
+		peer.Error = err.Error()
+		peers[geoTag] = peer
+	}
+	return peers
+}
+
+// explanations keeps the last explanation of every annotated resource. The zero value is ready to use
+type explanations struct {
+	lock   sync.RWMutex
+	values map[types.NamespacedName]Explanation
+}
+
+func (e *explanations) store(explanation Explanation) {
+	sort.Slice(explanation.Hosts, func(i, j int) bool {
+		return explanation.Hosts[i].Host < explanation.Hosts[j].Host
+	})
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.values == nil {
+		e.values = make(map[types.NamespacedName]Explanation)
+	}
+	e.values[types.NamespacedName{Namespace: explanation.Namespace, Name: explanation.Name}] = explanation
+}
+
+func (e *explanations) get(nn types.NamespacedName) (explanation Explanation, found bool) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	explanation, found = e.values[nn]
+	return explanation, found
+}
+
+// list returns explanations sorted by namespace and name
+func (e *explanations) list() []Explanation {
+	e.lock.RLock()
+	list := make([]Explanation, 0, len(e.values))
+	for _, explanation := range e.values {
+		list = append(list, explanation)
+	}
+	e.lock.RUnlock()
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Namespace != list[j].Namespace {
+			return list[i].Namespace < list[j].Namespace
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func (e *explanations) forget(nn types.NamespacedName) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	delete(e.values, nn)
+}
@@ -0,0 +1,131 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestExplainFailover(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeExplainReconciler(ctrl)
+	rs := fakeExplainState(ctrl, mapper.Spec{Type: depresolver.FailoverStrategy, PrimaryGeoTag: "eu", DNSTtlSeconds: 30},
+		map[string]metrics.HealthStatus{"failover.cloud.example.com": metrics.Unhealthy})
+	targets := assistant.NewTargets()
+	targets.Append("za", []string{"10.2.0.1"})
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().GetExternalTargets(gomock.Any(), "failover.cloud.example.com").
+		Return(targets, assistant.QueryErrors{"us": fmt.Errorf("i/o timeout")}).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateFailoverStatus(rs.NamespacedName, false, metrics.Unhealthy, []string{"10.2.0.1"}).Times(1)
+	// act
+	_, _, err := r.getDNSEndpoint(context.TODO(), rs)
+	explanation, found := r.explanations.get(rs.NamespacedName)
+	// assert
+	require.NoError(t, err)
+	require.True(t, found)
+	assert.Equal(t, rs.Spec, explanation.Spec)
+	assert.Equal(t, []string{"10.0.0.1"}, explanation.LocalTargets)
+	assert.False(t, explanation.Reconciled.IsZero())
+	assert.Equal(t, []HostExplanation{{
+		Host:   "failover.cloud.example.com",
+		Health: metrics.Unhealthy.String(),
+		Peers: map[string]PeerExplanation{
+			"za": {Targets: []string{"10.2.0.1"}},
+			"us": {Error: "i/o timeout"},
+		},
+		FailoverOrder: []string{"eu", "us", "za"},
+		ActiveGeoTag:  "za",
+		Targets:       []string{"10.2.0.1"},
+		Labels:        map[string]string{"strategy": depresolver.FailoverStrategy},
+	}}, explanation.Hosts)
+}
+
+func TestExplainRoundRobin(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeExplainReconciler(ctrl)
+	rs := fakeExplainState(ctrl, mapper.Spec{Type: depresolver.RoundRobinStrategy, DNSTtlSeconds: 30, Weights: map[string]int{"eu": 1}},
+		map[string]metrics.HealthStatus{"b.cloud.example.com": metrics.Healthy, "a.cloud.example.com": metrics.NotFound})
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().GetExternalTargets(gomock.Any(), gomock.Any()).Return(assistant.NewTargets(), assistant.QueryErrors{}).Times(2)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateRoundrobinStatus(rs.NamespacedName, gomock.Any(), gomock.Any()).Times(2)
+	// act
+	_, _, err := r.getDNSEndpoint(context.TODO(), rs)
+	explanation, _ := r.explanations.get(rs.NamespacedName)
+	// assert
+	require.NoError(t, err)
+	require.Len(t, explanation.Hosts, 2)
+	assert.Equal(t, "a.cloud.example.com", explanation.Hosts[0].Host)
+	assert.Equal(t, []string{}, explanation.Hosts[0].Targets)
+	assert.Nil(t, explanation.Hosts[0].Labels)
+	assert.Equal(t, "b.cloud.example.com", explanation.Hosts[1].Host)
+	assert.Equal(t, []string{"10.0.0.1"}, explanation.Hosts[1].Targets)
+	assert.Equal(t, map[string]string{"strategy": depresolver.RoundRobinStrategy, "weight-eu-0-1": "10.0.0.1"}, explanation.Hosts[1].Labels)
+	assert.Empty(t, explanation.Hosts[1].FailoverOrder)
+}
+
+func TestExplanations(t *testing.T) {
+	// arrange
+	var e explanations
+	// act
+	e.store(Explanation{Namespace: "b", Name: "a"})
+	e.store(Explanation{Namespace: "a", Name: "b"})
+	e.store(Explanation{Namespace: "a", Name: "a"})
+	e.forget(types.NamespacedName{Namespace: "a", Name: "b"})
+	_, found := e.get(types.NamespacedName{Namespace: "a", Name: "b"})
+	// assert
+	assert.False(t, found)
+	assert.Equal(t, []Explanation{{Namespace: "a", Name: "a"}, {Namespace: "b", Name: "a"}}, e.list())
+}
+
+func fakeExplainReconciler(ctrl *gomock.Controller) *AnnoReconciler {
+	r := fakeMapper(ctrl)
+	r.Scheme = scheme.Scheme
+	r.Config = &depresolver.Config{ClusterGeoTag: "eu", ExtClustersGeoTags: []string{"us", "za"}, EdgeDNSZone: "example.com"}
+	return r
+}
+
+func fakeExplainState(ctrl *gomock.Controller, spec mapper.Spec, health map[string]metrics.HealthStatus) *mapper.LoopState {
+	m := mocks.NewMockMapper(ctrl)
+	m.EXPECT().GetExposedIPs(gomock.Any()).Return([]string{"10.0.0.1"}, nil).AnyTimes()
+	m.EXPECT().GetStatus(gomock.Any()).Return(mapper.Status{ServiceHealth: health}).AnyTimes()
+	return &mapper.LoopState{
+		Mapper:         m,
+		Spec:           spec,
+		NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing"},
+		Ingress:        &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing", UID: "uid"}},
+	}
+}
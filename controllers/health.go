@@ -0,0 +1,81 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/dns"
+	"github.com/k8gb-io/k8gb-light/controllers/utils"
+
+	miekgdns "github.com/miekg/dns"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// dnsEndpointKind is written by all DNS providers, the operator can't work without the CRD
+var dnsEndpointKind = schema.GroupKind{Group: "externaldns.k8s.io", Kind: "DNSEndpoint"}
+
+// HealthChecker verifies the operator can do its job. The liveness probe checks the process responds,
+// the readiness probe checks dependencies the operator talks to
+type HealthChecker struct {
+	Config      *depresolver.Config
+	DNSProvider dns.Provider
+	RESTMapper  meta.RESTMapper
+}
+
+// SetupWithManager registers /healthz and /readyz checks served on HEALTH_PROBE_ADDRESS
+func (h *HealthChecker) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("edge-dns", h.checkEdgeDNS); err != nil {
+		return err
+	}
+	if err := mgr.AddReadyzCheck("dns-provider", h.checkDNSProvider); err != nil {
+		return err
+	}
+	return mgr.AddReadyzCheck("dnsendpoint-crd", h.checkDNSEndpointCRD)
+}
+
+// checkEdgeDNS queries SOA of the edge DNS zone; any answer means edge DNS is reachable
+func (h *HealthChecker) checkEdgeDNS(req *http.Request) error {
+	m := new(miekgdns.Msg)
+	m.SetQuestion(miekgdns.Fqdn(h.Config.EdgeDNSZone), miekgdns.TypeSOA)
+	_, err := utils.Exchange(req.Context(), m, h.Config.EdgeDNSServers)
+	return err
+}
+
+func (h *HealthChecker) checkDNSProvider(req *http.Request) error {
+	if err := h.DNSProvider.Ping(req.Context()); err != nil {
+		return fmt.Errorf("%s provider is not reachable: %w", h.DNSProvider, err)
+	}
+	return nil
+}
+
+func (h *HealthChecker) checkDNSEndpointCRD(*http.Request) error {
+	if _, err := h.RESTMapper.RESTMapping(dnsEndpointKind, "v1alpha1"); err != nil {
+		return fmt.Errorf("DNSEndpoint CRD is not available: %w", err)
+	}
+	return nil
+}
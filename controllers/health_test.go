@@ -0,0 +1,85 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+	"github.com/k8gb-io/k8gb-light/controllers/utils"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const healthFakeDNSPort = 7853
+
+func TestCheckEdgeDNS(t *testing.T) {
+	// arrange
+	h := &HealthChecker{Config: &depresolver.Config{EdgeDNSZone: "example.com",
+		EdgeDNSServers: []utils.DNSServer{{Host: "localhost", Port: healthFakeDNSPort}}}}
+	settings := utils.FakeDNSSettings{FakeDNSPort: healthFakeDNSPort, EdgeDNSZoneFQDN: "example.com.", DNSZoneFQDN: "cloud.example.com."}
+	// act
+	// assert
+	utils.NewFakeDNS(settings).
+		Start().
+		RunTestFunc(func() {
+			assert.NoError(t, h.checkEdgeDNS(readyzRequest()))
+		}).RequireNoError(t)
+	assert.Error(t, h.checkEdgeDNS(readyzRequest()))
+}
+
+func TestCheckDNSProvider(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	p := mocks.NewMockProvider(ctrl)
+	p.EXPECT().String().Return("INFOBLOX").AnyTimes()
+	gomock.InOrder(
+		p.EXPECT().Ping(gomock.Any()).Return(nil),
+		p.EXPECT().Ping(gomock.Any()).Return(fmt.Errorf("401 Unauthorized")),
+	)
+	h := &HealthChecker{DNSProvider: p}
+	// act
+	// assert
+	assert.NoError(t, h.checkDNSProvider(readyzRequest()))
+	assert.EqualError(t, h.checkDNSProvider(readyzRequest()), "INFOBLOX provider is not reachable: 401 Unauthorized")
+}
+
+func TestCheckDNSEndpointCRD(t *testing.T) {
+	// arrange
+	withCRD := meta.NewDefaultRESTMapper(nil)
+	withCRD.Add(dnsEndpointKind.WithVersion("v1alpha1"), meta.RESTScopeNamespace)
+	withoutCRD := meta.NewDefaultRESTMapper(nil)
+	withoutCRD.Add(schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"}, meta.RESTScopeNamespace)
+	// act
+	// assert
+	assert.NoError(t, (&HealthChecker{RESTMapper: withCRD}).checkDNSEndpointCRD(nil))
+	assert.Error(t, (&HealthChecker{RESTMapper: withoutCRD}).checkDNSEndpointCRD(nil))
+}
+
+func readyzRequest() *http.Request {
+	return httptest.NewRequest(http.MethodGet, "/readyz", nil)
+}
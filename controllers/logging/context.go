@@ -0,0 +1,50 @@
+package logging
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns copy of ctx carrying logger l
+func WithLogger(ctx context.Context, l *zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns logger carried by ctx, or the static logger when ctx carries none
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+		return l
+	}
+	return Logger()
+}
+
+// Debug returns copy of l which logs at trace level regardless of the level set by SetLevel.
+// It is used for resources annotated by k8gb.io/debug only, so the other resources don't flood the log
+func Debug(l *zerolog.Logger) *zerolog.Logger {
+	d := l.Sample(nil)
+	if d.GetLevel() != zerolog.Disabled {
+		d = d.Level(zerolog.TraceLevel)
+	}
+	return &d
+}
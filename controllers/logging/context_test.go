@@ -0,0 +1,81 @@
+package logging
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelSampler(t *testing.T) {
+	// arrange
+	defer SetLevel(Level())
+	var buf bytes.Buffer
+	l := zerolog.New(&buf).Sample(levelSampler{})
+	SetLevel(zerolog.InfoLevel)
+
+	// act
+	l.Debug().Msg("dropped")
+	l.Info().Msg("info")
+	SetLevel(zerolog.DebugLevel)
+	l.Debug().Msg("debug")
+
+	// assert
+	assert.NotContains(t, buf.String(), "dropped")
+	assert.Contains(t, buf.String(), "info")
+	assert.Contains(t, buf.String(), "debug")
+}
+
+func TestDebug(t *testing.T) {
+	// arrange
+	defer SetLevel(Level())
+	var buf bytes.Buffer
+	l := zerolog.New(&buf).Sample(levelSampler{})
+	disabled := zerolog.New(&buf).Level(zerolog.Disabled)
+	SetLevel(zerolog.InfoLevel)
+
+	// act
+	d := Debug(&l)
+	d.Trace().Msg("trace")
+	Debug(&disabled).Trace().Msg("disabled")
+	l.Debug().Msg("dropped")
+
+	// assert
+	assert.Equal(t, zerolog.TraceLevel, d.GetLevel())
+	assert.Contains(t, buf.String(), "trace")
+	assert.NotContains(t, buf.String(), "disabled")
+	assert.NotContains(t, buf.String(), "dropped")
+}
+
+func TestFromContext(t *testing.T) {
+	// arrange
+	l := zerolog.New(nil)
+
+	// act
+	carried := FromContext(WithLogger(context.TODO(), &l))
+	fallback := FromContext(context.TODO())
+
+	// assert
+	assert.Same(t, &l, carried)
+	assert.Same(t, Logger(), fallback)
+}
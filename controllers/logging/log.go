@@ -52,7 +52,10 @@ func (l *loggerFactory) get() zerolog.Logger {
 	}
 	// We can retrieve stack in case of pkg/errors
 	zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
-	zerolog.SetGlobalLevel(l.log.Level)
+	// global level is the lowest one, so loggers of debugged resources can log at any level. Level of other
+	// loggers is controlled by levelSampler, so it can be changed at runtime by SetLevel
+	zerolog.SetGlobalLevel(zerolog.TraceLevel)
+	SetLevel(l.log.Level)
 	switch l.log.Format {
 	case depresolver.JSONFormat:
 		logger = zerolog.New(os.Stdout).
@@ -60,14 +63,14 @@ func (l *loggerFactory) get() zerolog.Logger {
 			Caller().
 			Timestamp().
 			Logger().
-			Level(l.log.Level)
+			Sample(levelSampler{})
 	case depresolver.SimpleFormat:
 		logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339, NoColor: l.log.NoColor}).
 			With().
 			Caller().
 			Timestamp().
 			Logger().
-			Level(l.log.Level)
+			Sample(levelSampler{})
 	}
 	logger.Info().Msg("Logger configured")
 	logger.Info().
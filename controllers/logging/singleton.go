@@ -20,6 +20,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
 
@@ -27,8 +28,9 @@ import (
 )
 
 var (
-	once sync.Once
-	log  zerolog.Logger
+	once  sync.Once
+	log   zerolog.Logger
+	level atomic.Int32
 )
 
 // Logger public static logger, providing instance of initialised logger
@@ -42,3 +44,20 @@ func Init(c *depresolver.Config) {
 		log = newLogger(c).get()
 	})
 }
+
+// SetLevel changes level of all loggers at runtime, except loggers of debugged resources
+func SetLevel(l zerolog.Level) {
+	level.Store(int32(l))
+}
+
+// Level returns the level set by SetLevel
+func Level() zerolog.Level {
+	return zerolog.Level(level.Load())
+}
+
+// levelSampler drops events below the level set by SetLevel before they are built
+type levelSampler struct{}
+
+func (levelSampler) Sample(l zerolog.Level) bool {
+	return l >= Level()
+}
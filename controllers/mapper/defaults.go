@@ -0,0 +1,135 @@
+package mapper
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Sources of annotation values recorded in Status.SpecSources
+const (
+	SourceIngress   = "ingress"
+	SourceNamespace = "namespace"
+	SourceCluster   = "cluster"
+	SourceDefault   = "default"
+)
+
+// SpecAnnotations are the annotations parsed into Spec
+var SpecAnnotations = []string{
+	AnnotationStrategy,
+	AnnotationPrimaryGeoTag,
+	AnnotationDNSTTLSeconds,
+	AnnotationSplitBrainThresholdSeconds,
+	AnnotationWeightJSON,
+}
+
+// defaultableAnnotations can be defaulted by namespace annotations or by the cluster-wide ConfigMap. Strategy is
+// always taken from ingress, so defaults never enable k8gb on ingresses which are not annotated. The deprecated
+// split brain threshold is ignored, so it is not defaultable
+var defaultableAnnotations = []string{
+	AnnotationPrimaryGeoTag,
+	AnnotationDNSTTLSeconds,
+	AnnotationWeightJSON,
+}
+
+// Defaults holds default annotations which are merged beneath ingress annotations
+type Defaults struct {
+	// Cluster annotations from DEFAULTS_CONFIG_MAP_NAME ConfigMap
+	Cluster map[string]string
+	// Namespace annotations of ingress namespace
+	Namespace map[string]string
+}
+
+// merge returns annotations merged over namespace and cluster defaults, and the source of every spec annotation
+func (d Defaults) merge(annotations map[string]string) (merged map[string]string, sources map[string]string) {
+	merged = make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		merged[k] = v
+	}
+	sources = make(map[string]string, len(SpecAnnotations))
+	for _, a := range SpecAnnotations {
+		sources[a] = SourceDefault
+		if _, found := annotations[a]; found {
+			sources[a] = SourceIngress
+			continue
+		}
+		if v, found := d.Namespace[a]; found {
+			merged[a] = v
+			sources[a] = SourceNamespace
+			continue
+		}
+		if v, found := d.Cluster[a]; found {
+			merged[a] = v
+			sources[a] = SourceCluster
+		}
+	}
+	return merged, sources
+}
+
+// DefaultsResolver reads default annotations from DEFAULTS_CONFIG_MAP_NAME ConfigMap in k8gb namespace and,
+// when NAMESPACE_DEFAULTS_ENABLED, from annotations of ingress namespace
+type DefaultsResolver struct {
+	c      client.Reader
+	config *depresolver.Config
+}
+
+func NewDefaultsResolver(c client.Reader, config *depresolver.Config) *DefaultsResolver {
+	return &DefaultsResolver{
+		c:      c,
+		config: config,
+	}
+}
+
+// Resolve returns defaults for ingresses in namespace. Missing ConfigMap or namespace don't provide any defaults
+func (d *DefaultsResolver) Resolve(ctx context.Context, namespace string) (defaults Defaults, err error) {
+	if d.config.DefaultsConfigMapName != "" {
+		cm := &corev1.ConfigMap{}
+		err = d.c.Get(ctx, types.NamespacedName{Namespace: d.config.K8gbNamespace, Name: d.config.DefaultsConfigMapName}, cm)
+		if err != nil && !errors.IsNotFound(err) {
+			return defaults, err
+		}
+		defaults.Cluster = filterDefaultable(cm.Data)
+	}
+	if d.config.NamespaceDefaultsEnabled {
+		ns := &corev1.Namespace{}
+		err = d.c.Get(ctx, client.ObjectKey{Name: namespace}, ns)
+		if err != nil && !errors.IsNotFound(err) {
+			return defaults, err
+		}
+		defaults.Namespace = filterDefaultable(ns.GetAnnotations())
+	}
+	return defaults, nil
+}
+
+func filterDefaultable(annotations map[string]string) map[string]string {
+	m := make(map[string]string)
+	for _, a := range defaultableAnnotations {
+		if v, found := annotations[a]; found {
+			m[a] = v
+		}
+	}
+	return m
+}
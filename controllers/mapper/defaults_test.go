@@ -0,0 +1,130 @@
+package mapper
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDefaultsPrecedence(t *testing.T) {
+	// arrange
+	defaults := Defaults{
+		Cluster:   map[string]string{AnnotationDNSTTLSeconds: "60", AnnotationPrimaryGeoTag: "za", AnnotationWeightJSON: "eu:1,us:1"},
+		Namespace: map[string]string{AnnotationPrimaryGeoTag: "eu", AnnotationWeightJSON: "eu:5,us:1"},
+	}
+	annotations := map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy, AnnotationWeightJSON: "eu:1,us:5"}
+	rs := new(LoopState)
+	// act
+	spec, err := rs.asSpec(annotations, defaults)
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, Spec{Type: depresolver.RoundRobinStrategy, PrimaryGeoTag: "eu", DNSTtlSeconds: 60, SplitBrainThresholdSeconds: 300,
+		Weights: map[string]int{"eu": 1, "us": 5}}, spec)
+	assert.Equal(t, map[string]string{AnnotationStrategy: SourceIngress, AnnotationPrimaryGeoTag: SourceNamespace,
+		AnnotationDNSTTLSeconds: SourceCluster, AnnotationSplitBrainThresholdSeconds: SourceDefault,
+		AnnotationWeightJSON: SourceIngress}, rs.SpecSources)
+	assert.Len(t, annotations, 2, "ingress annotations must not be modified")
+}
+
+func TestDefaultsDoNotEnableStrategy(t *testing.T) {
+	// arrange
+	defaults := Defaults{Cluster: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}
+	// act
+	resolved := filterDefaultable(defaults.Cluster)
+	spec, err := ParseSpec(map[string]string{}, Defaults{Cluster: resolved})
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, spec.Type)
+}
+
+func TestDefaultsIgnoreSplitBrainThreshold(t *testing.T) {
+	// arrange
+	annotations := map[string]string{AnnotationSplitBrainThresholdSeconds: "180", AnnotationDNSTTLSeconds: "60"}
+	// act
+	resolved := filterDefaultable(annotations)
+	// assert
+	assert.Equal(t, map[string]string{AnnotationDNSTTLSeconds: "60"}, resolved)
+}
+
+func TestDefaultsResolve(t *testing.T) {
+	var serr = fmt.Errorf("error")
+	var tests = []struct {
+		name              string
+		config            *depresolver.Config
+		configMap         map[string]string
+		configMapErr      error
+		namespace         map[string]string
+		namespaceErr      error
+		expectedDefaults  Defaults
+		expectedErr       bool
+		expectedConfigMap int
+		expectedNamespace int
+	}{
+		{name: "disabled", config: &depresolver.Config{}, expectedDefaults: Defaults{}},
+		{name: "cluster and namespace", config: &depresolver.Config{K8gbNamespace: "k8gb", DefaultsConfigMapName: "k8gb-defaults",
+			NamespaceDefaultsEnabled: true}, configMap: map[string]string{AnnotationDNSTTLSeconds: "60", "foo": "bar"},
+			namespace: map[string]string{AnnotationPrimaryGeoTag: "eu", "owner": "team-a"}, expectedConfigMap: 1, expectedNamespace: 1,
+			expectedDefaults: Defaults{Cluster: map[string]string{AnnotationDNSTTLSeconds: "60"},
+				Namespace: map[string]string{AnnotationPrimaryGeoTag: "eu"}}},
+		{name: "missing ConfigMap", config: &depresolver.Config{K8gbNamespace: "k8gb", DefaultsConfigMapName: "k8gb-defaults"},
+			configMapErr: errors.NewNotFound(schema.GroupResource{}, "k8gb-defaults"), expectedConfigMap: 1,
+			expectedDefaults: Defaults{Cluster: map[string]string{}}},
+		{name: "ConfigMap error", config: &depresolver.Config{K8gbNamespace: "k8gb", DefaultsConfigMapName: "k8gb-defaults"},
+			configMapErr: serr, expectedConfigMap: 1, expectedErr: true},
+		{name: "namespace error", config: &depresolver.Config{NamespaceDefaultsEnabled: true},
+			namespaceErr: serr, expectedNamespace: 1, expectedErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			m := M(t)
+			m.Client.(*MockClient).EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: "k8gb", Name: "k8gb-defaults"},
+				EqTypeMatcher{&corev1.ConfigMap{}}).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, cm *corev1.ConfigMap, _ ...interface{}) error {
+					cm.Data = test.configMap
+					return test.configMapErr
+				}).Times(test.expectedConfigMap)
+			m.Client.(*MockClient).EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "team-a"},
+				EqTypeMatcher{&corev1.Namespace{}}).DoAndReturn(
+				func(_ context.Context, _ client.ObjectKey, ns *corev1.Namespace, _ ...interface{}) error {
+					ns.Annotations = test.namespace
+					return test.namespaceErr
+				}).Times(test.expectedNamespace)
+			// act
+			defaults, err := NewDefaultsResolver(m.Client, test.config).Resolve(context.TODO(), "team-a")
+			// assert
+			assert.Equal(t, test.expectedErr, err != nil)
+			if !test.expectedErr {
+				assert.Equal(t, test.expectedDefaults, defaults)
+			}
+		})
+	}
+}
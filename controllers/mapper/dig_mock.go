@@ -23,6 +23,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -52,16 +53,16 @@ func (m *MockDigger) EXPECT() *MockDiggerMockRecorder {
 }
 
 // DigA mocks base method.
-func (m *MockDigger) DigA(fqdn string) ([]string, error) {
+func (m *MockDigger) DigA(ctx context.Context, fqdn string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DigA", fqdn)
+	ret := m.ctrl.Call(m, "DigA", ctx, fqdn)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DigA indicates an expected call of DigA.
-func (mr *MockDiggerMockRecorder) DigA(fqdn interface{}) *gomock.Call {
+func (mr *MockDiggerMockRecorder) DigA(ctx, fqdn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DigA", reflect.TypeOf((*MockDigger)(nil).DigA), fqdn)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DigA", reflect.TypeOf((*MockDigger)(nil).DigA), ctx, fqdn)
 }
@@ -19,6 +19,8 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
+
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,15 +39,15 @@ func NewGatewayAPIMapper(c client.Client, config *depresolver.Config) *GatewayAP
 	}
 }
 
-func (g *GatewayAPIMapper) TryRemoveDNSEndpoint() (Result, error) {
+func (g *GatewayAPIMapper) TryRemoveDNSEndpoint(context.Context) (Result, error) {
 	panic("not implemented")
 }
 
-func (g *GatewayAPIMapper) GetStatus() Status {
+func (g *GatewayAPIMapper) GetStatus(context.Context) Status {
 	panic("not implemented")
 }
 
-func (g GatewayAPIMapper) UpdateStatusAnnotation() error {
+func (g GatewayAPIMapper) UpdateStatusAnnotation(context.Context) error {
 	panic("not implemented")
 }
 
@@ -53,15 +55,19 @@ func (g GatewayAPIMapper) Equal(*LoopState) bool {
 	panic("not implemented")
 }
 
-func (g GatewayAPIMapper) TryInjectFinalizer() (Result, error) {
+func (g GatewayAPIMapper) TryInjectFinalizer(context.Context) (Result, error) {
+	panic("not implemented")
+}
+
+func (g GatewayAPIMapper) TryRemoveFinalizer(ctx context.Context, f func(context.Context, *LoopState) error) (Result, error) {
 	panic("not implemented")
 }
 
-func (g GatewayAPIMapper) TryRemoveFinalizer(f func(*LoopState) error) (Result, error) {
+func (g GatewayAPIMapper) GetExposedIPs(context.Context) ([]string, error) {
 	panic("not implemented")
 }
 
-func (g GatewayAPIMapper) GetExposedIPs() ([]string, error) {
+func (g GatewayAPIMapper) HasOtherAnnotatedResources(context.Context) (bool, error) {
 	panic("not implemented")
 }
 
@@ -26,10 +26,13 @@ import (
 	"strings"
 
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/tracing"
 	"github.com/k8gb-io/k8gb-light/controllers/utils"
 
 	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -52,11 +55,11 @@ func NewIngressMapper(c client.Client, config *depresolver.Config, dig utils.Dig
 	}
 }
 
-func (i *IngressMapper) UpdateStatusAnnotation() (err error) {
+func (i *IngressMapper) UpdateStatusAnnotation(ctx context.Context) (err error) {
 	// check if object has not been deleted
 	var r Result
 	var s *LoopState
-	s, r, err = NewCommonProvider(i.c, i.config).Get(i.rs.NamespacedName)
+	s, r, err = NewCommonProvider(i.c, nil, i.config).Get(ctx, i.rs.NamespacedName)
 	switch r {
 	case ResultError:
 		return err
@@ -64,14 +67,14 @@ func (i *IngressMapper) UpdateStatusAnnotation() (err error) {
 		// object was deleted
 		return nil
 	}
-	i.rs.Status = i.GetStatus()
+	i.rs.Status = i.GetStatus(ctx)
 	// don't do update if nothing has changed
 	if s.Ingress.Annotations[AnnotationStatus] == i.rs.Status.String() {
 		return nil
 	}
 	// update the planned object
 	s.Ingress.Annotations[AnnotationStatus] = i.rs.Status.String()
-	return i.c.Update(context.TODO(), s.Ingress)
+	return i.c.Update(ctx, s.Ingress)
 }
 
 // Equal compares given ingress annotations and Ingres.Spec. If any of ingresses doesn't exist, returns false
@@ -88,13 +91,13 @@ func (i *IngressMapper) Equal(rs *LoopState) bool {
 	return true
 }
 
-func (i *IngressMapper) TryInjectFinalizer() (Result, error) {
+func (i *IngressMapper) TryInjectFinalizer(ctx context.Context) (Result, error) {
 	if i.rs == nil || i.rs.Ingress == nil {
 		return ResultError, fmt.Errorf("injecting finalizer from nil values")
 	}
 	if !utils.Contains(i.rs.Ingress.GetFinalizers(), Finalizer) {
 		i.rs.Ingress.SetFinalizers(append(i.rs.Ingress.GetFinalizers(), Finalizer))
-		err := i.c.Update(context.TODO(), i.rs.Ingress)
+		err := i.c.Update(ctx, i.rs.Ingress)
 		if err != nil {
 			return ResultError, err
 		}
@@ -103,21 +106,21 @@ func (i *IngressMapper) TryInjectFinalizer() (Result, error) {
 	return ResultContinue, nil
 }
 
-func (i *IngressMapper) TryRemoveFinalizer(finalize func(*LoopState) error) (Result, error) {
+func (i *IngressMapper) TryRemoveFinalizer(ctx context.Context, finalize func(context.Context, *LoopState) error) (Result, error) {
 	if i.rs == nil || i.rs.Ingress == nil {
 		return ResultError, fmt.Errorf("removing finalizer from nil values")
 	}
 	if utils.Contains(i.rs.Ingress.GetFinalizers(), Finalizer) {
 		isMarkedToBeDeleted := i.rs.Ingress.GetDeletionTimestamp() != nil
-		if !isMarkedToBeDeleted {
+		if !isMarkedToBeDeleted && i.managed() {
 			return ResultContinue, nil
 		}
-		err := finalize(i.rs)
+		err := finalize(ctx, i.rs)
 		if err != nil {
 			return ResultError, err
 		}
 		i.rs.Ingress.SetFinalizers(utils.Remove(i.rs.Ingress.GetFinalizers(), Finalizer))
-		err = i.c.Update(context.TODO(), i.rs.Ingress)
+		err = i.c.Update(ctx, i.rs.Ingress)
 		if err != nil {
 			return ResultError, err
 		}
@@ -126,14 +129,23 @@ func (i *IngressMapper) TryRemoveFinalizer(finalize func(*LoopState) error) (Res
 	return ResultContinue, nil
 }
 
-func (i *IngressMapper) GetExposedIPs() ([]string, error) {
+// managed returns true if ingress is annotated by k8gb strategy and selected by this instance. Finalizer
+// of ingress which is not managed anymore is removed, otherwise its deletion would hang
+func (i *IngressMapper) managed() bool {
+	if _, found := i.rs.Ingress.GetAnnotations()[AnnotationStrategy]; !found {
+		return false
+	}
+	return NewIngressSelector(i.config).Matches(i.rs.Ingress)
+}
+
+func (i *IngressMapper) GetExposedIPs(ctx context.Context) ([]string, error) {
 	var exposed []string
 	for _, ing := range i.rs.Ingress.Status.LoadBalancer.Ingress {
 		if len(ing.IP) > 0 {
 			exposed = append(exposed, ing.IP)
 		}
 		if len(ing.Hostname) > 0 {
-			ips, err := i.dig.DigA(ing.Hostname)
+			ips, err := i.digA(ctx, ing.Hostname)
 			if err != nil {
 				return nil, err
 			}
@@ -143,7 +155,7 @@ func (i *IngressMapper) GetExposedIPs() ([]string, error) {
 	return exposed, nil
 }
 
-func (i *IngressMapper) GetStatus() (status Status) {
+func (i *IngressMapper) GetStatus(ctx context.Context) (status Status) {
 	csv := func(rs *LoopState) string {
 		var hosts []string
 		for _, r := range rs.Ingress.Spec.Rules {
@@ -153,10 +165,14 @@ func (i *IngressMapper) GetStatus() (status Status) {
 	}
 
 	return Status{
-		ServiceHealth:  i.getHealthStatus(),
-		HealthyRecords: i.getHealthyRecords(),
+		ServiceHealth:  i.getHealthStatus(ctx),
+		HealthyRecords: i.getHealthyRecords(ctx),
 		GeoTag:         i.config.ClusterGeoTag,
 		Hosts:          csv(i.rs),
+		Spec:           i.rs.Spec,
+		SpecSources:    i.rs.SpecSources,
+		Peers:          i.rs.Status.Peers,
+		ActiveGeoTags:  i.rs.Status.ActiveGeoTags,
 	}
 }
 
@@ -164,12 +180,16 @@ func (i *IngressMapper) SetReference(rs *LoopState) {
 	i.rs = rs
 }
 
-func (i *IngressMapper) getHealthyRecords() map[string][]string {
+func (i *IngressMapper) getHealthyRecords(ctx context.Context) map[string][]string {
 	// TODO: make mapper for DNSEndpoint
 	healthyRecords := make(map[string][]string)
 	dnsEndpoint := &externaldns.DNSEndpoint{}
-	err := i.c.Get(context.TODO(), i.rs.NamespacedName, dnsEndpoint)
+	err := i.c.Get(ctx, i.rs.NamespacedName, dnsEndpoint)
 	if err != nil {
+		logging.FromContext(ctx).Debug().
+			Err(err).
+			Str("DNSEndpoint", i.rs.NamespacedName.String()).
+			Msg("Unable to read DNSEndpoint, no healthy records")
 		// todo: consider to return array with text "error"
 		return healthyRecords
 	}
@@ -186,7 +206,8 @@ func (i *IngressMapper) getHealthyRecords() map[string][]string {
 	return healthyRecords
 }
 
-func (i *IngressMapper) getHealthStatus() map[string]metrics.HealthStatus {
+func (i *IngressMapper) getHealthStatus(ctx context.Context) map[string]metrics.HealthStatus {
+	log := logging.FromContext(ctx)
 	serviceHealth := make(map[string]metrics.HealthStatus)
 	for _, rule := range i.rs.Ingress.Spec.Rules {
 		for _, path := range rule.HTTP.Paths {
@@ -198,8 +219,13 @@ func (i *IngressMapper) getHealthStatus() map[string]metrics.HealthStatus {
 			// check if service exists
 			selector := types.NamespacedName{Namespace: i.rs.NamespacedName.Namespace, Name: path.Backend.Service.Name}
 			service := &corev1.Service{}
-			err := i.c.Get(context.TODO(), selector, service)
+			err := i.c.Get(ctx, selector, service)
 			if err != nil {
+				log.Debug().
+					Err(err).
+					Str("host", rule.Host).
+					Str("service", selector.String()).
+					Msg("Unable to read backend service")
 				if errors.IsNotFound(err) {
 					serviceHealth[rule.Host] = metrics.NotFound
 					continue
@@ -211,8 +237,13 @@ func (i *IngressMapper) getHealthStatus() map[string]metrics.HealthStatus {
 			// check if service endpoint exists
 			serviceHealth[rule.Host] = metrics.Unhealthy
 			ep := &corev1.Endpoints{}
-			err = i.c.Get(context.TODO(), selector, ep)
+			err = i.c.Get(ctx, selector, ep)
 			if err != nil {
+				log.Debug().
+					Err(err).
+					Str("host", rule.Host).
+					Str("service", selector.String()).
+					Msg("Unable to read endpoints of backend service")
 				continue
 			}
 
@@ -221,19 +252,24 @@ func (i *IngressMapper) getHealthStatus() map[string]metrics.HealthStatus {
 					serviceHealth[rule.Host] = metrics.Healthy
 				}
 			}
+			log.Trace().
+				Str("host", rule.Host).
+				Str("service", selector.String()).
+				Str("health", serviceHealth[rule.Host].String()).
+				Msg("Checked backend service")
 		}
 	}
 	return serviceHealth
 }
 
-func (i *IngressMapper) TryRemoveDNSEndpoint() (r Result, err error) {
+func (i *IngressMapper) TryRemoveDNSEndpoint(ctx context.Context) (r Result, err error) {
 	dnsEndpoint := &externaldns.DNSEndpoint{}
-	err = i.c.Get(context.TODO(), i.rs.NamespacedName, dnsEndpoint)
+	err = i.c.Get(ctx, i.rs.NamespacedName, dnsEndpoint)
 	r, _ = i.getConverterResult(err)
 	if r == ResultNotFound || r == ResultError {
 		return r, nil
 	}
-	err = i.c.Delete(context.TODO(), dnsEndpoint)
+	err = i.c.Delete(ctx, dnsEndpoint)
 	r, err = i.getConverterResult(err)
 	if r == ResultExists {
 		return ResultEndpointDeleted, nil
@@ -241,6 +277,43 @@ func (i *IngressMapper) TryRemoveDNSEndpoint() (r Result, err error) {
 	return r, err
 }
 
+// HasOtherAnnotatedResources returns true if there is at least one more ingress in the cluster annotated
+// by k8gb.io/strategy, which is selected by this instance and not marked to be deleted
+func (i *IngressMapper) HasOtherAnnotatedResources(ctx context.Context) (bool, error) {
+	selector := NewIngressSelector(i.config)
+	ingList := &netv1.IngressList{}
+	err := i.c.List(ctx, ingList)
+	if err != nil {
+		return false, err
+	}
+	for j := range ingList.Items {
+		ing := &ingList.Items[j]
+		if ing.Namespace == i.rs.NamespacedName.Namespace && ing.Name == i.rs.NamespacedName.Name {
+			continue
+		}
+		if ing.GetDeletionTimestamp() != nil || !selector.Matches(ing) {
+			continue
+		}
+		if _, found := ing.GetAnnotations()[AnnotationStrategy]; found {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// digA resolves hostname of the load balancer within span
+func (i *IngressMapper) digA(ctx context.Context, hostname string) (ips []string, err error) {
+	ctx, span := tracing.Start(ctx, "DigA", tracing.RecordKey.String(hostname))
+	defer func() { tracing.End(span, err) }()
+	ips, err = i.dig.DigA(ctx, hostname)
+	logging.FromContext(ctx).Trace().
+		Err(err).
+		Str("hostname", hostname).
+		Strs("ips", ips).
+		Msg("Resolved load balancer hostname")
+	return ips, err
+}
+
 func (i *IngressMapper) getConverterResult(err error) (Result, error) {
 	if err != nil && errors.IsNotFound(err) {
 		return ResultNotFound, nil
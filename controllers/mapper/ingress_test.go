@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -73,8 +74,20 @@ func TestIngressMapperRemovingFinalizer(t *testing.T) {
 		{
 			name: "K8gb Finalizer Removed Without DeletationTimestamp", expectedResult: ResultContinue, expectedErr: nil,
 			expectedFinalizers: []string{Finalizer}, finalizationLogicCalled: false, updateError: nil, finalizationLogicError: nil,
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{Finalizer},
+				Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}},
+		},
+		{
+			name: "Remove K8gb Finalizer When Annotation Removed", expectedResult: ResultFinalizerRemoved, expectedErr: nil,
+			expectedFinalizers: []string{}, finalizationLogicCalled: true, updateError: nil, finalizationLogicError: nil,
 			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{Finalizer}}},
 		},
+		{
+			name: "Remove K8gb Finalizer When Not Selected", expectedResult: ResultFinalizerRemoved, expectedErr: nil,
+			expectedFinalizers: []string{}, finalizationLogicCalled: true, updateError: nil, finalizationLogicError: nil,
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{Finalizer}, Labels: map[string]string{"owner": "team-b"},
+				Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}},
+		},
 		{
 			name: "Update Error", expectedResult: ResultError, expectedErr: serr, expectedFinalizers: []string{},
 			finalizationLogicCalled: true, updateError: serr, finalizationLogicError: nil,
@@ -98,8 +111,9 @@ func TestIngressMapperRemovingFinalizer(t *testing.T) {
 			m := M(t)
 			m.Client.(*MockClient).EXPECT().Update(gomock.Any(), gomock.Any()).Return(test.updateError).Times(1)
 			// act
-			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, &depresolver.Config{}, utils.NewUDPDig()))
-			result, err := rs.TryRemoveFinalizer(func(state *LoopState) error {
+			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, &depresolver.Config{IngressLabelSelector: "owner notin (team-b)"},
+				utils.NewUDPDig()), Defaults{})
+			result, err := rs.TryRemoveFinalizer(context.TODO(), func(_ context.Context, state *LoopState) error {
 				fainalzationLogicCalled = true
 				return test.finalizationLogicError
 			})
@@ -148,8 +162,8 @@ func TestIngressMapperInjectingFinalizer(t *testing.T) {
 			m := M(t)
 			m.Client.(*MockClient).EXPECT().Update(gomock.Any(), gomock.Any()).Return(test.updateError).Times(1)
 			// act
-			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, &depresolver.Config{}, utils.NewUDPDig()))
-			result, err := rs.TryInjectFinalizer()
+			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, &depresolver.Config{}, utils.NewUDPDig()), Defaults{})
+			result, err := rs.TryInjectFinalizer(context.TODO())
 			// assert
 			assert.Equal(t, test.expectedResult, result)
 			assert.Equal(t, test.expectedErr != nil, err != nil)
@@ -160,6 +174,13 @@ func TestIngressMapperInjectingFinalizer(t *testing.T) {
 	}
 }
 
+var rrSpec = Spec{Type: depresolver.RoundRobinStrategy, DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300}
+var rrSources = map[string]string{AnnotationStrategy: SourceIngress, AnnotationPrimaryGeoTag: SourceDefault,
+	AnnotationDNSTTLSeconds: SourceDefault, AnnotationSplitBrainThresholdSeconds: SourceDefault, AnnotationWeightJSON: SourceDefault}
+var foSpec = Spec{Type: depresolver.FailoverStrategy, PrimaryGeoTag: "eu", DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300}
+var foSources = map[string]string{AnnotationStrategy: SourceIngress, AnnotationPrimaryGeoTag: SourceIngress,
+	AnnotationDNSTTLSeconds: SourceDefault, AnnotationSplitBrainThresholdSeconds: SourceDefault, AnnotationWeightJSON: SourceDefault}
+
 func TestIngressGetStatus(t *testing.T) {
 	var serr = fmt.Errorf("error")
 	ingressNoBackend := RRon2().Ingress.DeepCopy()
@@ -183,6 +204,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 
 		{name: "RR on TwoClusters Service Error", ingress: RRon2().Ingress, config: &depresolver.Config{ClusterGeoTag: "us"},
@@ -190,6 +212,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Unhealthy},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}, endpointError: nil},
 
 		{name: "RR on TwoClusters Service NotFound", ingress: RRon2().Ingress, config: &depresolver.Config{ClusterGeoTag: "us"},
@@ -198,6 +221,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.NotFound},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 
 		{name: "RR on TwoClusters Missing Rules", ingress: ingressNoRules, config: &depresolver.Config{ClusterGeoTag: "us"},
@@ -206,6 +230,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 
 		{name: "RR on TwoClusters Ingress Backend Not Specified", ingress: ingressNoBackend, config: &depresolver.Config{ClusterGeoTag: "us"},
@@ -214,6 +239,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.NotFound},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 
 		{name: "RR on TwoClusters Endpoint Error", ingress: RRon2().Ingress, config: &depresolver.Config{ClusterGeoTag: "us"},
@@ -222,6 +248,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Unhealthy},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 
 		{name: "RR on TwoClusters DNSEndpoint Error", ingress: RRon2().Ingress, config: &depresolver.Config{ClusterGeoTag: "us"},
@@ -230,6 +257,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
 				HealthyRecords: map[string][]string{},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 
 		{name: "FO on TwoClusters US", ingress: FOon2c2().Ingress, config: &depresolver.Config{ClusterGeoTag: "us"}, endpointError: nil,
@@ -237,6 +265,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "us", Hosts: "demo.cloud.example.com",
+				Spec: foSpec, SpecSources: foSources,
 			}},
 
 		{name: "FO on TwoClusters EU", ingress: FOon2c1().Ingress, config: &depresolver.Config{ClusterGeoTag: "eu"}, endpointError: nil,
@@ -244,6 +273,7 @@ func TestIngressGetStatus(t *testing.T) {
 			expectedStatus: Status{ServiceHealth: map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
 				HealthyRecords: map[string][]string{"demo.cloud.example.com": {"172.18.0.3", "172.18.0.4"}},
 				GeoTag:         "eu", Hosts: "demo.cloud.example.com",
+				Spec: foSpec, SpecSources: foSources,
 			}},
 	}
 	for _, test := range tests {
@@ -270,8 +300,8 @@ func TestIngressGetStatus(t *testing.T) {
 				})
 
 			// act
-			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, test.config, utils.NewUDPDig()))
-			status := rs.GetStatus()
+			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, test.config, utils.NewUDPDig()), Defaults{})
+			status := rs.GetStatus(context.TODO())
 
 			// assert
 			assert.True(t, reflect.DeepEqual(test.expectedStatus, status))
@@ -306,6 +336,7 @@ func TestIngressGetStatusMulti(t *testing.T) {
 					"demo.cloud.example.com":  {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"},
 					"rodeo.cloud.example.com": {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"}},
 				GeoTag: "us", Hosts: "demo.cloud.example.com, rodeo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 		{name: "RR on TwoClusters With Two Hosts Pointing To Different Services",
 			ingress: twoHostsDifferentService.Ingress,
@@ -317,6 +348,7 @@ func TestIngressGetStatusMulti(t *testing.T) {
 					"demo.cloud.example.com":  {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"},
 					"rodeo.cloud.example.com": {"172.20.0.3", "172.20.0.4", "172.20.0.5"}},
 				GeoTag: "us", Hosts: "demo.cloud.example.com, rodeo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 		{name: "RR on TwoClusters With Two Hosts Pointing To Different Services - Unhealthy",
 			ingress: twoHostsDifferentService.Ingress,
@@ -328,6 +360,7 @@ func TestIngressGetStatusMulti(t *testing.T) {
 					"demo.cloud.example.com":  {"172.18.0.5", "172.18.0.6", "172.18.0.3", "172.18.0.4"},
 					"rodeo.cloud.example.com": {"172.20.0.3", "172.20.0.4", "172.20.0.5"}},
 				GeoTag: "us", Hosts: "demo.cloud.example.com, rodeo.cloud.example.com",
+				Spec: rrSpec, SpecSources: rrSources,
 			}},
 	}
 	for _, test := range tests {
@@ -366,8 +399,8 @@ func TestIngressGetStatusMulti(t *testing.T) {
 				})
 
 			// act
-			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, test.config, utils.NewUDPDig()))
-			status := rs.GetStatus()
+			rs, _ := fromIngress(test.ingress, NewIngressMapper(m.Client, test.config, utils.NewUDPDig()), Defaults{})
+			status := rs.GetStatus(context.TODO())
 
 			// assert
 			assert.True(t, reflect.DeepEqual(test.expectedStatus, status))
@@ -416,15 +449,15 @@ func TestIngressGetExposedIPs(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			// arrange
 			m := M(t)
-			m.Dig.(*MockDigger).EXPECT().DigA(demo).Times(1).Return([]string{"172.18.0.5", "172.18.0.6"}, nil)
-			m.Dig.(*MockDigger).EXPECT().DigA(rodeo).Times(1).Return([]string{"172.18.0.7"}, nil)
-			m.Dig.(*MockDigger).EXPECT().DigA(zulu).Times(1).Return(nil, serr)
+			m.Dig.(*MockDigger).EXPECT().DigA(gomock.Any(), demo).Times(1).Return([]string{"172.18.0.5", "172.18.0.6"}, nil)
+			m.Dig.(*MockDigger).EXPECT().DigA(gomock.Any(), rodeo).Times(1).Return([]string{"172.18.0.7"}, nil)
+			m.Dig.(*MockDigger).EXPECT().DigA(gomock.Any(), zulu).Times(1).Return(nil, serr)
 			ingress := RRon2().Ingress.DeepCopy()
 			ingress.Status.LoadBalancer.Ingress = test.ingressStatusRecords
 
 			// act
-			rs, _ := fromIngress(ingress, NewIngressMapper(m.Client, &depresolver.Config{}, m.Dig))
-			ips, err := rs.GetExposedIPs()
+			rs, _ := fromIngress(ingress, NewIngressMapper(m.Client, &depresolver.Config{}, m.Dig), Defaults{})
+			ips, err := rs.GetExposedIPs(context.TODO())
 
 			// assert
 			assert.Equal(t, test.expectedIPs, ips)
@@ -486,3 +519,68 @@ func TestIngressEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestIngressHasOtherAnnotatedResources(t *testing.T) {
+	var serr = fmt.Errorf("list error")
+	var tx = metav1.Now()
+	var nginx, traefik = "nginx", "traefik"
+	annotated := map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}
+	var tests = []struct {
+		name           string
+		ingresses      []netv1.Ingress
+		listErr        error
+		config         depresolver.Config
+		expectedResult bool
+	}{
+		{name: "No Ingresses", ingresses: []netv1.Ingress{}, expectedResult: false},
+		{name: "Only Current Ingress", expectedResult: false,
+			ingresses: []netv1.Ingress{{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "demo", Annotations: annotated}}}},
+		{name: "Other Ingress Without Annotation", expectedResult: false,
+			ingresses: []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "demo", Annotations: annotated}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing2", Namespace: "demo"}},
+			}},
+		{name: "Other Annotated Ingress Marked To Be Deleted", expectedResult: false,
+			ingresses: []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "demo", Annotations: annotated}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing2", Namespace: "demo", Annotations: annotated, DeletionTimestamp: &tx}},
+			}},
+		{name: "Same Name In Other Namespace", expectedResult: true,
+			ingresses: []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "demo", Annotations: annotated}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "other", Annotations: annotated}},
+			}},
+		{name: "Other Annotated Ingress Of Other Class", expectedResult: false,
+			ingresses: []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "demo", Annotations: annotated}, Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing2", Namespace: "demo", Annotations: annotated}, Spec: netv1.IngressSpec{IngressClassName: &traefik}},
+			}, config: depresolver.Config{IngressClasses: []string{nginx}}},
+		{name: "Other Annotated Ingress Not Watched Namespace", expectedResult: false,
+			ingresses: []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: "demo", Annotations: annotated}, Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "ing2", Namespace: "other", Annotations: annotated}, Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+			}, config: depresolver.Config{WatchNamespaces: []string{"demo"}}},
+		{name: "List Error", ingresses: []netv1.Ingress{}, listErr: serr, expectedResult: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			m := M(t)
+			m.Client.(*MockClient).EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(arg0 interface{}, list *netv1.IngressList, args ...interface{}) error {
+					list.Items = test.ingresses
+					return test.listErr
+				}).Times(1)
+			ingress := RRon2().Ingress.DeepCopy()
+			ingress.Namespace = "demo"
+
+			// act
+			rs, _ := fromIngress(ingress, NewIngressMapper(m.Client, &test.config, m.Dig), Defaults{})
+			result, err := rs.HasOtherAnnotatedResources(context.TODO())
+
+			// assert
+			assert.Equal(t, test.expectedResult, result)
+			assert.Equal(t, test.listErr != nil, err != nil)
+		})
+	}
+}
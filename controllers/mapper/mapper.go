@@ -18,6 +18,8 @@ limitations under the License.
 Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
+import "context"
+
 type Result int
 
 const RecordTypeA = "A"
@@ -31,6 +33,8 @@ const (
 	ResultFinalizerInstalled
 	ResultEndpointDeleted
 	ResultContinue
+	// ResultExistsButNotSelected is returned for resource which doesn't match the selector of this instance
+	ResultExistsButNotSelected
 )
 
 func (r Result) IsIn(m ...Result) bool {
@@ -45,12 +49,15 @@ func (r Result) IsIn(m ...Result) bool {
 // Mapper is wrapper around resource. Mappers are an only way to access resources
 type Mapper interface {
 	Equal(*LoopState) bool
-	GetStatus() Status
-	GetExposedIPs() ([]string, error)
-	TryInjectFinalizer() (Result, error)
-	TryRemoveFinalizer(func(*LoopState) error) (Result, error)
+	GetStatus(context.Context) Status
+	GetExposedIPs(context.Context) ([]string, error)
+	TryInjectFinalizer(context.Context) (Result, error)
+	TryRemoveFinalizer(context.Context, func(context.Context, *LoopState) error) (Result, error)
 	SetReference(*LoopState)
-	UpdateStatusAnnotation() error
+	UpdateStatusAnnotation(context.Context) error
 	// TryRemoveDNSEndpoint removes local DNSEndpoint if exists
-	TryRemoveDNSEndpoint() (Result, error)
+	TryRemoveDNSEndpoint(context.Context) (Result, error)
+	// HasOtherAnnotatedResources returns true if any other resource in the cluster, which is not marked
+	// to be deleted, is annotated by k8gb strategy
+	HasOtherAnnotatedResources(context.Context) (bool, error)
 }
@@ -31,44 +31,108 @@ import (
 )
 
 type ProviderMapper interface {
-	Get(types.NamespacedName) (*LoopState, Result, error)
-	FromIngress(*netv1.Ingress) (*LoopState, error)
+	Get(context.Context, types.NamespacedName) (*LoopState, Result, error)
+	FromIngress(context.Context, *netv1.Ingress) (*LoopState, error)
 	FromGatewayAPI() (*LoopState, error)
+	List(context.Context) ([]*LoopState, error)
 }
 
 type CommonProvider struct {
-	c      client.Client
-	config *depresolver.Config
+	c         client.Client
+	apiReader client.Reader
+	config    *depresolver.Config
+	selector  *IngressSelector
+	defaults  *DefaultsResolver
 }
 
-func NewCommonProvider(c client.Client, config *depresolver.Config) *CommonProvider {
+// NewCommonProvider creates CommonProvider. Ingresses which stop matching IngressLabelSelector disappear from the
+// informer cache, so they are read by apiReader to be cleaned up; nil apiReader treats them as not existing
+func NewCommonProvider(c client.Client, apiReader client.Reader, config *depresolver.Config) *CommonProvider {
 	return &CommonProvider{
-		c:      c,
-		config: config,
+		c:         c,
+		apiReader: apiReader,
+		config:    config,
+		selector:  NewIngressSelector(config),
+		defaults:  NewDefaultsResolver(c, config),
 	}
 }
 
-func (c *CommonProvider) Get(selector types.NamespacedName) (rs *LoopState, result Result, err error) {
+func (c *CommonProvider) Get(ctx context.Context, selector types.NamespacedName) (rs *LoopState, result Result, err error) {
 	// TODO: implement gateway part of Get. Only ingress is implemented
 	// e.g: You can try read GW first, if not success than Ingress
+	// ingresses outside of watched namespaces are treated as not existing, so they are never touched
+	if !c.selector.MatchesNamespace(selector.Namespace) {
+		return nil, ResultNotFound, nil
+	}
 	var ing = &netv1.Ingress{}
-	err = c.c.Get(context.TODO(), selector, ing)
+	err = c.c.Get(ctx, selector, ing)
 	result, err = c.getConverterResult(err, ing)
+	if result == ResultNotFound && c.apiReader != nil && !c.selector.Labels().Empty() {
+		ing = &netv1.Ingress{}
+		err = c.apiReader.Get(ctx, selector, ing)
+		result, err = c.getConverterResult(err, ing)
+		if result != ResultError && result != ResultNotFound && c.selector.Matches(ing) {
+			// ingress is not in the cache yet
+			return nil, ResultNotFound, nil
+		}
+	}
 	if result == ResultError {
 		return nil, result, err
 	}
-	rs, err = c.FromIngress(ing)
+	// ingress which stopped matching label selector or ingress class is cleaned up like ingress without annotation.
+	// Annotations are not parsed, because the ingress is not handled anymore
+	if result != ResultNotFound && !c.selector.Matches(ing) {
+		rs, _ = fromIngress(ing, NewIngressMapper(c.c, c.config, utils.NewUDPDig(c.config.EdgeDNSServers...)), Defaults{})
+		return rs, ResultExistsButNotSelected, nil
+	}
+	rs, err = c.FromIngress(ctx, ing)
 	if err != nil {
 		return nil, ResultError, err
 	}
 	return rs, result, err
 }
 
-// FromIngress LoopState from Ingress instance
-func (c *CommonProvider) FromIngress(ingress *netv1.Ingress) (*LoopState, error) {
+// FromIngress LoopState from Ingress instance. Ingress annotations are merged over namespace and cluster defaults
+func (c *CommonProvider) FromIngress(ctx context.Context, ingress *netv1.Ingress) (*LoopState, error) {
 	// TODO: check here
 	m := NewIngressMapper(c.c, c.config, utils.NewUDPDig(c.config.EdgeDNSServers...))
-	return fromIngress(ingress, m)
+	var defaults Defaults
+	if ingress != nil {
+		var err error
+		if defaults, err = c.defaults.Resolve(ctx, ingress.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	return fromIngress(ingress, m, defaults)
+}
+
+// List returns LoopState for every selected and annotated resource which is not being deleted. Resources
+// with invalid annotations are skipped
+func (c *CommonProvider) List(ctx context.Context) (states []*LoopState, err error) {
+	var ingList = &netv1.IngressList{}
+	var opts []client.ListOption
+	if !c.selector.Labels().Empty() {
+		opts = append(opts, client.MatchingLabelsSelector{Selector: c.selector.Labels()})
+	}
+	err = c.c.List(ctx, ingList, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if ing.DeletionTimestamp != nil || !c.selector.Matches(ing) {
+			continue
+		}
+		if _, found := ing.GetAnnotations()[AnnotationStrategy]; !found {
+			continue
+		}
+		rs, err := c.FromIngress(ctx, ing)
+		if err != nil {
+			continue
+		}
+		states = append(states, rs)
+	}
+	return states, nil
 }
 
 func (c *CommonProvider) FromGatewayAPI() (*LoopState, error) {
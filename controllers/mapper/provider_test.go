@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -101,7 +102,7 @@ func TestGetIngress(t *testing.T) {
 				})
 
 			// act
-			rs, result, err := NewCommonProvider(m.Client, &depresolver.Config{}).Get(test.nn)
+			rs, result, err := NewCommonProvider(m.Client, nil, &depresolver.Config{}).Get(context.TODO(), test.nn)
 
 			// assert
 			assert.Equal(t, test.expectedResult, result)
@@ -118,6 +119,161 @@ func TestGetIngress(t *testing.T) {
 	}
 }
 
+func TestListIngresses(t *testing.T) {
+	// arrange
+	now := metav1.Now()
+	ingresses := []netv1.Ingress{
+		{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Namespace: "a",
+			Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "not-annotated", Namespace: "a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "a", DeletionTimestamp: &now,
+			Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "b",
+			Annotations: map[string]string{AnnotationStrategy: "NON-EXISTING"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Namespace: "b",
+			Annotations: map[string]string{AnnotationStrategy: depresolver.GeoStrategy}}},
+	}
+	m := M(t)
+	m.Client.(*MockClient).EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, args ...interface{}) error {
+			list.Items = ingresses
+			return nil
+		})
+
+	// act
+	states, err := NewCommonProvider(m.Client, nil, &depresolver.Config{}).List(context.TODO())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.Equal(t, types.NamespacedName{Namespace: "a", Name: "annotated"}, states[0].NamespacedName)
+	assert.Equal(t, types.NamespacedName{Namespace: "b", Name: "annotated"}, states[1].NamespacedName)
+}
+
+func TestGetIngressNotSelected(t *testing.T) {
+	// arrange
+	nn := types.NamespacedName{Namespace: "a", Name: "annotated"}
+	m := M(t)
+	m.Client.(*MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0, arg1 interface{}, ing *netv1.Ingress, args ...interface{}) error {
+			ing.ObjectMeta = metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace, Labels: map[string]string{"owner": "team-b"},
+				Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}
+			return nil
+		})
+
+	// act
+	rs, result, err := NewCommonProvider(m.Client, nil, &depresolver.Config{IngressLabelSelector: "owner=team-a"}).Get(context.TODO(), nn)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, ResultExistsButNotSelected, result)
+	assert.Equal(t, nn, rs.NamespacedName)
+}
+
+func TestGetIngressLeftCache(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "a", Name: "annotated"}
+	notFound := errors.NewNotFound(schema.GroupResource{}, nn.Name)
+	var tests = []struct {
+		name           string
+		labels         map[string]string
+		apiErr         error
+		expectedResult Result
+	}{
+		{name: "Not Selected", labels: map[string]string{"owner": "team-b"}, expectedResult: ResultExistsButNotSelected},
+		{name: "Deleted", apiErr: notFound, expectedResult: ResultNotFound},
+		{name: "Not Cached Yet", labels: map[string]string{"owner": "team-a"}, expectedResult: ResultNotFound},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			cached := M(t)
+			api := M(t)
+			cached.Client.(*MockClient).EXPECT().Get(gomock.Any(), nn, gomock.Any()).Return(notFound).Times(1)
+			api.Client.(*MockClient).EXPECT().Get(gomock.Any(), nn, gomock.Any()).DoAndReturn(
+				func(arg0, arg1 interface{}, ing *netv1.Ingress, args ...interface{}) error {
+					ing.ObjectMeta = metav1.ObjectMeta{Name: nn.Name, Namespace: nn.Namespace, Labels: test.labels,
+						Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}
+					return test.apiErr
+				}).Times(1)
+
+			// act
+			_, result, err := NewCommonProvider(cached.Client, api.Client, &depresolver.Config{IngressLabelSelector: "owner=team-a"}).
+				Get(context.TODO(), nn)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestGetIngressNotWatchedNamespace(t *testing.T) {
+	// arrange
+	nn := types.NamespacedName{Namespace: "b", Name: "annotated"}
+	m := M(t)
+
+	// act
+	rs, result, err := NewCommonProvider(m.Client, nil, &depresolver.Config{WatchNamespaces: []string{"a"}}).Get(context.TODO(), nn)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, ResultNotFound, result)
+	assert.Nil(t, rs)
+}
+
+func TestListSelectedIngresses(t *testing.T) {
+	// arrange
+	nginx := "nginx"
+	ingresses := []netv1.Ingress{
+		{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Namespace: "a",
+			Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}},
+			Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-class", Namespace: "a",
+			Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Namespace: "b",
+			Annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}},
+			Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+	}
+	m := M(t)
+	m.Client.(*MockClient).EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, opts ...client.ListOption) error {
+			assert.Len(t, opts, 1)
+			list.Items = ingresses
+			return nil
+		})
+	config := &depresolver.Config{WatchNamespaces: []string{"a"}, IngressClasses: []string{"nginx"}, IngressLabelSelector: "owner=team-a"}
+
+	// act
+	states, err := NewCommonProvider(m.Client, nil, config).List(context.TODO())
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, states, 0)
+	config.IngressLabelSelector = ""
+	m.Client.(*MockClient).EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, opts ...client.ListOption) error {
+			list.Items = ingresses
+			return nil
+		})
+	states, err = NewCommonProvider(m.Client, nil, config).List(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, states, 1)
+	assert.Equal(t, types.NamespacedName{Namespace: "a", Name: "annotated"}, states[0].NamespacedName)
+}
+
+func TestListIngressesError(t *testing.T) {
+	// arrange
+	m := M(t)
+	m.Client.(*MockClient).EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("list error"))
+
+	// act
+	states, err := NewCommonProvider(m.Client, nil, &depresolver.Config{}).List(context.TODO())
+
+	// assert
+	assert.Error(t, err)
+	assert.Nil(t, states)
+}
+
 func M(t *testing.T) struct {
 	Client client.Client
 	Dig    utils.Digger
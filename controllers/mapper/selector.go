@@ -0,0 +1,89 @@
+package mapper
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// legacyIngressClassAnnotation is used by ingresses created before spec.ingressClassName was introduced
+const legacyIngressClassAnnotation = "kubernetes.io/ingress.class"
+
+// IngressSelector decides which ingresses are handled by this k8gb instance. Empty WatchNamespaces,
+// IngressLabelSelector and IngressClasses select everything
+type IngressSelector struct {
+	namespaces map[string]bool
+	classes    map[string]bool
+	labels     labels.Selector
+}
+
+// NewIngressSelector creates IngressSelector from configuration. Configuration is validated by depresolver,
+// so an unparsable label selector selects nothing rather than everything
+func NewIngressSelector(config *depresolver.Config) *IngressSelector {
+	s := &IngressSelector{
+		namespaces: map[string]bool{},
+		classes:    map[string]bool{},
+	}
+	for _, ns := range config.WatchNamespaces {
+		s.namespaces[ns] = true
+	}
+	for _, class := range config.IngressClasses {
+		s.classes[class] = true
+	}
+	var err error
+	if s.labels, err = labels.Parse(config.IngressLabelSelector); err != nil {
+		s.labels = labels.Nothing()
+	}
+	return s
+}
+
+// Labels returns label selector applied on ingresses
+func (s *IngressSelector) Labels() labels.Selector {
+	return s.labels
+}
+
+// MatchesNamespace returns true if ingresses in namespace can be handled
+func (s *IngressSelector) MatchesNamespace(namespace string) bool {
+	return len(s.namespaces) == 0 || s.namespaces[namespace]
+}
+
+// Matches returns true if ingress is in watched namespace, matches label selector and ingress class
+func (s *IngressSelector) Matches(ing *netv1.Ingress) bool {
+	if ing == nil {
+		return false
+	}
+	if !s.MatchesNamespace(ing.Namespace) {
+		return false
+	}
+	if !s.labels.Matches(labels.Set(ing.Labels)) {
+		return false
+	}
+	return len(s.classes) == 0 || s.classes[ingressClass(ing)]
+}
+
+// ingressClass reads spec.ingressClassName, falls back to legacy annotation
+func ingressClass(ing *netv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations[legacyIngressClassAnnotation]
+}
@@ -0,0 +1,79 @@
+package mapper
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIngressSelector(t *testing.T) {
+	nginx := "nginx"
+	var tests = []struct {
+		name     string
+		config   depresolver.Config
+		ingress  *netv1.Ingress
+		expected bool
+	}{
+		{name: "empty selection matches everything", config: depresolver.Config{},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "a"}}, expected: true},
+		{name: "nil ingress", config: depresolver.Config{}, ingress: nil, expected: false},
+		{name: "watched namespace", config: depresolver.Config{WatchNamespaces: []string{"a", "b"}},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "b"}}, expected: true},
+		{name: "not watched namespace", config: depresolver.Config{WatchNamespaces: []string{"a", "b"}},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "c"}}, expected: false},
+		{name: "matching labels", config: depresolver.Config{IngressLabelSelector: "owner in (team-a,team-b)"},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"owner": "team-a"}}}, expected: true},
+		{name: "not matching labels", config: depresolver.Config{IngressLabelSelector: "owner in (team-a,team-b)"},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"owner": "team-c"}}}, expected: false},
+		{name: "missing labels", config: depresolver.Config{IngressLabelSelector: "owner=team-a"},
+			ingress: &netv1.Ingress{}, expected: false},
+		{name: "invalid label selector matches nothing", config: depresolver.Config{IngressLabelSelector: "owner in team-a"},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"owner": "team-a"}}}, expected: false},
+		{name: "matching ingress class", config: depresolver.Config{IngressClasses: []string{"nginx"}},
+			ingress: &netv1.Ingress{Spec: netv1.IngressSpec{IngressClassName: &nginx}}, expected: true},
+		{name: "matching legacy ingress class annotation", config: depresolver.Config{IngressClasses: []string{"nginx"}},
+			ingress:  &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{legacyIngressClassAnnotation: "nginx"}}},
+			expected: true},
+		{name: "ingress class name wins over annotation", config: depresolver.Config{IngressClasses: []string{"traefik"}},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{legacyIngressClassAnnotation: "traefik"}},
+				Spec: netv1.IngressSpec{IngressClassName: &nginx}}, expected: false},
+		{name: "missing ingress class", config: depresolver.Config{IngressClasses: []string{"nginx"}},
+			ingress: &netv1.Ingress{}, expected: false},
+		{name: "all criteria", config: depresolver.Config{WatchNamespaces: []string{"a"}, IngressLabelSelector: "owner=team-a",
+			IngressClasses: []string{"nginx"}},
+			ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "a", Labels: map[string]string{"owner": "team-a"}},
+				Spec: netv1.IngressSpec{IngressClassName: &nginx}}, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			s := NewIngressSelector(&test.config)
+			// act
+			matches := s.Matches(test.ingress)
+			// assert
+			assert.Equal(t, test.expected, matches)
+		})
+	}
+}
@@ -19,7 +19,9 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -39,9 +41,14 @@ const (
 	AnnotationSplitBrainThresholdSeconds = "k8gb.io/splitbrain-threshold-seconds"
 	AnnotationWeightJSON                 = "k8gb.io/weights"
 	AnnotationStatus                     = "k8gb.io/status"
+	AnnotationDecommission               = "k8gb.io/decommission"
+	AnnotationDebug                      = "k8gb.io/debug"
 	Finalizer                            = "k8gb.io/finalizer"
 )
 
+// ErrInvalidAnnotations is returned when k8gb annotations of resource can't be parsed
+var ErrInvalidAnnotations = errors.New("invalid k8gb annotations")
+
 type Spec struct {
 	PrimaryGeoTag              string         `json:"primaryGeoTag"`
 	Type                       string         `json:"strategy"`
@@ -64,6 +71,14 @@ type Status struct {
 	GeoTag string `json:"geoTag"`
 	// Comma-separated list of hosts. Duplicating the value from range .spec.ingress.rules[*].host for printer column
 	Hosts string `json:"hosts,omitempty"`
+	// Effective spec resolved from ingress annotations and defaults
+	Spec Spec `json:"spec"`
+	// Source of every k8gb annotation of the effective spec; ingress, namespace, cluster or default
+	SpecSources map[string]string `json:"specSources"`
+	// Geo tags of peer clusters serving host, observed by the last reconciliation
+	Peers map[string][]string `json:"peers,omitempty"`
+	// Geo tag of cluster serving host of failover strategy, observed by the last reconciliation
+	ActiveGeoTags map[string]string `json:"activeGeoTags,omitempty"`
 }
 
 func (s Status) String() string {
@@ -82,9 +97,11 @@ type LoopState struct {
 	Spec           Spec
 	NamespacedName types.NamespacedName
 	Status         Status
+	// SpecSources source of every k8gb annotation of Spec
+	SpecSources map[string]string
 }
 
-func fromIngress(ingress *netv1.Ingress, m Mapper) (rs *LoopState, err error) {
+func fromIngress(ingress *netv1.Ingress, m Mapper, defaults Defaults) (rs *LoopState, err error) {
 	rs = &LoopState{Mapper: m}
 	rs.SetReference(rs)
 	if ingress == nil {
@@ -97,7 +114,10 @@ func fromIngress(ingress *netv1.Ingress, m Mapper) (rs *LoopState, err error) {
 		Hosts:          "",
 	}
 	rs.Ingress = ingress
-	rs.Spec, err = rs.asSpec(ingress.GetAnnotations())
+	rs.Spec, err = rs.asSpec(ingress.GetAnnotations(), defaults)
+	if err != nil {
+		err = fmt.Errorf("%w: %s", ErrInvalidAnnotations, err)
+	}
 	rs.NamespacedName = types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
 	return rs, err
 }
@@ -106,7 +126,65 @@ func fromGatewayAPI(gw *netv1.Ingress, m Mapper) (rs *LoopState, err error) {
 	panic("not implemented")
 }
 
-func (rs *LoopState) asSpec(annotations map[string]string) (result Spec, err error) {
+// Debug is true when the resource is annotated by k8gb.io/debug: "true". Reconciliation of such resource
+// is logged at trace level regardless of LOG_LEVEL
+func (rs *LoopState) Debug() bool {
+	if rs.Ingress == nil {
+		return false
+	}
+	debug, _ := strconv.ParseBool(rs.Ingress.GetAnnotations()[AnnotationDebug])
+	return debug
+}
+
+// ParseSpec parses k8gb annotations merged over defaults into Spec the same way as reconciliation does
+func ParseSpec(annotations map[string]string, defaults Defaults) (Spec, error) {
+	return new(LoopState).asSpec(annotations, defaults)
+}
+
+// ParseStatus decodes k8gb.io/status annotation written by the operator; found is false when the annotation is missing
+func ParseStatus(annotations map[string]string) (status Status, found bool, err error) {
+	value, found := annotations[AnnotationStatus]
+	if !found {
+		return status, false, nil
+	}
+	err = json.Unmarshal([]byte(value), &status)
+	return status, true, err
+}
+
+// ValidateSpec validates parsed Spec against the operator configuration. Primary geo tags and weights
+// must reference configured clusters, TTL must be positive and weights can't be negative. The deprecated
+// split brain threshold is ignored, so it is not validated
+func ValidateSpec(spec Spec, config *depresolver.Config) (err error) {
+	if spec.Type == "" {
+		return nil
+	}
+	if err = depresolver.ValidateHigherThanZero(AnnotationDNSTTLSeconds, spec.DNSTtlSeconds); err != nil {
+		return err
+	}
+	if spec.PrimaryGeoTag != "" {
+		var geoTags []string
+		for _, v := range strings.Split(spec.PrimaryGeoTag, ",") {
+			geoTags = append(geoTags, strings.TrimSpace(v))
+		}
+		if err = config.ValidateGeoTags(AnnotationPrimaryGeoTag, geoTags); err != nil {
+			return err
+		}
+	}
+	geoTags := make([]string, 0, len(spec.Weights))
+	for k, v := range spec.Weights {
+		if err = depresolver.ValidateHigherOrEqualToZero(fmt.Sprintf("%s[%s]", AnnotationWeightJSON, k), v); err != nil {
+			return err
+		}
+		geoTags = append(geoTags, k)
+	}
+	sort.Strings(geoTags)
+	return config.ValidateGeoTags(AnnotationWeightJSON, geoTags)
+}
+
+// asSpec parses annotations merged over defaults; the ingress annotations take precedence over namespace
+// defaults, followed by cluster defaults. The source of every value is stored in SpecSources
+func (rs *LoopState) asSpec(annotations map[string]string, defaults Defaults) (result Spec, err error) {
+	annotations, rs.SpecSources = defaults.merge(annotations)
 	var supportedStrategies = []string{depresolver.GeoStrategy, depresolver.FailoverStrategy, depresolver.RoundRobinStrategy}
 	toInt := func(k string, v string) (int, error) {
 		intValue, err := strconv.Atoi(v)
@@ -24,8 +24,11 @@ import (
 	"testing"
 
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
 
 	"github.com/stretchr/testify/assert"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAnnotations(t *testing.T) {
@@ -97,9 +100,93 @@ func TestAnnotations(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			spec, err := new(LoopState).asSpec(test.annotations)
+			spec, err := new(LoopState).asSpec(test.annotations, Defaults{})
 			assert.Equal(t, test.expectedError != nil, err != nil)
 			assert.True(t, reflect.DeepEqual(test.expectedSpec, spec))
 		})
 	}
 }
+
+func TestValidateSpec(t *testing.T) {
+	var tests = []struct {
+		name        string
+		annotations map[string]string
+		isValid     bool
+	}{
+		{name: "No Strategy", annotations: map[string]string{AnnotationPrimaryGeoTag: "cz"}, isValid: true},
+		{name: "RR", annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy}, isValid: true},
+		{name: "FO", annotations: map[string]string{AnnotationStrategy: depresolver.FailoverStrategy, AnnotationPrimaryGeoTag: "eu"}, isValid: true},
+		{name: "FO Multiple PrimaryGeoTags", annotations: map[string]string{AnnotationStrategy: depresolver.FailoverStrategy,
+			AnnotationPrimaryGeoTag: "us, eu"}, isValid: true},
+		{name: "FO Unknown PrimaryGeoTag", annotations: map[string]string{AnnotationStrategy: depresolver.FailoverStrategy,
+			AnnotationPrimaryGeoTag: "cz"}, isValid: false},
+		{name: "FO Redundant PrimaryGeoTag", annotations: map[string]string{AnnotationStrategy: depresolver.FailoverStrategy,
+			AnnotationPrimaryGeoTag: "eu,eu"}, isValid: false},
+		{name: "Zero TTL", annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy,
+			AnnotationDNSTTLSeconds: "0"}, isValid: false},
+		{name: "Negative SB", annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy,
+			AnnotationSplitBrainThresholdSeconds: "-1"}, isValid: true},
+		{name: "WRR", annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy,
+			AnnotationWeightJSON: "eu:10,us:0"}, isValid: true},
+		{name: "WRR Unknown GeoTag", annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy,
+			AnnotationWeightJSON: "eu:10,cz:5"}, isValid: false},
+		{name: "WRR Negative Weight", annotations: map[string]string{AnnotationStrategy: depresolver.RoundRobinStrategy,
+			AnnotationWeightJSON: "eu:10,us:-5"}, isValid: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			config := &depresolver.Config{ClusterGeoTag: "us", ExtClustersGeoTags: []string{"eu", "za"}}
+			spec, err := ParseSpec(test.annotations, Defaults{})
+			assert.NoError(t, err)
+			// act
+			err = ValidateSpec(spec, config)
+			// assert
+			assert.Equal(t, test.isValid, err == nil)
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	// arrange
+	status := Status{
+		ServiceHealth:  map[string]metrics.HealthStatus{"demo.cloud.example.com": metrics.Healthy},
+		HealthyRecords: map[string][]string{"demo.cloud.example.com": {"10.0.0.1"}},
+		GeoTag:         "eu",
+		Hosts:          "demo.cloud.example.com",
+		Spec:           Spec{Type: depresolver.RoundRobinStrategy, DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300},
+		SpecSources:    map[string]string{AnnotationStrategy: SourceIngress},
+	}
+	// act
+	parsed, found, err := ParseStatus(map[string]string{AnnotationStatus: status.String()})
+	_, missing, missingErr := ParseStatus(map[string]string{})
+	_, _, invalidErr := ParseStatus(map[string]string{AnnotationStatus: "{"})
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, status, parsed)
+	assert.False(t, missing)
+	assert.NoError(t, missingErr)
+	assert.Error(t, invalidErr)
+}
+
+func TestLoopStateDebug(t *testing.T) {
+	var tests = []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{name: "No Annotations", annotations: nil, expected: false},
+		{name: "Debug", annotations: map[string]string{AnnotationDebug: "true"}, expected: true},
+		{name: "Debug Disabled", annotations: map[string]string{AnnotationDebug: "false"}, expected: false},
+		{name: "Invalid Debug", annotations: map[string]string{AnnotationDebug: "yes please"}, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rs := &LoopState{Ingress: &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: test.annotations}}}
+			assert.Equal(t, test.expected, rs.Debug())
+		})
+	}
+	assert.False(t, new(LoopState).Debug())
+}
@@ -23,6 +23,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
@@ -55,72 +56,88 @@ func (m *MockAssistant) EXPECT() *MockAssistantMockRecorder {
 }
 
 // CoreDNSExposedIPs mocks base method.
-func (m *MockAssistant) CoreDNSExposedIPs() ([]string, error) {
+func (m *MockAssistant) CoreDNSExposedIPs(ctx context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CoreDNSExposedIPs")
+	ret := m.ctrl.Call(m, "CoreDNSExposedIPs", ctx)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CoreDNSExposedIPs indicates an expected call of CoreDNSExposedIPs.
-func (mr *MockAssistantMockRecorder) CoreDNSExposedIPs() *gomock.Call {
+func (mr *MockAssistantMockRecorder) CoreDNSExposedIPs(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoreDNSExposedIPs", reflect.TypeOf((*MockAssistant)(nil).CoreDNSExposedIPs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoreDNSExposedIPs", reflect.TypeOf((*MockAssistant)(nil).CoreDNSExposedIPs), ctx)
+}
+
+// GetDNSEndpoint mocks base method.
+func (m *MockAssistant) GetDNSEndpoint(ctx context.Context, namespace, name string) (*endpoint.DNSEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDNSEndpoint", ctx, namespace, name)
+	ret0, _ := ret[0].(*endpoint.DNSEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDNSEndpoint indicates an expected call of GetDNSEndpoint.
+func (mr *MockAssistantMockRecorder) GetDNSEndpoint(ctx, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDNSEndpoint", reflect.TypeOf((*MockAssistant)(nil).GetDNSEndpoint), ctx, namespace, name)
 }
 
 // GetExternalTargets mocks base method.
-func (m *MockAssistant) GetExternalTargets(host string, extClusterNsNames map[string]string) assistant.Targets {
+func (m *MockAssistant) GetExternalTargets(ctx context.Context, host string, extClusterNsNames map[string]string) (assistant.Targets, assistant.QueryErrors) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExternalTargets", host, extClusterNsNames)
+	ret := m.ctrl.Call(m, "GetExternalTargets", ctx, host, extClusterNsNames)
 	ret0, _ := ret[0].(assistant.Targets)
-	return ret0
+	ret1, _ := ret[1].(assistant.QueryErrors)
+	return ret0, ret1
 }
 
 // GetExternalTargets indicates an expected call of GetExternalTargets.
-func (mr *MockAssistantMockRecorder) GetExternalTargets(host, extClusterNsNames interface{}) *gomock.Call {
+func (mr *MockAssistantMockRecorder) GetExternalTargets(ctx, host, extClusterNsNames interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalTargets", reflect.TypeOf((*MockAssistant)(nil).GetExternalTargets), host, extClusterNsNames)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalTargets", reflect.TypeOf((*MockAssistant)(nil).GetExternalTargets), ctx, host, extClusterNsNames)
 }
 
 // InspectTXTThreshold mocks base method.
-func (m *MockAssistant) InspectTXTThreshold(fqdn string, splitBrainThreshold time.Duration) error {
+func (m *MockAssistant) InspectTXTThreshold(ctx context.Context, geoTag, fqdn string, splitBrainThreshold time.Duration) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InspectTXTThreshold", fqdn, splitBrainThreshold)
+	ret := m.ctrl.Call(m, "InspectTXTThreshold", ctx, geoTag, fqdn, splitBrainThreshold)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InspectTXTThreshold indicates an expected call of InspectTXTThreshold.
-func (mr *MockAssistantMockRecorder) InspectTXTThreshold(fqdn, splitBrainThreshold interface{}) *gomock.Call {
+func (mr *MockAssistantMockRecorder) InspectTXTThreshold(ctx, geoTag, fqdn, splitBrainThreshold interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectTXTThreshold", reflect.TypeOf((*MockAssistant)(nil).InspectTXTThreshold), fqdn, splitBrainThreshold)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectTXTThreshold", reflect.TypeOf((*MockAssistant)(nil).InspectTXTThreshold), ctx, geoTag, fqdn, splitBrainThreshold)
 }
 
 // RemoveEndpoint mocks base method.
-func (m *MockAssistant) RemoveEndpoint(endpointName string) error {
+func (m *MockAssistant) RemoveEndpoint(ctx context.Context, endpointName string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveEndpoint", endpointName)
+	ret := m.ctrl.Call(m, "RemoveEndpoint", ctx, endpointName)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveEndpoint indicates an expected call of RemoveEndpoint.
-func (mr *MockAssistantMockRecorder) RemoveEndpoint(endpointName interface{}) *gomock.Call {
+func (mr *MockAssistantMockRecorder) RemoveEndpoint(ctx, endpointName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEndpoint", reflect.TypeOf((*MockAssistant)(nil).RemoveEndpoint), endpointName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveEndpoint", reflect.TypeOf((*MockAssistant)(nil).RemoveEndpoint), ctx, endpointName)
 }
 
 // SaveDNSEndpoint mocks base method.
-func (m *MockAssistant) SaveDNSEndpoint(namespace string, i *endpoint.DNSEndpoint) error {
+func (m *MockAssistant) SaveDNSEndpoint(ctx context.Context, namespace string, i *endpoint.DNSEndpoint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveDNSEndpoint", namespace, i)
+	ret := m.ctrl.Call(m, "SaveDNSEndpoint", ctx, namespace, i)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveDNSEndpoint indicates an expected call of SaveDNSEndpoint.
-func (mr *MockAssistantMockRecorder) SaveDNSEndpoint(namespace, i interface{}) *gomock.Call {
+func (mr *MockAssistantMockRecorder) SaveDNSEndpoint(ctx, namespace, i interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDNSEndpoint", reflect.TypeOf((*MockAssistant)(nil).SaveDNSEndpoint), namespace, i)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDNSEndpoint", reflect.TypeOf((*MockAssistant)(nil).SaveDNSEndpoint), ctx, namespace, i)
 }
@@ -23,6 +23,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -67,32 +68,47 @@ func (mr *MockMapperMockRecorder) Equal(arg0 interface{}) *gomock.Call {
 }
 
 // GetExposedIPs mocks base method.
-func (m *MockMapper) GetExposedIPs() ([]string, error) {
+func (m *MockMapper) GetExposedIPs(arg0 context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExposedIPs")
+	ret := m.ctrl.Call(m, "GetExposedIPs", arg0)
 	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetExposedIPs indicates an expected call of GetExposedIPs.
-func (mr *MockMapperMockRecorder) GetExposedIPs() *gomock.Call {
+func (mr *MockMapperMockRecorder) GetExposedIPs(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExposedIPs", reflect.TypeOf((*MockMapper)(nil).GetExposedIPs))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExposedIPs", reflect.TypeOf((*MockMapper)(nil).GetExposedIPs), arg0)
 }
 
 // GetStatus mocks base method.
-func (m *MockMapper) GetStatus() mapper.Status {
+func (m *MockMapper) GetStatus(arg0 context.Context) mapper.Status {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStatus")
+	ret := m.ctrl.Call(m, "GetStatus", arg0)
 	ret0, _ := ret[0].(mapper.Status)
 	return ret0
 }
 
 // GetStatus indicates an expected call of GetStatus.
-func (mr *MockMapperMockRecorder) GetStatus() *gomock.Call {
+func (mr *MockMapperMockRecorder) GetStatus(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockMapper)(nil).GetStatus))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockMapper)(nil).GetStatus), arg0)
+}
+
+// HasOtherAnnotatedResources mocks base method.
+func (m *MockMapper) HasOtherAnnotatedResources(arg0 context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOtherAnnotatedResources", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOtherAnnotatedResources indicates an expected call of HasOtherAnnotatedResources.
+func (mr *MockMapperMockRecorder) HasOtherAnnotatedResources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOtherAnnotatedResources", reflect.TypeOf((*MockMapper)(nil).HasOtherAnnotatedResources), arg0)
 }
 
 // SetReference mocks base method.
@@ -108,60 +124,60 @@ func (mr *MockMapperMockRecorder) SetReference(arg0 interface{}) *gomock.Call {
 }
 
 // TryInjectFinalizer mocks base method.
-func (m *MockMapper) TryInjectFinalizer() (mapper.Result, error) {
+func (m *MockMapper) TryInjectFinalizer(arg0 context.Context) (mapper.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TryInjectFinalizer")
+	ret := m.ctrl.Call(m, "TryInjectFinalizer", arg0)
 	ret0, _ := ret[0].(mapper.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // TryInjectFinalizer indicates an expected call of TryInjectFinalizer.
-func (mr *MockMapperMockRecorder) TryInjectFinalizer() *gomock.Call {
+func (mr *MockMapperMockRecorder) TryInjectFinalizer(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryInjectFinalizer", reflect.TypeOf((*MockMapper)(nil).TryInjectFinalizer))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryInjectFinalizer", reflect.TypeOf((*MockMapper)(nil).TryInjectFinalizer), arg0)
 }
 
 // TryRemoveDNSEndpoint mocks base method.
-func (m *MockMapper) TryRemoveDNSEndpoint() (mapper.Result, error) {
+func (m *MockMapper) TryRemoveDNSEndpoint(arg0 context.Context) (mapper.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TryRemoveDNSEndpoint")
+	ret := m.ctrl.Call(m, "TryRemoveDNSEndpoint", arg0)
 	ret0, _ := ret[0].(mapper.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // TryRemoveDNSEndpoint indicates an expected call of TryRemoveDNSEndpoint.
-func (mr *MockMapperMockRecorder) TryRemoveDNSEndpoint() *gomock.Call {
+func (mr *MockMapperMockRecorder) TryRemoveDNSEndpoint(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRemoveDNSEndpoint", reflect.TypeOf((*MockMapper)(nil).TryRemoveDNSEndpoint))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRemoveDNSEndpoint", reflect.TypeOf((*MockMapper)(nil).TryRemoveDNSEndpoint), arg0)
 }
 
 // TryRemoveFinalizer mocks base method.
-func (m *MockMapper) TryRemoveFinalizer(arg0 func(*mapper.LoopState) error) (mapper.Result, error) {
+func (m *MockMapper) TryRemoveFinalizer(arg0 context.Context, arg1 func(context.Context, *mapper.LoopState) error) (mapper.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TryRemoveFinalizer", arg0)
+	ret := m.ctrl.Call(m, "TryRemoveFinalizer", arg0, arg1)
 	ret0, _ := ret[0].(mapper.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // TryRemoveFinalizer indicates an expected call of TryRemoveFinalizer.
-func (mr *MockMapperMockRecorder) TryRemoveFinalizer(arg0 interface{}) *gomock.Call {
+func (mr *MockMapperMockRecorder) TryRemoveFinalizer(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRemoveFinalizer", reflect.TypeOf((*MockMapper)(nil).TryRemoveFinalizer), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRemoveFinalizer", reflect.TypeOf((*MockMapper)(nil).TryRemoveFinalizer), arg0, arg1)
 }
 
 // UpdateStatusAnnotation mocks base method.
-func (m *MockMapper) UpdateStatusAnnotation() error {
+func (m *MockMapper) UpdateStatusAnnotation(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusAnnotation")
+	ret := m.ctrl.Call(m, "UpdateStatusAnnotation", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateStatusAnnotation indicates an expected call of UpdateStatusAnnotation.
-func (mr *MockMapperMockRecorder) UpdateStatusAnnotation() *gomock.Call {
+func (mr *MockMapperMockRecorder) UpdateStatusAnnotation(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusAnnotation", reflect.TypeOf((*MockMapper)(nil).UpdateStatusAnnotation))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusAnnotation", reflect.TypeOf((*MockMapper)(nil).UpdateStatusAnnotation), arg0)
 }
@@ -23,6 +23,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -70,24 +71,24 @@ func (mr *MockProviderMapperMockRecorder) FromGatewayAPI() *gomock.Call {
 }
 
 // FromIngress mocks base method.
-func (m *MockProviderMapper) FromIngress(arg0 *v1.Ingress) (*mapper.LoopState, error) {
+func (m *MockProviderMapper) FromIngress(arg0 context.Context, arg1 *v1.Ingress) (*mapper.LoopState, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FromIngress", arg0)
+	ret := m.ctrl.Call(m, "FromIngress", arg0, arg1)
 	ret0, _ := ret[0].(*mapper.LoopState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FromIngress indicates an expected call of FromIngress.
-func (mr *MockProviderMapperMockRecorder) FromIngress(arg0 interface{}) *gomock.Call {
+func (mr *MockProviderMapperMockRecorder) FromIngress(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FromIngress", reflect.TypeOf((*MockProviderMapper)(nil).FromIngress), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FromIngress", reflect.TypeOf((*MockProviderMapper)(nil).FromIngress), arg0, arg1)
 }
 
 // Get mocks base method.
-func (m *MockProviderMapper) Get(arg0 types.NamespacedName) (*mapper.LoopState, mapper.Result, error) {
+func (m *MockProviderMapper) Get(arg0 context.Context, arg1 types.NamespacedName) (*mapper.LoopState, mapper.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
 	ret0, _ := ret[0].(*mapper.LoopState)
 	ret1, _ := ret[1].(mapper.Result)
 	ret2, _ := ret[2].(error)
@@ -95,7 +96,22 @@ func (m *MockProviderMapper) Get(arg0 types.NamespacedName) (*mapper.LoopState,
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockProviderMapperMockRecorder) Get(arg0 interface{}) *gomock.Call {
+func (mr *MockProviderMapperMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProviderMapper)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProviderMapper)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method.
+func (m *MockProviderMapper) List(arg0 context.Context) ([]*mapper.LoopState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].([]*mapper.LoopState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProviderMapperMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProviderMapper)(nil).List), arg0)
 }
@@ -55,6 +55,30 @@ func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
 	return m.recorder
 }
 
+// DeleteHost mocks base method.
+func (m *MockMetrics) DeleteHost(n types.NamespacedName, host string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteHost", n, host)
+}
+
+// DeleteHost indicates an expected call of DeleteHost.
+func (mr *MockMetricsMockRecorder) DeleteHost(n, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHost", reflect.TypeOf((*MockMetrics)(nil).DeleteHost), n, host)
+}
+
+// DeleteIngress mocks base method.
+func (m *MockMetrics) DeleteIngress(n types.NamespacedName, hosts []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteIngress", n, hosts)
+}
+
+// DeleteIngress indicates an expected call of DeleteIngress.
+func (mr *MockMetricsMockRecorder) DeleteIngress(n, hosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngress", reflect.TypeOf((*MockMetrics)(nil).DeleteIngress), n, hosts)
+}
+
 // Get mocks base method.
 func (m *MockMetrics) Get(name string) *metrics.MetricResult {
 	m.ctrl.T.Helper()
@@ -141,6 +165,54 @@ func (mr *MockMetricsMockRecorder) InfobloxIncrementZoneUpdateError(n interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InfobloxIncrementZoneUpdateError", reflect.TypeOf((*MockMetrics)(nil).InfobloxIncrementZoneUpdateError), n)
 }
 
+// IncrementConfigReload mocks base method.
+func (m *MockMetrics) IncrementConfigReload(n types.NamespacedName) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncrementConfigReload", n)
+}
+
+// IncrementConfigReload indicates an expected call of IncrementConfigReload.
+func (mr *MockMetricsMockRecorder) IncrementConfigReload(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementConfigReload", reflect.TypeOf((*MockMetrics)(nil).IncrementConfigReload), n)
+}
+
+// IncrementConfigReloadError mocks base method.
+func (m *MockMetrics) IncrementConfigReloadError(n types.NamespacedName) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncrementConfigReloadError", n)
+}
+
+// IncrementConfigReloadError indicates an expected call of IncrementConfigReloadError.
+func (mr *MockMetricsMockRecorder) IncrementConfigReloadError(n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementConfigReloadError", reflect.TypeOf((*MockMetrics)(nil).IncrementConfigReloadError), n)
+}
+
+// IncrementZoneDelegation mocks base method.
+func (m *MockMetrics) IncrementZoneDelegation() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncrementZoneDelegation")
+}
+
+// IncrementZoneDelegation indicates an expected call of IncrementZoneDelegation.
+func (mr *MockMetricsMockRecorder) IncrementZoneDelegation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementZoneDelegation", reflect.TypeOf((*MockMetrics)(nil).IncrementZoneDelegation))
+}
+
+// IncrementZoneDelegationError mocks base method.
+func (m *MockMetrics) IncrementZoneDelegationError() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncrementZoneDelegationError")
+}
+
+// IncrementZoneDelegationError indicates an expected call of IncrementZoneDelegationError.
+func (mr *MockMetricsMockRecorder) IncrementZoneDelegationError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementZoneDelegationError", reflect.TypeOf((*MockMetrics)(nil).IncrementZoneDelegationError))
+}
+
 // InfobloxObserveRequestDuration mocks base method.
 func (m *MockMetrics) InfobloxObserveRequestDuration(start time.Time, request metrics.DNSProviderRequest, success bool) {
 	m.ctrl.T.Helper()
@@ -153,6 +225,18 @@ func (mr *MockMetricsMockRecorder) InfobloxObserveRequestDuration(start, request
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InfobloxObserveRequestDuration", reflect.TypeOf((*MockMetrics)(nil).InfobloxObserveRequestDuration), start, request, success)
 }
 
+// ObservePeerQuery mocks base method.
+func (m *MockMetrics) ObservePeerQuery(geoTag string, query metrics.PeerQuery, start time.Time, errorType string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObservePeerQuery", geoTag, query, start, errorType)
+}
+
+// ObservePeerQuery indicates an expected call of ObservePeerQuery.
+func (mr *MockMetricsMockRecorder) ObservePeerQuery(geoTag, query, start, errorType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObservePeerQuery", reflect.TypeOf((*MockMetrics)(nil).ObservePeerQuery), geoTag, query, start, errorType)
+}
+
 // Register mocks base method.
 func (m *MockMetrics) Register() error {
 	m.ctrl.T.Helper()
@@ -167,6 +251,54 @@ func (mr *MockMetricsMockRecorder) Register() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockMetrics)(nil).Register))
 }
 
+// SetDryRunPlannedChanges mocks base method.
+func (m *MockMetrics) SetDryRunPlannedChanges(kind, name string, changes int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDryRunPlannedChanges", kind, name, changes)
+}
+
+// SetDryRunPlannedChanges indicates an expected call of SetDryRunPlannedChanges.
+func (mr *MockMetricsMockRecorder) SetDryRunPlannedChanges(kind, name, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDryRunPlannedChanges", reflect.TypeOf((*MockMetrics)(nil).SetDryRunPlannedChanges), kind, name, changes)
+}
+
+// SetPeerHeartbeatAge mocks base method.
+func (m *MockMetrics) SetPeerHeartbeatAge(geoTag string, age time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPeerHeartbeatAge", geoTag, age)
+}
+
+// SetPeerHeartbeatAge indicates an expected call of SetPeerHeartbeatAge.
+func (mr *MockMetricsMockRecorder) SetPeerHeartbeatAge(geoTag, age interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerHeartbeatAge", reflect.TypeOf((*MockMetrics)(nil).SetPeerHeartbeatAge), geoTag, age)
+}
+
+// SetPeerTargets mocks base method.
+func (m *MockMetrics) SetPeerTargets(geoTag, host string, targets int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPeerTargets", geoTag, host, targets)
+}
+
+// SetPeerTargets indicates an expected call of SetPeerTargets.
+func (mr *MockMetricsMockRecorder) SetPeerTargets(geoTag, host, targets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerTargets", reflect.TypeOf((*MockMetrics)(nil).SetPeerTargets), geoTag, host, targets)
+}
+
+// SetPeerUp mocks base method.
+func (m *MockMetrics) SetPeerUp(geoTag string, up bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPeerUp", geoTag, up)
+}
+
+// SetPeerUp indicates an expected call of SetPeerUp.
+func (mr *MockMetricsMockRecorder) SetPeerUp(geoTag, up interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPeerUp", reflect.TypeOf((*MockMetrics)(nil).SetPeerUp), geoTag, up)
+}
+
 // SetRuntimeInfo mocks base method.
 func (m *MockMetrics) SetRuntimeInfo(version, commit string) {
 	m.ctrl.T.Helper()
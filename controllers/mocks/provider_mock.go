@@ -23,6 +23,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -55,45 +56,60 @@ func (m *MockProvider) EXPECT() *MockProviderMockRecorder {
 }
 
 // CreateZoneDelegationForExternalDNS mocks base method.
-func (m *MockProvider) CreateZoneDelegationForExternalDNS(arg0 *mapper.LoopState) error {
+func (m *MockProvider) CreateZoneDelegationForExternalDNS(ctx context.Context, exposedIPs, resources []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateZoneDelegationForExternalDNS", arg0)
+	ret := m.ctrl.Call(m, "CreateZoneDelegationForExternalDNS", ctx, exposedIPs, resources)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateZoneDelegationForExternalDNS indicates an expected call of CreateZoneDelegationForExternalDNS.
-func (mr *MockProviderMockRecorder) CreateZoneDelegationForExternalDNS(arg0 interface{}) *gomock.Call {
+func (mr *MockProviderMockRecorder) CreateZoneDelegationForExternalDNS(ctx, exposedIPs, resources interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZoneDelegationForExternalDNS", reflect.TypeOf((*MockProvider)(nil).CreateZoneDelegationForExternalDNS), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZoneDelegationForExternalDNS", reflect.TypeOf((*MockProvider)(nil).CreateZoneDelegationForExternalDNS), ctx, exposedIPs, resources)
 }
 
 // Finalize mocks base method.
-func (m *MockProvider) Finalize(arg0 *mapper.LoopState) error {
+func (m *MockProvider) Finalize(ctx context.Context, rs *mapper.LoopState) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Finalize", arg0)
+	ret := m.ctrl.Call(m, "Finalize", ctx, rs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Finalize indicates an expected call of Finalize.
-func (mr *MockProviderMockRecorder) Finalize(arg0 interface{}) *gomock.Call {
+func (mr *MockProviderMockRecorder) Finalize(ctx, rs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finalize", reflect.TypeOf((*MockProvider)(nil).Finalize), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finalize", reflect.TypeOf((*MockProvider)(nil).Finalize), ctx, rs)
 }
 
 // GetExternalTargets mocks base method.
-func (m *MockProvider) GetExternalTargets(arg0 string) assistant.Targets {
+func (m *MockProvider) GetExternalTargets(ctx context.Context, host string) (assistant.Targets, assistant.QueryErrors) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExternalTargets", arg0)
+	ret := m.ctrl.Call(m, "GetExternalTargets", ctx, host)
 	ret0, _ := ret[0].(assistant.Targets)
-	return ret0
+	ret1, _ := ret[1].(assistant.QueryErrors)
+	return ret0, ret1
 }
 
 // GetExternalTargets indicates an expected call of GetExternalTargets.
-func (mr *MockProviderMockRecorder) GetExternalTargets(arg0 interface{}) *gomock.Call {
+func (mr *MockProviderMockRecorder) GetExternalTargets(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalTargets", reflect.TypeOf((*MockProvider)(nil).GetExternalTargets), ctx, host)
+}
+
+// Ping mocks base method.
+func (m *MockProvider) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockProviderMockRecorder) Ping(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalTargets", reflect.TypeOf((*MockProvider)(nil).GetExternalTargets), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockProvider)(nil).Ping), ctx)
 }
 
 // RequireFinalizer mocks base method.
@@ -111,17 +127,17 @@ func (mr *MockProviderMockRecorder) RequireFinalizer() *gomock.Call {
 }
 
 // SaveDNSEndpoint mocks base method.
-func (m *MockProvider) SaveDNSEndpoint(arg0 *mapper.LoopState, arg1 *endpoint.DNSEndpoint) error {
+func (m *MockProvider) SaveDNSEndpoint(ctx context.Context, rs *mapper.LoopState, ep *endpoint.DNSEndpoint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveDNSEndpoint", arg0, arg1)
+	ret := m.ctrl.Call(m, "SaveDNSEndpoint", ctx, rs, ep)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SaveDNSEndpoint indicates an expected call of SaveDNSEndpoint.
-func (mr *MockProviderMockRecorder) SaveDNSEndpoint(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockProviderMockRecorder) SaveDNSEndpoint(ctx, rs, ep interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDNSEndpoint", reflect.TypeOf((*MockProvider)(nil).SaveDNSEndpoint), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDNSEndpoint", reflect.TypeOf((*MockProvider)(nil).SaveDNSEndpoint), ctx, rs, ep)
 }
 
 // String mocks base method.
@@ -137,3 +153,17 @@ func (mr *MockProviderMockRecorder) String() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockProvider)(nil).String))
 }
+
+// Withdraw mocks base method.
+func (m *MockProvider) Withdraw(ctx context.Context, resources []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Withdraw", ctx, resources)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Withdraw indicates an expected call of Withdraw.
+func (mr *MockProviderMockRecorder) Withdraw(ctx, resources interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Withdraw", reflect.TypeOf((*MockProvider)(nil).Withdraw), ctx, resources)
+}
@@ -66,3 +66,18 @@ func (mr *MockGslbResolverMockRecorder) ResolveOperatorConfig() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveOperatorConfig", reflect.TypeOf((*MockGslbResolver)(nil).ResolveOperatorConfig))
 }
+
+// ReloadOperatorConfig mocks base method.
+func (m *MockGslbResolver) ReloadOperatorConfig(overrides map[string]string) (*depresolver.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadOperatorConfig", overrides)
+	ret0, _ := ret[0].(*depresolver.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadOperatorConfig indicates an expected call of ReloadOperatorConfig.
+func (mr *MockGslbResolverMockRecorder) ReloadOperatorConfig(overrides interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadOperatorConfig", reflect.TypeOf((*MockGslbResolver)(nil).ReloadOperatorConfig), overrides)
+}
@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"time"
 
 	externaldns "sigs.k8s.io/external-dns/endpoint"
@@ -26,14 +27,18 @@ import (
 
 type Assistant interface {
 	// CoreDNSExposedIPs retrieves list of exposed IP by CoreDNS
-	CoreDNSExposedIPs() ([]string, error)
-	// GetExternalTargets retrieves slice of targets from external clusters
-	GetExternalTargets(host string, extClusterNsNames map[string]string) (targets Targets)
+	CoreDNSExposedIPs(ctx context.Context) ([]string, error)
+	// GetExternalTargets retrieves slice of targets from external clusters. Failed queries are returned as errors
+	// keyed by geo tag of the external cluster
+	GetExternalTargets(ctx context.Context, host string, extClusterNsNames map[string]string) (targets Targets, errs QueryErrors)
+	// GetDNSEndpoint retrieves DNS endpoint. Returns nil if endpoint doesn't exist
+	GetDNSEndpoint(ctx context.Context, namespace, name string) (*externaldns.DNSEndpoint, error)
 	// SaveDNSEndpoint update DNS endpoint or create new one if doesnt exist
-	SaveDNSEndpoint(namespace string, i *externaldns.DNSEndpoint) error
+	SaveDNSEndpoint(ctx context.Context, namespace string, i *externaldns.DNSEndpoint) error
 	// RemoveEndpoint removes endpoint
-	RemoveEndpoint(endpointName string) error
+	RemoveEndpoint(ctx context.Context, endpointName string) error
 	// InspectTXTThreshold inspects fqdn TXT record from edgeDNSServer. If record doesn't exists or timestamp is greater than
-	// splitBrainThreshold the error is returned. In case fakeDNSEnabled is true, 127.0.0.1:7753 is used as edgeDNSServer
-	InspectTXTThreshold(fqdn string, splitBrainThreshold time.Duration) error
+	// splitBrainThreshold the error is returned. In case fakeDNSEnabled is true, 127.0.0.1:7753 is used as edgeDNSServer.
+	// The geoTag identifies the peer cluster owning the record
+	InspectTXTThreshold(ctx context.Context, geoTag, fqdn string, splitBrainThreshold time.Duration) error
 }
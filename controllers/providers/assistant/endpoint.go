@@ -22,10 +22,14 @@ import (
 	"context"
 	coreerrors "errors"
 	"fmt"
+	"net"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/tracing"
 	"github.com/k8gb-io/k8gb-light/controllers/utils"
 
 	"github.com/miekg/dns"
@@ -45,20 +49,21 @@ type Gslb struct {
 	client         client.Client
 	k8gbNamespace  string
 	edgeDNSServers utils.DNSList
+	metrics        metrics.Metrics
 }
 
-var log = logging.Logger()
-
-func NewGslbAssistant(client client.Client, k8gbNamespace string, edgeDNSServers []utils.DNSServer) *Gslb {
+func NewGslbAssistant(client client.Client, k8gbNamespace string, edgeDNSServers []utils.DNSServer, metrics metrics.Metrics) *Gslb {
 	return &Gslb{
 		client:         client,
 		k8gbNamespace:  k8gbNamespace,
 		edgeDNSServers: edgeDNSServers,
+		metrics:        metrics,
 	}
 }
 
 // CoreDNSExposedIPs retrieves list of IP's exposed by CoreDNS
-func (r *Gslb) CoreDNSExposedIPs() ([]string, error) {
+func (r *Gslb) CoreDNSExposedIPs(ctx context.Context) ([]string, error) {
+	log := logging.FromContext(ctx)
 	serviceList := &corev1.ServiceList{}
 	sel, err := labels.Parse(coreDNSServiceLabel)
 	if err != nil {
@@ -70,7 +75,7 @@ func (r *Gslb) CoreDNSExposedIPs() ([]string, error) {
 		Namespace:     r.k8gbNamespace,
 	}
 
-	err = r.client.List(context.TODO(), serviceList, listOption)
+	err = r.client.List(ctx, serviceList, listOption)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Warn().Err(err).Msg("Can't find CoreDNS service")
@@ -98,12 +103,13 @@ func (r *Gslb) CoreDNSExposedIPs() ([]string, error) {
 		return nil, err
 	}
 	lb = coreDNSService.Status.LoadBalancer.Ingress[0]
-	return extractIPFromLB(lb, r.edgeDNSServers)
+	return extractIPFromLB(ctx, lb, r.edgeDNSServers)
 }
 
-func extractIPFromLB(lb corev1.LoadBalancerIngress, ns utils.DNSList) (ips []string, err error) {
+func extractIPFromLB(ctx context.Context, lb corev1.LoadBalancerIngress, ns utils.DNSList) (ips []string, err error) {
+	log := logging.FromContext(ctx)
 	if lb.Hostname != "" {
-		IPs, err := utils.NewUDPDig(ns...).DigA(lb.Hostname)
+		IPs, err := utils.NewUDPDig(ns...).DigA(ctx, lb.Hostname)
 		if err != nil {
 			log.Warn().Err(err).
 				Str("loadBalancerHostname", lb.Hostname).
@@ -118,10 +124,23 @@ func extractIPFromLB(lb corev1.LoadBalancerIngress, ns utils.DNSList) (ips []str
 	return nil, nil
 }
 
+// GetDNSEndpoint retrieves DNS endpoint. Returns nil if endpoint doesn't exist
+func (r *Gslb) GetDNSEndpoint(ctx context.Context, namespace, name string) (*externaldns.DNSEndpoint, error) {
+	found := &externaldns.DNSEndpoint{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, found)
+	if err != nil && errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
 // SaveDNSEndpoint update DNS endpoint or create new one if doesnt exist
-func (r *Gslb) SaveDNSEndpoint(namespace string, i *externaldns.DNSEndpoint) error {
+func (r *Gslb) SaveDNSEndpoint(ctx context.Context, namespace string, i *externaldns.DNSEndpoint) error {
+	log := logging.FromContext(ctx)
 	found := &externaldns.DNSEndpoint{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{
+	err := r.client.Get(ctx, types.NamespacedName{
 		Name:      i.Name,
 		Namespace: namespace,
 	}, found)
@@ -131,7 +150,7 @@ func (r *Gslb) SaveDNSEndpoint(namespace string, i *externaldns.DNSEndpoint) err
 		log.Info().
 			Interface("DNSEndpoint", i).
 			Msgf("Creating a new DNSEndpoint")
-		err = r.client.Create(context.TODO(), i)
+		err = r.client.Create(ctx, i)
 
 		if err != nil {
 			// Creation failed
@@ -153,7 +172,7 @@ func (r *Gslb) SaveDNSEndpoint(namespace string, i *externaldns.DNSEndpoint) err
 	found.Spec = i.Spec
 	found.ObjectMeta.Annotations = i.ObjectMeta.Annotations
 	found.ObjectMeta.Labels = i.ObjectMeta.Labels
-	err = r.client.Update(context.TODO(), found)
+	err = r.client.Update(ctx, found)
 
 	if err != nil {
 		// Update failed
@@ -167,13 +186,14 @@ func (r *Gslb) SaveDNSEndpoint(namespace string, i *externaldns.DNSEndpoint) err
 }
 
 // RemoveEndpoint removes endpoint
-func (r *Gslb) RemoveEndpoint(endpointName string) error {
+func (r *Gslb) RemoveEndpoint(ctx context.Context, endpointName string) error {
+	log := logging.FromContext(ctx)
 	log.Info().
 		Str("namespace", r.k8gbNamespace).
 		Str("name", endpointName).
 		Msg("Removing endpoint")
 	dnsEndpoint := &externaldns.DNSEndpoint{}
-	err := r.client.Get(context.Background(), client.ObjectKey{Namespace: r.k8gbNamespace, Name: endpointName}, dnsEndpoint)
+	err := r.client.Get(ctx, client.ObjectKey{Namespace: r.k8gbNamespace, Name: endpointName}, dnsEndpoint)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Warn().
@@ -185,16 +205,21 @@ func (r *Gslb) RemoveEndpoint(endpointName string) error {
 		}
 		return err
 	}
-	err = r.client.Delete(context.TODO(), dnsEndpoint)
+	err = r.client.Delete(ctx, dnsEndpoint)
 	return err
 }
 
 // InspectTXTThreshold inspects fqdn TXT record from edgeDNSServer. If record doesn't exists or timestamp is greater than
-// splitBrainThreshold the error is returned.
-func (r *Gslb) InspectTXTThreshold(fqdn string, splitBrainThreshold time.Duration) error {
+// splitBrainThreshold the error is returned. The geoTag identifies the peer cluster owning the record.
+func (r *Gslb) InspectTXTThreshold(ctx context.Context, geoTag, fqdn string, splitBrainThreshold time.Duration) (err error) {
+	ctx, span := tracing.Start(ctx, "InspectTXTThreshold", tracing.GeoTagKey.String(geoTag), tracing.RecordKey.String(fqdn))
+	defer func() { tracing.End(span, err) }()
+	log := logging.FromContext(ctx)
 	m := new(dns.Msg)
 	m.SetQuestion(dns.Fqdn(fqdn), dns.TypeTXT)
-	txt, err := utils.Exchange(m, r.edgeDNSServers)
+	start := time.Now()
+	txt, err := utils.Exchange(ctx, m, r.edgeDNSServers)
+	r.metrics.ObservePeerQuery(geoTag, metrics.PeerQueryHeartbeat, start, queryErrorType(txt, err))
 	if err != nil {
 		log.Info().
 			Interface("edgeDNSServers", r.edgeDNSServers).
@@ -222,6 +247,7 @@ func (r *Gslb) InspectTXTThreshold(fqdn string, splitBrainThreshold time.Duratio
 				Time("parsed", timeFromTXT).
 				Str("diff", diff.String()).
 				Msg("Split brain TXT")
+			r.metrics.SetPeerHeartbeatAge(geoTag, diff)
 
 			if diff > splitBrainThreshold {
 				return errors.NewResourceExpired(fmt.Sprintf("Split brain TXT record expired the time threshold: (%s)", splitBrainThreshold))
@@ -241,11 +267,12 @@ func getARecords(msg *dns.Msg) []string {
 	return ARecords
 }
 
-func dnsQuery(host string, nameservers utils.DNSList) (*dns.Msg, error) {
+func dnsQuery(ctx context.Context, host string, nameservers utils.DNSList) (*dns.Msg, error) {
+	log := logging.FromContext(ctx)
 	dnsMsg := new(dns.Msg)
 	fqdn := fmt.Sprintf("%s.", host) // Convert to true FQDN with dot at the end
 	dnsMsg.SetQuestion(fqdn, dns.TypeA)
-	dnsMsgA, err := utils.Exchange(dnsMsg, nameservers)
+	dnsMsgA, err := utils.Exchange(ctx, dnsMsg, nameservers)
 	if err != nil {
 		log.Warn().
 			Str("fqdn", fqdn).
@@ -256,16 +283,69 @@ func dnsQuery(host string, nameservers utils.DNSList) (*dns.Msg, error) {
 	return dnsMsgA, err
 }
 
-func (r *Gslb) GetExternalTargets(host string, extClusterNsNames map[string]string) (targets Targets) {
+// peerQuery resolves host on behalf of peer cluster identified by geoTag and observes the query
+func (r *Gslb) peerQuery(ctx context.Context, geoTag string, query metrics.PeerQuery, host string, nameservers utils.DNSList) (msg *dns.Msg, err error) {
+	ctx, span := tracing.Start(ctx, "PeerQuery "+string(query),
+		tracing.GeoTagKey.String(geoTag), tracing.RecordKey.String(host), tracing.NameserverKey.String(nameservers.String()))
+	defer func() { tracing.End(span, err) }()
+	start := time.Now()
+	msg, err = dnsQuery(ctx, host, nameservers)
+	r.metrics.ObservePeerQuery(geoTag, query, start, queryErrorType(msg, err))
+	if msg != nil {
+		logging.FromContext(ctx).Trace().
+			Str("geoTag", geoTag).
+			Str("query", string(query)).
+			Str("host", host).
+			Str("nameservers", nameservers.String()).
+			Str("rcode", dns.RcodeToString[msg.Rcode]).
+			Strs("answer", getARecords(msg)).
+			Msg("Peer query answered")
+	}
+	return msg, err
+}
+
+// queryErrorType classifies the result of DNS query. It returns empty string for successful query
+func queryErrorType(msg *dns.Msg, err error) string {
+	var netErr net.Error
+	switch {
+	case coreerrors.As(err, &netErr) && netErr.Timeout():
+		return metrics.PeerErrorTimeout
+	case err != nil:
+		return metrics.PeerErrorNetwork
+	case msg.Rcode == dns.RcodeSuccess:
+		return ""
+	case msg.Rcode == dns.RcodeServerFailure:
+		return metrics.PeerErrorServfail
+	case msg.Rcode == dns.RcodeNameError:
+		return metrics.PeerErrorNXDomain
+	case msg.Rcode == dns.RcodeRefused:
+		return metrics.PeerErrorRefused
+	}
+	return metrics.PeerErrorOther
+}
+
+func (r *Gslb) GetExternalTargets(ctx context.Context, host string, extClusterNsNames map[string]string) (targets Targets, errs QueryErrors) {
+	log := logging.FromContext(ctx)
 	targets = NewTargets()
-	for tag, cluster := range extClusterNsNames {
+	errs = QueryErrors{}
+	// peers are queried in order of geo tags, failure of one peer doesn't prevent querying the others
+	tags := make([]string, 0, len(extClusterNsNames))
+	for tag := range extClusterNsNames {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		cluster := extClusterNsNames[tag]
 		// Use edgeDNSServer for resolution of NS names and fallback to local nameservers
 		log.Info().
 			Str("cluster", cluster).
 			Msg("Adding external Gslb targets from cluster")
-		glueA, err := dnsQuery(cluster, r.edgeDNSServers)
+		glueA, err := r.peerQuery(ctx, tag, metrics.PeerQueryGlue, cluster, r.edgeDNSServers)
 		if err != nil {
-			return
+			r.metrics.SetPeerUp(tag, false)
+			r.metrics.SetPeerTargets(tag, host, 0)
+			errs[tag] = err
+			continue
 		}
 		log.Debug().
 			Str("nameserver", cluster).
@@ -281,11 +361,17 @@ func (r *Gslb) GetExternalTargets(host string, extClusterNsNames map[string]stri
 		}
 		nameServersToUse := getNSCombinations(r.edgeDNSServers, hostToUse)
 		lHost := fmt.Sprintf("localtargets-%s", host)
-		a, err := dnsQuery(lHost, nameServersToUse)
+		a, err := r.peerQuery(ctx, tag, metrics.PeerQueryTargets, lHost, nameServersToUse)
 		if err != nil {
-			return
+			r.metrics.SetPeerUp(tag, false)
+			r.metrics.SetPeerTargets(tag, host, 0)
+			errs[tag] = err
+			continue
 		}
+		// the nameserver of peer answered authoritatively, even if it has no targets for the host
+		r.metrics.SetPeerUp(tag, a.Rcode == dns.RcodeSuccess || a.Rcode == dns.RcodeNameError)
 		clusterTargets := getARecords(a)
+		r.metrics.SetPeerTargets(tag, host, len(clusterTargets))
 		if len(clusterTargets) > 0 {
 			targets[tag] = &Target{IPs: clusterTargets}
 			log.Info().
@@ -294,7 +380,7 @@ func (r *Gslb) GetExternalTargets(host string, extClusterNsNames map[string]stri
 				Msg("Extend Gslb targets by targets from cluster")
 		}
 	}
-	return targets
+	return targets, errs
 }
 
 func getNSCombinations(original []utils.DNSServer, hostToUse string) []utils.DNSServer {
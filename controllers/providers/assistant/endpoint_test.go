@@ -0,0 +1,203 @@
+package assistant
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/tracing"
+	"github.com/k8gb-io/k8gb-light/controllers/utils"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const assistantFakeDNSPort = 7855
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestQueryErrorType(t *testing.T) {
+	msg := func(rcode int) *dns.Msg {
+		m := new(dns.Msg)
+		m.Rcode = rcode
+		return m
+	}
+	var tests = []struct {
+		name     string
+		msg      *dns.Msg
+		err      error
+		expected string
+	}{
+		{name: "success", msg: msg(dns.RcodeSuccess), expected: ""},
+		{name: "wrapped timeout", err: fmt.Errorf("exchange error: %w", timeoutError{}), expected: metrics.PeerErrorTimeout},
+		{name: "network", err: fmt.Errorf("connection refused"), expected: metrics.PeerErrorNetwork},
+		{name: "servfail", msg: msg(dns.RcodeServerFailure), expected: metrics.PeerErrorServfail},
+		{name: "nxdomain", msg: msg(dns.RcodeNameError), expected: metrics.PeerErrorNXDomain},
+		{name: "refused", msg: msg(dns.RcodeRefused), expected: metrics.PeerErrorRefused},
+		{name: "other", msg: msg(dns.RcodeNotImplemented), expected: metrics.PeerErrorOther},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			// act
+			result := queryErrorType(test.msg, test.err)
+			// assert
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestPeerMetrics(t *testing.T) {
+	// arrange
+	m := metrics.Prometheus()
+	edgeDNSServers := []utils.DNSServer{{Host: "localhost", Port: assistantFakeDNSPort}}
+	a := NewGslbAssistant(nil, "k8gb", edgeDNSServers, m)
+	settings := utils.FakeDNSSettings{FakeDNSPort: assistantFakeDNSPort, EdgeDNSZoneFQDN: "example.com.", DNSZoneFQDN: "cloud.example.com."}
+	heartbeat := time.Now().UTC().Add(-time.Minute).Format("2006-01-02T15:04:05")
+	// act
+	// assert
+	utils.NewFakeDNS(settings).
+		AddARecord("gslb-ns-za-cloud.example.com.", net.IPv4(127, 0, 0, 1)).
+		AddARecord("localtargets-app.cloud.example.com.", net.IPv4(10, 0, 0, 1)).
+		AddARecord("localtargets-app.cloud.example.com.", net.IPv4(10, 0, 0, 2)).
+		AddTXTRecord("gslb-ns-za-heartbeat.example.com.", heartbeat).
+		Start().
+		RunTestFunc(func() {
+			targets, errs := a.GetExternalTargets(context.TODO(), "app.cloud.example.com", map[string]string{"za": "gslb-ns-za-cloud.example.com"})
+			assert.Empty(t, errs)
+			assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, targets.GetIPs())
+			assert.NoError(t, a.InspectTXTThreshold(context.TODO(), "za", "gslb-ns-za-heartbeat.example.com", 5*time.Minute))
+		}).RequireNoError(t)
+	assert.Equal(t, 1., testutil.ToFloat64(m.Get(metrics.K8gbPeerUp).AsGaugeVec().With(prometheus.Labels{"geotag": "za"})))
+	assert.Equal(t, 2., testutil.ToFloat64(m.Get(metrics.K8gbPeerTargets).AsGaugeVec().
+		With(prometheus.Labels{"geotag": "za", "host": "app.cloud.example.com"})))
+	assert.InDelta(t, 60., testutil.ToFloat64(m.Get(metrics.K8gbPeerHeartbeatAge).AsGaugeVec().
+		With(prometheus.Labels{"geotag": "za"})), 5.)
+	assert.Equal(t, 0, testutil.CollectAndCount(m.Get(metrics.K8gbPeerQueryErrorsTotal).AsCounterVec()))
+
+	// the fake DNS is gone, the peer is unreachable
+	_, errs := a.GetExternalTargets(context.TODO(), "app.cloud.example.com", map[string]string{"za": "gslb-ns-za-cloud.example.com"})
+	assert.Error(t, errs["za"])
+	assert.Equal(t, 0., testutil.ToFloat64(m.Get(metrics.K8gbPeerUp).AsGaugeVec().With(prometheus.Labels{"geotag": "za"})))
+	assert.Equal(t, 1., testutil.ToFloat64(m.Get(metrics.K8gbPeerQueryErrorsTotal).AsCounterVec().
+		With(prometheus.Labels{"geotag": "za", "query": string(metrics.PeerQueryGlue), "type": metrics.PeerErrorNetwork})))
+}
+
+func TestGetExternalTargetsQueriesAllPeers(t *testing.T) {
+	// arrange
+	edgeDNSServers := []utils.DNSServer{{Host: "localhost", Port: assistantFakeDNSPort}}
+	a := NewGslbAssistant(nil, "k8gb", edgeDNSServers, metrics.Prometheus())
+	settings := utils.FakeDNSSettings{FakeDNSPort: assistantFakeDNSPort, EdgeDNSZoneFQDN: "example.com.", DNSZoneFQDN: "cloud.example.com."}
+	// nameservers of eu and us have no glue records and can't be resolved
+	peers := map[string]string{"eu": "gslb-ns-eu.invalid", "us": "gslb-ns-us.invalid", "za": "gslb-ns-za-cloud.example.com"}
+	var targets Targets
+	var errs QueryErrors
+	// act
+	utils.NewFakeDNS(settings).
+		AddARecord("gslb-ns-za-cloud.example.com.", net.IPv4(127, 0, 0, 1)).
+		AddARecord("localtargets-app.cloud.example.com.", net.IPv4(10, 0, 0, 1)).
+		Start().
+		RunTestFunc(func() {
+			targets, errs = a.GetExternalTargets(context.TODO(), "app.cloud.example.com", peers)
+		}).RequireNoError(t)
+	// assert
+	assert.Equal(t, []string{"10.0.0.1"}, targets.GetIPs())
+	assert.Len(t, errs, 2)
+	assert.Error(t, errs["eu"])
+	assert.Error(t, errs["us"])
+}
+
+func TestPeerMetricsOfAllPeers(t *testing.T) {
+	// arrange
+	m := metrics.Prometheus()
+	edgeDNSServers := []utils.DNSServer{{Host: "localhost", Port: assistantFakeDNSPort}}
+	a := NewGslbAssistant(nil, "k8gb", edgeDNSServers, m)
+	settings := utils.FakeDNSSettings{FakeDNSPort: assistantFakeDNSPort, EdgeDNSZoneFQDN: "example.com.", DNSZoneFQDN: "cloud.example.com."}
+	peers := map[string]string{"ca": "gslb-ns-ca-cloud.example.com", "de": "gslb-ns-de-cloud.example.com", "pl": "gslb-ns-pl-cloud.example.com"}
+	targets := func(tag string) float64 {
+		return testutil.ToFloat64(m.Get(metrics.K8gbPeerTargets).AsGaugeVec().
+			With(prometheus.Labels{"geotag": tag, "host": "web.cloud.example.com"}))
+	}
+	up := func(tag string) float64 {
+		return testutil.ToFloat64(m.Get(metrics.K8gbPeerUp).AsGaugeVec().With(prometheus.Labels{"geotag": tag}))
+	}
+	// act
+	utils.NewFakeDNS(settings).
+		AddARecord("gslb-ns-ca-cloud.example.com.", net.IPv4(127, 0, 0, 1)).
+		AddARecord("gslb-ns-de-cloud.example.com.", net.IPv4(127, 0, 0, 1)).
+		AddARecord("gslb-ns-pl-cloud.example.com.", net.IPv4(127, 0, 0, 1)).
+		AddARecord("localtargets-web.cloud.example.com.", net.IPv4(10, 0, 0, 1)).
+		Start().
+		RunTestFunc(func() {
+			_, errs := a.GetExternalTargets(context.TODO(), "web.cloud.example.com", peers)
+			require.Empty(t, errs)
+		}).RequireNoError(t)
+	// assert
+	for tag := range peers {
+		assert.Equal(t, 1., up(tag), tag)
+		assert.Equal(t, 1., targets(tag), tag)
+	}
+
+	// the fake DNS is gone, every peer is unreachable and its targets are withdrawn
+	_, errs := a.GetExternalTargets(context.TODO(), "web.cloud.example.com", peers)
+	assert.Len(t, errs, len(peers))
+	for tag := range peers {
+		assert.Equal(t, 0., up(tag), tag)
+		assert.Equal(t, 0., targets(tag), tag)
+		assert.Equal(t, 1., testutil.ToFloat64(m.Get(metrics.K8gbPeerQueryErrorsTotal).AsCounterVec().
+			With(prometheus.Labels{"geotag": tag, "query": string(metrics.PeerQueryGlue), "type": metrics.PeerErrorNetwork})), tag)
+	}
+}
+
+func TestPeerQuerySpans(t *testing.T) {
+	// arrange
+	edgeDNSServers := []utils.DNSServer{{Host: "localhost", Port: assistantFakeDNSPort}}
+	a := NewGslbAssistant(nil, "k8gb", edgeDNSServers, metrics.Prometheus())
+	recorder := tracetest.NewSpanRecorder()
+	ctx, parent := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test").Start(context.Background(), "parent")
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	// act
+	_, errs := a.GetExternalTargets(ctx, "app.cloud.example.com", map[string]string{"eu": "gslb-ns-eu-cloud.example.com"})
+	parent.End()
+	// assert
+	assert.ErrorIs(t, errs["eu"], context.Canceled)
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "PeerQuery glue", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), tracing.GeoTagKey.String("eu"))
+	assert.Contains(t, spans[0].Attributes(), tracing.RecordKey.String("gslb-ns-eu-cloud.example.com"))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
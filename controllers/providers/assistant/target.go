@@ -30,6 +30,9 @@ type Target struct {
 
 type Targets map[string]*Target
 
+// QueryErrors maps geo tag of external cluster to the error of query for its targets
+type QueryErrors map[string]error
+
 func NewTargets() Targets {
 	return make(map[string]*Target, 0)
 }
@@ -57,6 +60,15 @@ func (t Targets) AppendTargets(targets Targets) {
 	}
 }
 
+// Copy returns deep copy of targets
+func (t Targets) Copy() Targets {
+	c := NewTargets()
+	for k, v := range t {
+		c[k] = &Target{IPs: append([]string{}, v.IPs...)}
+	}
+	return c
+}
+
 func (t Targets) Sort() {
 	sort := func(targets []string) []string {
 		sort.Slice(targets, func(i, j int) bool {
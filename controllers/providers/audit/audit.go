@@ -0,0 +1,100 @@
+package audit
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Record describes change of targets published for the host together with the state which triggered it
+type Record struct {
+	// Timestamp when the change was computed
+	Timestamp time.Time `json:"timestamp"`
+	// Host which is affected
+	Host string `json:"host"`
+	// Namespace of the annotated resource
+	Namespace string `json:"namespace"`
+	// Ingress name of the annotated resource
+	Ingress string `json:"ingress"`
+	// Strategy of the host
+	Strategy string `json:"strategy"`
+	// OldTargets published before the change
+	OldTargets []string `json:"oldTargets"`
+	// NewTargets published after the change
+	NewTargets []string `json:"newTargets"`
+	// Health of the host in the local cluster
+	Health string `json:"health"`
+	// Peers targets resolved from external clusters by geo tag
+	Peers map[string][]string `json:"peers"`
+	// PeerErrors errors of queries for targets of external clusters by geo tag
+	PeerErrors map[string]string `json:"peerErrors,omitempty"`
+	// FailoverOrder and ActiveGeoTag are set by failover strategy
+	FailoverOrder []string `json:"failoverOrder,omitempty"`
+	ActiveGeoTag  string   `json:"activeGeoTag,omitempty"`
+	// ClusterGeoTag of the cluster which computed the change
+	ClusterGeoTag string `json:"clusterGeoTag"`
+	// Instance name of the operator pod which computed the change
+	Instance string `json:"instance"`
+	// DryRun is true when the operator doesn't apply DNS changes
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Auditor writes audit records to the configured sink
+type Auditor interface {
+	// Audit writes record; failures are logged
+	Audit(ctx context.Context, r Record)
+	// Start writes queued records until ctx is cancelled; implements manager.Runnable
+	Start(ctx context.Context) error
+	String() string
+}
+
+// NewAuditor returns auditor configured by AUDIT_* environment variables; records are dropped when the sink is none.
+// ConfigMap sink reads the ConfigMap by reader, which should bypass the cache
+func NewAuditor(config *depresolver.Config, c client.Client, reader client.Reader, log *zerolog.Logger) (Auditor, error) {
+	switch config.Audit.Sink {
+	case depresolver.AuditSinkStdout:
+		return NewWriterAuditor("STDOUT", os.Stdout, log), nil
+	case depresolver.AuditSinkFile:
+		f, err := NewRotatingFile(config.Audit.File, int64(config.Audit.FileMaxSizeMB)*1024*1024, config.Audit.FileMaxBackups)
+		if err != nil {
+			return nil, fmt.Errorf("can't open audit log: %w", err)
+		}
+		return NewWriterAuditor("FILE", f, log), nil
+	case depresolver.AuditSinkConfigMap:
+		return NewConfigMapAuditor(c, reader, config.K8gbNamespace, config.Audit.ConfigMapName, config.Audit.ConfigMapSize, log), nil
+	}
+	return NewEmptyAuditor(), nil
+}
+
+// Instance returns name of the operator instance; hostname of the pod is the pod name
+func Instance() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	name, _ := os.Hostname()
+	return name
+}
@@ -0,0 +1,194 @@
+package audit
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var record = Record{
+	Timestamp:     time.Date(2023, 3, 1, 3, 12, 0, 0, time.UTC),
+	Host:          "demo.cloud.example.com",
+	Namespace:     "demo",
+	Ingress:       "demo",
+	Strategy:      "failover",
+	OldTargets:    []string{"10.0.0.1"},
+	NewTargets:    []string{"10.1.0.1"},
+	Health:        "Unhealthy",
+	Peers:         map[string][]string{"us": {"10.1.0.1"}},
+	ActiveGeoTag:  "us",
+	ClusterGeoTag: "eu",
+	Instance:      "k8gb-6d4f8b7c9-x2x7k",
+}
+
+func TestWriterAuditor(t *testing.T) {
+	// arrange
+	buf := &bytes.Buffer{}
+	a := NewWriterAuditor("STDOUT", buf, logging.Logger())
+	// act
+	a.Audit(context.TODO(), record)
+	a.Audit(context.TODO(), record)
+	// assert
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	var r Record
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &r))
+	assert.Equal(t, record, r)
+	assert.Equal(t, "STDOUT", a.String())
+}
+
+func TestRotatingFile(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := NewRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	defer f.Close()
+	// act
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err = f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	// assert
+	for file, expected := range map[string]string{path: "fourth\n", path + ".1": "third\n", path + ".2": "second\n"} {
+		b, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b))
+	}
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestRotatingFileWithoutBackups(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(path, []byte("existing\n"), 0o600))
+	f, err := NewRotatingFile(path, 10, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	// act
+	_, err = f.Write([]byte("next\n"))
+	// assert
+	require.NoError(t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "next\n", string(b))
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestConfigMapAuditor(t *testing.T) {
+	// arrange
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	a := NewConfigMapAuditor(c, c, "k8gb", "k8gb-audit", 2, logging.Logger())
+	ctx, cancel := context.WithCancel(context.TODO())
+	stopped := make(chan struct{})
+	go func() {
+		_ = a.Start(ctx)
+		close(stopped)
+	}()
+	// act
+	for _, host := range []string{"a.cloud.example.com", "b.cloud.example.com", "c.cloud.example.com"} {
+		r := record
+		r.Host = host
+		a.Audit(context.TODO(), r)
+	}
+	// assert
+	readHosts := func() (hosts []string) {
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: "k8gb", Name: "k8gb-audit"}, cm); err != nil {
+			return nil
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(cm.Data[ConfigMapRecordsKey], "\n"), "\n") {
+			var r Record
+			require.NoError(t, json.Unmarshal([]byte(line), &r))
+			hosts = append(hosts, r.Host)
+		}
+		return hosts
+	}
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"b.cloud.example.com", "c.cloud.example.com"}, readHosts())
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "CONFIGMAP k8gb/k8gb-audit", a.String())
+	cancel()
+	<-stopped
+}
+
+func TestConfigMapAuditorDropsRecordsWhenQueueIsFull(t *testing.T) {
+	// arrange
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	a := NewConfigMapAuditor(c, c, "k8gb", "k8gb-audit", 2, logging.Logger())
+	// act
+	for i := 0; i <= queueSize; i++ {
+		a.Audit(context.TODO(), record)
+	}
+	// assert
+	assert.Len(t, a.queue, queueSize)
+	cm := &corev1.ConfigMap{}
+	assert.Error(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "k8gb", Name: "k8gb-audit"}, cm),
+		"records are written by Start only")
+}
+
+func TestNewAuditor(t *testing.T) {
+	var tests = []struct {
+		sink     string
+		expected string
+	}{
+		{sink: depresolver.AuditSinkNone, expected: "EMPTY"},
+		{sink: depresolver.AuditSinkStdout, expected: "STDOUT"},
+		{sink: depresolver.AuditSinkFile, expected: "FILE"},
+		{sink: depresolver.AuditSinkConfigMap, expected: "CONFIGMAP k8gb/k8gb-audit"},
+	}
+	for _, test := range tests {
+		t.Run(test.sink, func(t *testing.T) {
+			// arrange
+			config := &depresolver.Config{K8gbNamespace: "k8gb", Audit: depresolver.Audit{Sink: test.sink,
+				File: filepath.Join(t.TempDir(), "audit.log"), FileMaxSizeMB: 1, ConfigMapName: "k8gb-audit", ConfigMapSize: 10}}
+			// act
+			a, err := NewAuditor(config, nil, nil, logging.Logger())
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, a.String())
+		})
+	}
+}
+
+func TestNewAuditorInvalidFile(t *testing.T) {
+	// arrange
+	config := &depresolver.Config{Audit: depresolver.Audit{Sink: depresolver.AuditSinkFile,
+		File: filepath.Join(t.TempDir(), "missing", "audit.log"), FileMaxSizeMB: 1}}
+	// act
+	_, err := NewAuditor(config, nil, nil, logging.Logger())
+	// assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package audit
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigMapRecordsKey key of ConfigMap data containing audit records as JSON lines, the oldest first
+const ConfigMapRecordsKey = "records"
+
+// configMapTimeout limits time spent by writing single record
+const configMapTimeout = 10 * time.Second
+
+// queueSize is the number of records waiting for the ConfigMap write. Further records are dropped until
+// the writer catches up
+const queueSize = 100
+
+// queued is a marshalled record waiting in the queue
+type queued struct {
+	host string
+	line string
+}
+
+// ConfigMapAuditor keeps the latest records in ConfigMap as ring buffer, so they survive operator restarts
+// and are readable by kubectl
+type ConfigMapAuditor struct {
+	client client.Client
+	reader client.Reader
+	name   types.NamespacedName
+	size   int
+	queue  chan queued
+	log    *zerolog.Logger
+}
+
+func NewConfigMapAuditor(c client.Client, reader client.Reader, namespace, name string, size int, log *zerolog.Logger) *ConfigMapAuditor {
+	return &ConfigMapAuditor{client: c, reader: reader, name: types.NamespacedName{Namespace: namespace, Name: name}, size: size,
+		queue: make(chan queued, queueSize), log: log}
+}
+
+// Audit queues record for the writer, so the reconciliation is never blocked by the API server. The record
+// is dropped when the queue is full
+func (a *ConfigMapAuditor) Audit(_ context.Context, r Record) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		a.log.Err(err).Str("host", r.Host).Msg("can't marshal audit record")
+		return
+	}
+	select {
+	case a.queue <- queued{host: r.Host, line: string(b)}:
+	default:
+		a.log.Warn().
+			Str("configMap", a.name.String()).
+			Str("host", r.Host).
+			Msg("audit queue is full, dropping audit record")
+	}
+}
+
+// Start implements manager.Runnable. Records are written by a single worker in the order they were queued,
+// so concurrent reconciliations never conflict on the ConfigMap. The worker stops when ctx is cancelled
+func (a *ConfigMapAuditor) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case q := <-a.queue:
+			if err := a.write(ctx, q.line); err != nil {
+				a.log.Err(err).
+					Str("configMap", a.name.String()).
+					Str("host", q.host).
+					Msg("can't write audit record")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the leader queues records,
+// the writers of other replicas stay idle
+func (a *ConfigMapAuditor) NeedLeaderElection() bool {
+	return false
+}
+
+func (a *ConfigMapAuditor) write(ctx context.Context, line string) error {
+	ctx, cancel := context.WithTimeout(ctx, configMapTimeout)
+	defer cancel()
+	return a.append(ctx, line)
+}
+
+// append adds line to the records and drops the oldest ones exceeding size; ConfigMap is created when missing
+func (a *ConfigMapAuditor) append(ctx context.Context, line string) error {
+	cm := &corev1.ConfigMap{}
+	err := a.reader.Get(ctx, a.name, cm)
+	if errors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: a.name.Namespace, Name: a.name.Name},
+			Data:       map[string]string{ConfigMapRecordsKey: line + "\n"},
+		}
+		return a.client.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+	var lines []string
+	if current := strings.TrimSuffix(cm.Data[ConfigMapRecordsKey], "\n"); current != "" {
+		lines = strings.Split(current, "\n")
+	}
+	lines = append(lines, line)
+	if len(lines) > a.size {
+		lines = lines[len(lines)-a.size:]
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[ConfigMapRecordsKey] = strings.Join(lines, "\n") + "\n"
+	return a.client.Update(ctx, cm)
+}
+
+func (a *ConfigMapAuditor) String() string {
+	return fmt.Sprintf("CONFIGMAP %s", a.name)
+}
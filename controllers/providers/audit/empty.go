@@ -0,0 +1,38 @@
+package audit
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import "context"
+
+// EmptyAuditor drops all records, it is used when audit log is disabled
+type EmptyAuditor struct{}
+
+func NewEmptyAuditor() *EmptyAuditor {
+	return &EmptyAuditor{}
+}
+
+func (a *EmptyAuditor) Audit(context.Context, Record) {}
+
+func (a *EmptyAuditor) Start(context.Context) error {
+	return nil
+}
+
+func (a *EmptyAuditor) String() string {
+	return "EMPTY"
+}
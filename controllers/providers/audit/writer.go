@@ -0,0 +1,63 @@
+package audit
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// WriterAuditor writes records as JSON lines; it is used by stdout and file sinks
+type WriterAuditor struct {
+	name string
+	lock sync.Mutex
+	w    io.Writer
+	log  *zerolog.Logger
+}
+
+func NewWriterAuditor(name string, w io.Writer, log *zerolog.Logger) *WriterAuditor {
+	return &WriterAuditor{name: name, w: w, log: log}
+}
+
+func (a *WriterAuditor) Audit(_ context.Context, r Record) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		a.log.Err(err).Str("host", r.Host).Msg("can't marshal audit record")
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	// single write keeps lines of concurrent records apart
+	if _, err = a.w.Write(append(b, '\n')); err != nil {
+		a.log.Err(err).Str("host", r.Host).Msg("can't write audit record")
+	}
+}
+
+// Start implements manager.Runnable; records are written synchronously, so there is nothing to run
+func (a *WriterAuditor) Start(context.Context) error {
+	return nil
+}
+
+func (a *WriterAuditor) String() string {
+	return a.name
+}
@@ -19,6 +19,8 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
+
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
 
@@ -26,16 +28,23 @@ import (
 )
 
 type Provider interface {
-	// CreateZoneDelegationForExternalDNS handles delegated zone in Edge DNS
-	CreateZoneDelegationForExternalDNS(*mapper.LoopState) error
-	// GetExternalTargets retrieves list of external targets for specified host
-	GetExternalTargets(string) assistant.Targets
+	// CreateZoneDelegationForExternalDNS handles delegated zone in Edge DNS. Exposed IPs are used
+	// for nameserver A records unless CoreDNS is exposed. Resources are names of annotated resources,
+	// which identify heartbeats of previous releases
+	CreateZoneDelegationForExternalDNS(ctx context.Context, exposedIPs []string, resources []string) error
+	// GetExternalTargets retrieves list of external targets for specified host and errors of failed queries
+	GetExternalTargets(ctx context.Context, host string) (assistant.Targets, assistant.QueryErrors)
 	// SaveDNSEndpoint update DNS endpoint in gslb or create new one if doesn't exist
-	SaveDNSEndpoint(*mapper.LoopState, *externaldns.DNSEndpoint) error
+	SaveDNSEndpoint(ctx context.Context, rs *mapper.LoopState, ep *externaldns.DNSEndpoint) error
 	// String see: Stringer interface
 	String() string
 	// RequireFinalizer tells whether provider requires to collect any resources
 	RequireFinalizer() bool
 	// Finalize would be implemented when RequireFinalizer is true
-	Finalize(*mapper.LoopState) error
+	Finalize(ctx context.Context, rs *mapper.LoopState) error
+	// Withdraw removes cluster nameservers from delegated zone and cluster heartbeats, including heartbeats
+	// of resources published by previous releases. The error is returned until the withdrawal is confirmed
+	Withdraw(ctx context.Context, resources []string) error
+	// Ping checks the DNS provider is reachable, it is used by the readiness probe
+	Ping(ctx context.Context) error
 }
@@ -0,0 +1,151 @@
+package dns
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+
+	ibcl "github.com/infobloxopen/infoblox-go-client"
+	"github.com/rs/zerolog"
+	externaldns "sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	PlanKindDNSEndpoint    = "DNSEndpoint"
+	PlanKindZoneDelegation = "ZoneDelegation"
+	PlanKindHeartbeat      = "HeartbeatTXT"
+)
+
+// Plan describes changes which would be applied by provider if dry run wasn't enabled
+type Plan struct {
+	Kind    string
+	Name    string
+	Changes []string
+}
+
+// dryRunAssistant computes the difference between current and desired DNSEndpoint and reports it
+// instead of writing to the cluster
+type dryRunAssistant struct {
+	assistant.Assistant
+	log     *zerolog.Logger
+	metrics metrics.Metrics
+}
+
+func newDryRunAssistant(a assistant.Assistant, log *zerolog.Logger, metrics metrics.Metrics) *dryRunAssistant {
+	return &dryRunAssistant{
+		Assistant: a,
+		log:       log,
+		metrics:   metrics,
+	}
+}
+
+func (a *dryRunAssistant) SaveDNSEndpoint(ctx context.Context, namespace string, i *externaldns.DNSEndpoint) error {
+	current, err := a.Assistant.GetDNSEndpoint(ctx, namespace, i.Name)
+	if err != nil {
+		return err
+	}
+	reportPlan(a.log, a.metrics, Plan{
+		Kind:    PlanKindDNSEndpoint,
+		Name:    fmt.Sprintf("%s/%s", namespace, i.Name),
+		Changes: diffDNSEndpoints(current, i),
+	})
+	return nil
+}
+
+func (a *dryRunAssistant) RemoveEndpoint(_ context.Context, endpointName string) error {
+	reportPlan(a.log, a.metrics, Plan{
+		Kind:    PlanKindDNSEndpoint,
+		Name:    endpointName,
+		Changes: []string{fmt.Sprintf("- %s", endpointName)},
+	})
+	return nil
+}
+
+// reportPlan logs planned changes and exposes their count via metrics
+func reportPlan(log *zerolog.Logger, m metrics.Metrics, plan Plan) {
+	m.SetDryRunPlannedChanges(plan.Kind, plan.Name, len(plan.Changes))
+	if len(plan.Changes) == 0 {
+		log.Debug().
+			Str("kind", plan.Kind).
+			Str("name", plan.Name).
+			Msg("Dry run: no changes")
+		return
+	}
+	log.Info().
+		Str("kind", plan.Kind).
+		Str("name", plan.Name).
+		Strs("changes", plan.Changes).
+		Msg("Dry run: changes not applied")
+}
+
+// diffDNSEndpoints returns human readable list of changes between current and desired endpoints.
+// Records are prefixed by + when created, - when deleted and ~ when updated
+func diffDNSEndpoints(current, desired *externaldns.DNSEndpoint) (changes []string) {
+	key := func(ep *externaldns.Endpoint) string {
+		return ep.DNSName + "/" + ep.RecordType
+	}
+	existing := map[string]*externaldns.Endpoint{}
+	if current != nil {
+		for _, ep := range current.Spec.Endpoints {
+			existing[key(ep)] = ep
+		}
+	}
+	for _, ep := range desired.Spec.Endpoints {
+		old, found := existing[key(ep)]
+		switch {
+		case !found:
+			changes = append(changes, fmt.Sprintf("+ %s", ep))
+		case !old.Targets.Same(ep.Targets) || old.RecordTTL != ep.RecordTTL || fmt.Sprint(old.Labels) != fmt.Sprint(ep.Labels):
+			changes = append(changes, fmt.Sprintf("~ %s -> %s", old, ep))
+		}
+		delete(existing, key(ep))
+	}
+	if current != nil {
+		for _, ep := range current.Spec.Endpoints {
+			if _, found := existing[key(ep)]; found {
+				changes = append(changes, fmt.Sprintf("- %s", ep))
+			}
+		}
+	}
+	return changes
+}
+
+// diffNameServers returns human readable list of nameservers added to or removed from delegated zone
+func diffNameServers(current, desired []ibcl.NameServer) (changes []string) {
+	existing := map[ibcl.NameServer]bool{}
+	for _, ns := range current {
+		existing[ns] = true
+	}
+	for _, ns := range desired {
+		if !existing[ns] {
+			changes = append(changes, fmt.Sprintf("+ %s %s", ns.Name, ns.Address))
+		}
+		delete(existing, ns)
+	}
+	for _, ns := range current {
+		if existing[ns] {
+			changes = append(changes, fmt.Sprintf("- %s %s", ns.Name, ns.Address))
+		}
+	}
+	return changes
+}
@@ -0,0 +1,125 @@
+package dns
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mocks"
+
+	"github.com/golang/mock/gomock"
+	ibclient "github.com/infobloxopen/infoblox-go-client"
+	"github.com/stretchr/testify/assert"
+	externaldns "sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDiffDNSEndpoints(t *testing.T) {
+	ep := func(endpoints ...*externaldns.Endpoint) *externaldns.DNSEndpoint {
+		return &externaldns.DNSEndpoint{Spec: externaldns.DNSEndpointSpec{Endpoints: endpoints}}
+	}
+	a := &externaldns.Endpoint{DNSName: "demo.cloud.example.com", RecordType: "A", RecordTTL: 30, Targets: []string{"10.0.0.1", "10.0.0.2"}}
+	aReordered := &externaldns.Endpoint{DNSName: "demo.cloud.example.com", RecordType: "A", RecordTTL: 30, Targets: []string{"10.0.0.2", "10.0.0.1"}}
+	aTTL := &externaldns.Endpoint{DNSName: "demo.cloud.example.com", RecordType: "A", RecordTTL: 60, Targets: []string{"10.0.0.1", "10.0.0.2"}}
+	local := &externaldns.Endpoint{DNSName: "localtargets-demo.cloud.example.com", RecordType: "A", RecordTTL: 30, Targets: []string{"10.0.0.1"}}
+	var tests = []struct {
+		name     string
+		current  *externaldns.DNSEndpoint
+		desired  *externaldns.DNSEndpoint
+		expected []string
+	}{
+		{name: "Endpoint Doesn't Exist", current: nil, desired: ep(a, local),
+			expected: []string{fmt.Sprintf("+ %s", a), fmt.Sprintf("+ %s", local)}},
+		{name: "No Changes", current: ep(a, local), desired: ep(aReordered, local), expected: nil},
+		{name: "TTL Changed", current: ep(a), desired: ep(aTTL), expected: []string{fmt.Sprintf("~ %s -> %s", a, aTTL)}},
+		{name: "Record Removed", current: ep(a, local), desired: ep(a), expected: []string{fmt.Sprintf("- %s", local)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			changes := diffDNSEndpoints(test.current, test.desired)
+			// assert
+			assert.Equal(t, test.expected, changes)
+		})
+	}
+}
+
+func TestDiffNameServers(t *testing.T) {
+	// arrange
+	current := []ibclient.NameServer{
+		{Address: "10.0.0.1", Name: "gslb-ns-us-west-1-cloud.example.com"},
+		{Address: "10.1.0.1", Name: "gslb-ns-us-east-1-cloud.example.com"},
+	}
+	desired := []ibclient.NameServer{
+		{Address: "10.0.0.1", Name: "gslb-ns-us-west-1-cloud.example.com"},
+		{Address: "10.0.0.2", Name: "gslb-ns-us-west-1-cloud.example.com"},
+	}
+	// act
+	changes := diffNameServers(current, desired)
+	// assert
+	assert.Equal(t, []string{
+		"+ gslb-ns-us-west-1-cloud.example.com 10.0.0.2",
+		"- gslb-ns-us-east-1-cloud.example.com 10.1.0.1",
+	}, changes)
+}
+
+func TestDryRunSaveDNSEndpoint(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	m := mocks.NewMockMetrics(ctrl)
+	desired := &externaldns.DNSEndpoint{Spec: externaldns.DNSEndpointSpec{Endpoints: []*externaldns.Endpoint{
+		{DNSName: "demo.cloud.example.com", RecordType: "A", RecordTTL: 30, Targets: []string{"10.0.0.1"}},
+	}}}
+	desired.Name = "demo"
+	a.EXPECT().GetDNSEndpoint(gomock.Any(), "test", "demo").Return(nil, nil).Times(1)
+	a.EXPECT().SaveDNSEndpoint(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	m.EXPECT().SetDryRunPlannedChanges(PlanKindDNSEndpoint, "test/demo", 1).Times(1)
+	// act
+	err := newDryRunAssistant(a, log, m).SaveDNSEndpoint(context.TODO(), "test", desired)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestInfobloxDryRunCreateZoneDelegation(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	m := mocks.NewMockMetrics(ctrl)
+	m.EXPECT().InfobloxObserveRequestDuration(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	m.EXPECT().InfobloxIncrementZoneUpdate(gomock.Any()).AnyTimes()
+	m.EXPECT().SetDryRunPlannedChanges(PlanKindZoneDelegation, defaultConfig.DNSZone, 2).Times(1)
+	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).Return(nil)
+	con.EXPECT().CreateObject(gomock.Any()).Times(0)
+	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Times(0)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	config := defaultConfig
+	config.DryRun = true
+	provider := NewInfobloxDNS(&config, a, cl, log, m)
+
+	// act
+	err := provider.CreateZoneDelegationForExternalDNS(context.TODO(), ipRange, nil)
+	// assert
+	assert.NoError(t, err)
+}
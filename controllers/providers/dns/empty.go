@@ -19,6 +19,8 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
+
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
@@ -29,26 +31,27 @@ import (
 // EmptyDNSProvider is executed when fakeDNSEnabled is true.
 type EmptyDNSProvider struct {
 	assistant assistant.Assistant
-	config    depresolver.Config
+	config    *depresolver.Config
 }
 
-func NewEmptyDNS(config depresolver.Config, assistant assistant.Assistant) *EmptyDNSProvider {
+func NewEmptyDNS(config *depresolver.Config, assistant assistant.Assistant) *EmptyDNSProvider {
 	return &EmptyDNSProvider{
 		config:    config,
 		assistant: assistant,
 	}
 }
 
-func (p *EmptyDNSProvider) CreateZoneDelegationForExternalDNS(*mapper.LoopState) (err error) {
+func (p *EmptyDNSProvider) CreateZoneDelegationForExternalDNS(context.Context, []string, []string) (err error) {
 	return
 }
 
-func (p *EmptyDNSProvider) GetExternalTargets(host string) (targets assistant.Targets) {
-	return p.assistant.GetExternalTargets(host, p.config.GetExternalClusterNSNames())
+func (p *EmptyDNSProvider) GetExternalTargets(ctx context.Context, host string) (targets assistant.Targets, errs assistant.QueryErrors) {
+	config := depresolver.ConfigFromContext(ctx, p.config)
+	return p.assistant.GetExternalTargets(ctx, host, config.GetExternalClusterNSNames())
 }
 
-func (p *EmptyDNSProvider) SaveDNSEndpoint(gslb *mapper.LoopState, i *externaldns.DNSEndpoint) error {
-	return p.assistant.SaveDNSEndpoint(gslb.NamespacedName.Namespace, i)
+func (p *EmptyDNSProvider) SaveDNSEndpoint(ctx context.Context, gslb *mapper.LoopState, i *externaldns.DNSEndpoint) error {
+	return p.assistant.SaveDNSEndpoint(ctx, gslb.NamespacedName.Namespace, i)
 }
 
 func (p *EmptyDNSProvider) String() string {
@@ -59,6 +62,14 @@ func (p *EmptyDNSProvider) RequireFinalizer() bool {
 	return false
 }
 
-func (p *EmptyDNSProvider) Finalize(*mapper.LoopState) error {
+func (p *EmptyDNSProvider) Finalize(context.Context, *mapper.LoopState) error {
+	return nil
+}
+
+func (p *EmptyDNSProvider) Withdraw(context.Context, []string) error {
+	return nil
+}
+
+func (p *EmptyDNSProvider) Ping(context.Context) error {
 	return nil
 }
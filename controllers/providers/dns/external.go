@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -37,12 +38,12 @@ const externalDNSTypeCommon = "extdns"
 
 type ExternalDNSProvider struct {
 	assistant    assistant2.Assistant
-	config       depresolver.Config
+	config       *depresolver.Config
 	endpointName string
 	log          *zerolog.Logger
 }
 
-func NewExternalDNS(config depresolver.Config, assistant assistant2.Assistant, log *zerolog.Logger) *ExternalDNSProvider {
+func NewExternalDNS(config *depresolver.Config, assistant assistant2.Assistant, log *zerolog.Logger) *ExternalDNSProvider {
 	return &ExternalDNSProvider{
 		assistant:    assistant,
 		config:       config,
@@ -51,42 +52,41 @@ func NewExternalDNS(config depresolver.Config, assistant assistant2.Assistant, l
 	}
 }
 
-func (p *ExternalDNSProvider) CreateZoneDelegationForExternalDNS(rs *mapper.LoopState) error {
-	ttl := externaldns.TTL(rs.Spec.DNSTtlSeconds)
+func (p *ExternalDNSProvider) CreateZoneDelegationForExternalDNS(ctx context.Context, exposedIPs []string, _ []string) error {
+	config := depresolver.ConfigFromContext(ctx, p.config)
+	ttl := externaldns.TTL(config.NSRecordTTL)
 	p.log.Info().
 		Str("provider", p.String()).
 		Msg("Creating/Updating DNSEndpoint CRDs")
-	NSServerList := []string{p.config.GetClusterNSName()}
-	for _, v := range p.config.GetExternalClusterNSNames() {
+	NSServerList := []string{config.GetClusterNSName()}
+	for _, v := range config.GetExternalClusterNSNames() {
 		NSServerList = append(NSServerList, v)
 	}
 	sort.Strings(NSServerList)
-	var NSServerIPs []string
+	NSServerIPs := exposedIPs
 	var err error
-	if p.config.CoreDNSExposed {
-		NSServerIPs, err = p.assistant.CoreDNSExposedIPs()
-	} else {
-		NSServerIPs, err = rs.GetExposedIPs()
-	}
-	if err != nil {
-		return err
+	if config.CoreDNSExposed {
+		NSServerIPs, err = p.assistant.CoreDNSExposedIPs(ctx)
+		if err != nil {
+			return err
+		}
 	}
 	NSRecord := &externaldns.DNSEndpoint{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        p.endpointName,
-			Namespace:   p.config.K8gbNamespace,
+			Namespace:   config.K8gbNamespace,
 			Annotations: map[string]string{"k8gb.absa.oss/dnstype": externalDNSTypeCommon},
 		},
 		Spec: externaldns.DNSEndpointSpec{
 			Endpoints: []*externaldns.Endpoint{
 				{
-					DNSName:    p.config.DNSZone,
+					DNSName:    config.DNSZone,
 					RecordTTL:  ttl,
 					RecordType: "NS",
 					Targets:    NSServerList,
 				},
 				{
-					DNSName:    p.config.GetClusterNSName(),
+					DNSName:    config.GetClusterNSName(),
 					RecordTTL:  ttl,
 					RecordType: "A",
 					Targets:    NSServerIPs,
@@ -94,19 +94,20 @@ func (p *ExternalDNSProvider) CreateZoneDelegationForExternalDNS(rs *mapper.Loop
 			},
 		},
 	}
-	err = p.assistant.SaveDNSEndpoint(p.config.K8gbNamespace, NSRecord)
+	err = p.assistant.SaveDNSEndpoint(ctx, config.K8gbNamespace, NSRecord)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (p *ExternalDNSProvider) GetExternalTargets(host string) (targets assistant2.Targets) {
-	return p.assistant.GetExternalTargets(host, p.config.GetExternalClusterNSNames())
+func (p *ExternalDNSProvider) GetExternalTargets(ctx context.Context, host string) (targets assistant2.Targets, errs assistant2.QueryErrors) {
+	config := depresolver.ConfigFromContext(ctx, p.config)
+	return p.assistant.GetExternalTargets(ctx, host, config.GetExternalClusterNSNames())
 }
 
-func (p *ExternalDNSProvider) SaveDNSEndpoint(rs *mapper.LoopState, i *externaldns.DNSEndpoint) error {
-	return p.assistant.SaveDNSEndpoint(rs.NamespacedName.Namespace, i)
+func (p *ExternalDNSProvider) SaveDNSEndpoint(ctx context.Context, rs *mapper.LoopState, i *externaldns.DNSEndpoint) error {
+	return p.assistant.SaveDNSEndpoint(ctx, rs.NamespacedName.Namespace, i)
 }
 
 func (p *ExternalDNSProvider) String() string {
@@ -117,6 +118,26 @@ func (p *ExternalDNSProvider) RequireFinalizer() bool {
 	return false
 }
 
-func (p *ExternalDNSProvider) Finalize(*mapper.LoopState) error {
+func (p *ExternalDNSProvider) Finalize(context.Context, *mapper.LoopState) error {
+	return nil
+}
+
+func (p *ExternalDNSProvider) Withdraw(ctx context.Context, _ []string) error {
+	err := p.assistant.RemoveEndpoint(ctx, p.endpointName)
+	if err != nil || p.config.DryRun {
+		return err
+	}
+	ep, err := p.assistant.GetDNSEndpoint(ctx, p.config.K8gbNamespace, p.endpointName)
+	if err != nil {
+		return err
+	}
+	if ep != nil {
+		return fmt.Errorf("DNSEndpoint %s/%s still exists", p.config.K8gbNamespace, p.endpointName)
+	}
+	return nil
+}
+
+// Ping is no-op, DNSEndpoints are written to the cluster and external-dns talks to the DNS provider
+func (p *ExternalDNSProvider) Ping(context.Context) error {
 	return nil
 }
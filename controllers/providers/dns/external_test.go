@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -53,6 +54,7 @@ var a = struct {
 }{
 	Config: depresolver.Config{
 		ReconcileRequeueSeconds: 30,
+		NSRecordTTL:             30,
 		ClusterGeoTag:           "us",
 		ExtClustersGeoTags:      []string{"za", "eu"},
 		EdgeDNSServers: []utils.DNSServer{
@@ -122,18 +124,37 @@ func TestCreateZoneDelegationOnExternalDNS(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	m := mocks.NewMockAssistant(ctrl)
-	mp := mocks.NewMockMapper(ctrl)
-	mp.EXPECT().GetExposedIPs().Return(a.TargetIPs, nil).Times(1)
-
-	p := NewExternalDNS(a.Config, m, log)
-	m.EXPECT().SaveDNSEndpoint(a.Config.K8gbNamespace, gomock.Eq(expectedDNSEndpoint)).Return(nil).Times(1).
-		Do(func(ns string, ep *externaldns.DNSEndpoint) {
+	p := NewExternalDNS(&a.Config, m, log)
+	m.EXPECT().SaveDNSEndpoint(gomock.Any(), a.Config.K8gbNamespace, gomock.Eq(expectedDNSEndpoint)).Return(nil).Times(1).
+		Do(func(_ context.Context, ns string, ep *externaldns.DNSEndpoint) {
 			require.True(t, reflect.DeepEqual(ep, expectedDNSEndpoint))
 			require.Equal(t, ns, a.Config.K8gbNamespace)
 		})
 
 	// act
-	err := p.CreateZoneDelegationForExternalDNS(a.State(mp))
+	err := p.CreateZoneDelegationForExternalDNS(context.TODO(), a.TargetIPs, nil)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestCreateZoneDelegationOnExternalDNSWithConfigSnapshot(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockAssistant(ctrl)
+	p := NewExternalDNS(&a.Config, m, log)
+	snapshot := a.Config
+	snapshot.NSRecordTTL = 60
+	snapshot.ExtClustersGeoTags = []string{"eu"}
+	m.EXPECT().SaveDNSEndpoint(gomock.Any(), a.Config.K8gbNamespace, gomock.Any()).Return(nil).Times(1).
+		Do(func(_ context.Context, _ string, ep *externaldns.DNSEndpoint) {
+			require.Equal(t, externaldns.TTL(60), ep.Spec.Endpoints[0].RecordTTL)
+			require.Equal(t, externaldns.Targets{"gslb-ns-eu-cloud.example.com", "gslb-ns-us-cloud.example.com"},
+				ep.Spec.Endpoints[0].Targets)
+		})
+
+	// act
+	err := p.CreateZoneDelegationForExternalDNS(depresolver.WithConfig(context.TODO(), &snapshot), a.TargetIPs, nil)
 	// assert
 	assert.NoError(t, err)
 }
@@ -161,10 +182,10 @@ func TestSaveNewDNSEndpointOnExternalDNS(t *testing.T) {
 
 	var cl = fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(ep).Build()
 
-	assistant := assistant.NewGslbAssistant(cl, a.Config.K8gbNamespace, a.Config.EdgeDNSServers)
-	p := NewExternalDNS(a.Config, assistant, log)
+	assistant := assistant.NewGslbAssistant(cl, a.Config.K8gbNamespace, a.Config.EdgeDNSServers, mx)
+	p := NewExternalDNS(&a.Config, assistant, log)
 	// act, assert
-	err := p.SaveDNSEndpoint(a.State(), expectedDNSEndpoint)
+	err := p.SaveDNSEndpoint(context.TODO(), a.State(), expectedDNSEndpoint)
 	assert.NoError(t, err)
 }
 
@@ -180,9 +201,37 @@ func TestSaveExistingDNSEndpointOnExternalDNS(t *testing.T) {
 	require.NoError(t, schemeBuilder.AddToScheme(runtimeScheme))
 
 	var cl = fake.NewClientBuilder().WithScheme(runtimeScheme).WithObjects(endpointToSave).Build()
-	assistant := assistant.NewGslbAssistant(cl, a.Config.K8gbNamespace, a.Config.EdgeDNSServers)
-	p := NewExternalDNS(a.Config, assistant, log)
+	assistant := assistant.NewGslbAssistant(cl, a.Config.K8gbNamespace, a.Config.EdgeDNSServers, mx)
+	p := NewExternalDNS(&a.Config, assistant, log)
 	// act, assert
-	err := p.SaveDNSEndpoint(a.State(), endpointToSave)
+	err := p.SaveDNSEndpoint(context.TODO(), a.State(), endpointToSave)
 	assert.NoError(t, err)
 }
+
+func TestWithdrawOnExternalDNS(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockAssistant(ctrl)
+	m.EXPECT().RemoveEndpoint(gomock.Any(), "k8gb-ns-extdns").Return(nil).Times(1)
+	m.EXPECT().GetDNSEndpoint(gomock.Any(), a.Config.K8gbNamespace, "k8gb-ns-extdns").Return(nil, nil).Times(1)
+	p := NewExternalDNS(&a.Config, m, log)
+	// act
+	err := p.Withdraw(context.TODO(), nil)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestWithdrawOnExternalDNSNotConfirmed(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockAssistant(ctrl)
+	m.EXPECT().RemoveEndpoint(gomock.Any(), "k8gb-ns-extdns").Return(nil).Times(1)
+	m.EXPECT().GetDNSEndpoint(gomock.Any(), a.Config.K8gbNamespace, "k8gb-ns-extdns").Return(expectedDNSEndpoint, nil).Times(1)
+	p := NewExternalDNS(&a.Config, m, log)
+	// act
+	err := p.Withdraw(context.TODO(), nil)
+	// assert
+	assert.Error(t, err)
+}
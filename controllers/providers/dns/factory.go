@@ -30,7 +30,7 @@ import (
 )
 
 type ProviderFactory struct {
-	config  depresolver.Config
+	config  *depresolver.Config
 	client  client.Client
 	log     *zerolog.Logger
 	metrics metrics.Metrics
@@ -38,7 +38,7 @@ type ProviderFactory struct {
 
 func NewDNSProviderFactory(
 	client client.Client,
-	config depresolver.Config,
+	config *depresolver.Config,
 	log *zerolog.Logger,
 	metrics metrics.Metrics,
 ) (f *ProviderFactory, err error) {
@@ -61,7 +61,10 @@ func NewDNSProviderFactory(
 }
 
 func (f *ProviderFactory) Provider() Provider {
-	a := assistant.NewGslbAssistant(f.client, f.config.K8gbNamespace, f.config.EdgeDNSServers)
+	var a assistant.Assistant = assistant.NewGslbAssistant(f.client, f.config.K8gbNamespace, f.config.EdgeDNSServers, f.metrics)
+	if f.config.DryRun {
+		a = newDryRunAssistant(a, f.log, f.metrics)
+	}
 	switch f.config.EdgeDNSType {
 	case depresolver.DNSTypeExternal:
 		return NewExternalDNS(f.config, a, f.log)
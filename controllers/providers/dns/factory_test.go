@@ -33,9 +33,12 @@ import (
 
 var (
 	defaultConfig = depresolver.Config{
-		ReconcileRequeueSeconds: 30,
-		ClusterGeoTag:           "us-west-1",
-		ExtClustersGeoTags:      []string{"us-east-1"},
+		ReconcileRequeueSeconds:      30,
+		ZoneDelegationRequeueSeconds: 30,
+		SplitBrainThresholdSeconds:   300,
+		NSRecordTTL:                  30,
+		ClusterGeoTag:                "us-west-1",
+		ExtClustersGeoTags:           []string{"us-east-1"},
 		EdgeDNSServers: []utils.DNSServer{
 			{
 				Host: "8.8.8.8",
@@ -61,7 +64,7 @@ func TestFactoryInfoblox(t *testing.T) {
 	customConfig := defaultConfig
 	customConfig.EdgeDNSType = depresolver.DNSTypeInfoblox
 	// act
-	f, err := NewDNSProviderFactory(client, customConfig, log, mx)
+	f, err := NewDNSProviderFactory(client, &customConfig, log, mx)
 	require.NoError(t, err)
 	provider := f.Provider()
 	// assert
@@ -76,7 +79,7 @@ func TestFactoryExternal(t *testing.T) {
 	customConfig := defaultConfig
 	customConfig.EdgeDNSType = depresolver.DNSTypeExternal
 	// act
-	f, err := NewDNSProviderFactory(client, customConfig, log, mx)
+	f, err := NewDNSProviderFactory(client, &customConfig, log, mx)
 	require.NoError(t, err)
 	provider := f.Provider()
 	// assert
@@ -91,7 +94,7 @@ func TestFactoryNoEdgeDNS(t *testing.T) {
 	customConfig := defaultConfig
 	customConfig.EdgeDNSType = depresolver.DNSTypeNoEdgeDNS
 	// act
-	f, err := NewDNSProviderFactory(client, customConfig, log, mx)
+	f, err := NewDNSProviderFactory(client, &customConfig, log, mx)
 	require.NoError(t, err)
 	provider := f.Provider()
 	// assert
@@ -105,7 +108,7 @@ func TestFactoryNilClient(t *testing.T) {
 	customConfig.EdgeDNSType = depresolver.DNSTypeNoEdgeDNS
 	// act
 	// assert
-	_, err := NewDNSProviderFactory(nil, customConfig, log, mx)
+	_, err := NewDNSProviderFactory(nil, &customConfig, log, mx)
 	require.Error(t, err)
 }
 
@@ -116,7 +119,7 @@ func TestFactoryNilLog(t *testing.T) {
 	customConfig.EdgeDNSType = depresolver.DNSTypeNoEdgeDNS
 	// act
 	// assert
-	_, err := NewDNSProviderFactory(client, customConfig, nil, mx)
+	_, err := NewDNSProviderFactory(client, &customConfig, nil, mx)
 	require.Error(t, err)
 }
 
@@ -127,6 +130,6 @@ func TestFactoryNilMetrics(t *testing.T) {
 	customConfig.EdgeDNSType = depresolver.DNSTypeInfoblox
 	// act
 	// assert
-	_, err := NewDNSProviderFactory(client, customConfig, log, nil)
+	_, err := NewDNSProviderFactory(client, &customConfig, log, nil)
 	require.Error(t, err)
 }
@@ -32,10 +32,10 @@ type InfobloxClient interface {
 
 type Client struct {
 	objMgr *ibclient.ObjectManager
-	config depresolver.Config
+	config *depresolver.Config
 }
 
-func NewInfobloxClient(config depresolver.Config) *Client {
+func NewInfobloxClient(config *depresolver.Config) *Client {
 	return &Client{
 		config: config,
 	}
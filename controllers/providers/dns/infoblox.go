@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"time"
@@ -27,22 +28,24 @@ import (
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/tracing"
 
 	ibcl "github.com/infobloxopen/infoblox-go-client"
 	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/types"
 	externaldns "sigs.k8s.io/external-dns/endpoint"
 )
 
 type InfobloxProvider struct {
 	assistant assistant.Assistant
-	config    depresolver.Config
+	config    *depresolver.Config
 	client    InfobloxClient
 	log       *zerolog.Logger
 	metrics   metrics.Metrics
 }
 
 func NewInfobloxDNS(
-	config depresolver.Config,
+	config *depresolver.Config,
 	assistant assistant.Assistant,
 	client InfobloxClient,
 	log *zerolog.Logger,
@@ -57,6 +60,16 @@ func NewInfobloxDNS(
 	}
 }
 
+// Ping reads the delegated zone, which verifies that WAPI is reachable and credentials are valid
+func (p *InfobloxProvider) Ping(ctx context.Context) error {
+	objMgr, err := p.objectManager(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = p.getZoneDelegated(ctx, objMgr, p.config.DNSZone)
+	return err
+}
+
 func (p *InfobloxProvider) sanitizeDelegateZone(local, upstream []ibcl.NameServer) []ibcl.NameServer {
 	// Drop own records for straight away update
 	// And ensure local entries are up to date
@@ -69,39 +82,39 @@ func (p *InfobloxProvider) sanitizeDelegateZone(local, upstream []ibcl.NameServe
 	return final
 }
 
-func (p *InfobloxProvider) CreateZoneDelegationForExternalDNS(rs *mapper.LoopState) error {
-	objMgr, err := p.client.GetObjectManager()
+func (p *InfobloxProvider) CreateZoneDelegationForExternalDNS(ctx context.Context, exposedIPs []string, resources []string) error {
+	config := depresolver.ConfigFromContext(ctx, p.config)
+	zone := p.zoneNamespacedName()
+	objMgr, err := p.objectManager(ctx)
 	if err != nil {
-		p.metrics.InfobloxIncrementZoneUpdateError(rs.NamespacedName)
+		p.metrics.InfobloxIncrementZoneUpdateError(zone)
 		return err
 	}
-	var addresses []string
-	if p.config.CoreDNSExposed {
-		addresses, err = p.assistant.CoreDNSExposedIPs()
-	} else {
-		addresses, err = rs.GetExposedIPs()
-	}
-	if err != nil {
-		p.metrics.InfobloxIncrementZoneUpdateError(rs.NamespacedName)
-		return err
+	addresses := exposedIPs
+	if config.CoreDNSExposed {
+		addresses, err = p.assistant.CoreDNSExposedIPs(ctx)
+		if err != nil {
+			p.metrics.InfobloxIncrementZoneUpdateError(zone)
+			return err
+		}
 	}
 	var delegateTo []ibcl.NameServer
 
 	for _, address := range addresses {
-		nameServer := ibcl.NameServer{Address: address, Name: p.config.GetClusterNSName()}
+		nameServer := ibcl.NameServer{Address: address, Name: config.GetClusterNSName()}
 		delegateTo = append(delegateTo, nameServer)
 	}
 
-	findZone, err := p.getZoneDelegated(objMgr, p.config.DNSZone)
+	findZone, err := p.getZoneDelegated(ctx, objMgr, config.DNSZone)
 	if err != nil {
-		p.metrics.InfobloxIncrementZoneUpdateError(rs.NamespacedName)
+		p.metrics.InfobloxIncrementZoneUpdateError(zone)
 		return err
 	}
 
 	if findZone != nil {
 		err = p.checkZoneDelegated(findZone)
 		if err != nil {
-			p.metrics.InfobloxIncrementZoneUpdateError(rs.NamespacedName)
+			p.metrics.InfobloxIncrementZoneUpdateError(zone)
 			return err
 		}
 
@@ -111,12 +124,9 @@ func (p *InfobloxProvider) CreateZoneDelegationForExternalDNS(rs *mapper.LoopSta
 			currentList := p.sanitizeDelegateZone(delegateTo, findZone.DelegateTo)
 
 			// Drop external records if they are stale
-			extClusterHeartbeatFQDNs := p.config.GetExternalClusterHeartbeatFQDNs(rs.NamespacedName.Name)
-			if p.config.SplitBrainCheck {
-				for extClusterGeoTag, nsServerNameExt := range p.config.GetExternalClusterNSNames() {
-					err = p.assistant.InspectTXTThreshold(
-						extClusterHeartbeatFQDNs[extClusterGeoTag],
-						time.Second*time.Duration(rs.Spec.SplitBrainThresholdSeconds))
+			if config.SplitBrainCheck {
+				for extClusterGeoTag, nsServerNameExt := range config.GetExternalClusterNSNames() {
+					err = p.inspectHeartbeat(ctx, extClusterGeoTag, resources)
 					if err != nil {
 						p.log.Err(err).
 							Str("cluster", nsServerNameExt).
@@ -127,81 +137,132 @@ func (p *InfobloxProvider) CreateZoneDelegationForExternalDNS(rs *mapper.LoopSta
 				}
 			}
 
-			if !reflect.DeepEqual(findZone.DelegateTo, currentList) {
+			if reflect.DeepEqual(findZone.DelegateTo, currentList) {
+				p.plan(PlanKindZoneDelegation, findZone.Fqdn, nil)
+			} else {
 				p.log.Info().
 					Interface("records", findZone.DelegateTo).
 					Msg("Found delegated zone records")
 				p.log.Info().
-					Str("DNSZone", p.config.DNSZone).
+					Str("DNSZone", config.DNSZone).
 					Interface("serverList", currentList).
 					Msg("Updating delegated zone with the server list")
-				_, err = p.updateZoneDelegated(objMgr, findZone.Ref, currentList)
+				_, err = p.updateZoneDelegated(ctx, objMgr, findZone, currentList)
 				if err != nil {
-					p.metrics.InfobloxIncrementZoneUpdateError(rs.NamespacedName)
+					p.metrics.InfobloxIncrementZoneUpdateError(zone)
 					return err
 				}
-				p.metrics.InfobloxIncrementZoneUpdate(rs.NamespacedName)
+				p.metrics.InfobloxIncrementZoneUpdate(zone)
 			}
 		}
 	} else {
 		p.log.Info().
-			Str("DNSZone", p.config.DNSZone).
+			Str("DNSZone", config.DNSZone).
 			Msg("Creating delegated zone")
 		sortZones(delegateTo)
 		p.log.Debug().
 			Interface("records", delegateTo).
 			Msg("Delegated records")
-		_, err = p.createZoneDelegated(objMgr, p.config.DNSZone, delegateTo)
+		_, err = p.createZoneDelegated(ctx, objMgr, config.DNSZone, delegateTo)
 		if err != nil {
-			p.metrics.InfobloxIncrementZoneUpdateError(rs.NamespacedName)
+			p.metrics.InfobloxIncrementZoneUpdateError(zone)
 			return err
 		}
-		p.metrics.InfobloxIncrementZoneUpdate(rs.NamespacedName)
+		p.metrics.InfobloxIncrementZoneUpdate(zone)
 	}
-	if p.config.SplitBrainCheck {
-		return p.saveHeartbeatTXTRecord(objMgr, rs)
+	if config.SplitBrainCheck {
+		return p.saveHeartbeatTXTRecord(ctx, objMgr, resources)
 	}
 	return nil
 }
 
-func (p *InfobloxProvider) Finalize(rs *mapper.LoopState) error {
-	objMgr, err := p.client.GetObjectManager()
+// inspectHeartbeat returns nil when heartbeat of external cluster is fresh. Peers running previous release publish
+// heartbeat per annotated resource only, so these are accepted as well
+func (p *InfobloxProvider) inspectHeartbeat(ctx context.Context, geoTag string, resources []string) (err error) {
+	config := depresolver.ConfigFromContext(ctx, p.config)
+	threshold := time.Second * time.Duration(config.SplitBrainThresholdSeconds)
+	err = p.assistant.InspectTXTThreshold(ctx, geoTag, config.GetExternalClusterHeartbeatFQDNs()[geoTag], threshold)
+	for _, resource := range resources {
+		if err == nil {
+			return nil
+		}
+		err = p.assistant.InspectTXTThreshold(ctx, geoTag, config.GetExternalClusterLegacyHeartbeatFQDNs(resource)[geoTag], threshold)
+	}
+	return err
+}
+
+func (p *InfobloxProvider) Finalize(ctx context.Context, rs *mapper.LoopState) error {
+	objMgr, err := p.objectManager(ctx)
 	if err != nil {
 		return err
 	}
-	findZone, err := p.getZoneDelegated(objMgr, p.config.DNSZone)
+	// delegated zone is shared by all annotated resources within the cluster, so the cluster nameservers
+	// are withdrawn only when the last annotated resource is being removed
+	inUse, err := rs.HasOtherAnnotatedResources(ctx)
+	if err != nil {
+		return err
+	}
+	err = p.deleteHeartbeatTXTRecord(ctx, objMgr, p.config.GetClusterLegacyHeartbeatFQDN(rs.NamespacedName.Name))
+	if err != nil || inUse {
+		return err
+	}
+	err = p.removeClusterFromZoneDelegation(ctx, objMgr)
 	if err != nil {
 		return err
 	}
+	return p.deleteHeartbeatTXTRecord(ctx, objMgr, p.config.GetClusterHeartbeatFQDN())
+}
 
-	if findZone != nil {
-		err = p.checkZoneDelegated(findZone)
+func (p *InfobloxProvider) Withdraw(ctx context.Context, resources []string) error {
+	objMgr, err := p.objectManager(ctx)
+	if err != nil {
+		return err
+	}
+	err = p.removeClusterFromZoneDelegation(ctx, objMgr)
+	if err != nil {
+		return err
+	}
+	for _, heartbeatTXTName := range heartbeatFQDNs(depresolver.ConfigFromContext(ctx, p.config), resources) {
+		err = p.deleteHeartbeatTXTRecord(ctx, objMgr, heartbeatTXTName)
 		if err != nil {
 			return err
 		}
-		if len(findZone.Ref) > 0 {
-			p.log.Info().
-				Str("DNSZone", p.config.DNSZone).
-				Msg("Deleting delegated zone")
-			_, err := p.deleteZoneDelegated(objMgr, findZone.Ref)
-			if err != nil {
-				return err
-			}
-		}
 	}
-
-	heartbeatTXTName := p.config.GetClusterHeartbeatFQDN(rs.NamespacedName.Name)
-	findTXT, err := p.getTXTRecord(objMgr, heartbeatTXTName)
+	if p.config.DryRun {
+		return nil
+	}
+	// confirm the delegated zone doesn't contain cluster nameservers anymore
+	findZone, err := p.getZoneDelegated(ctx, objMgr, p.config.DNSZone)
 	if err != nil {
 		return err
 	}
+	if findZone != nil && len(p.filterOutDelegateTo(findZone.DelegateTo, p.config.GetClusterNSName())) != len(findZone.DelegateTo) {
+		return fmt.Errorf("delegated zone %s still contains %s", p.config.DNSZone, p.config.GetClusterNSName())
+	}
+	return nil
+}
+
+// heartbeatFQDNs returns heartbeat of the cluster followed by heartbeats of resources published for peers running
+// previous release
+func heartbeatFQDNs(config *depresolver.Config, resources []string) []string {
+	names := []string{config.GetClusterHeartbeatFQDN()}
+	for _, resource := range resources {
+		names = append(names, config.GetClusterLegacyHeartbeatFQDN(resource))
+	}
+	return names
+}
 
+func (p *InfobloxProvider) deleteHeartbeatTXTRecord(ctx context.Context, objMgr *ibcl.ObjectManager, heartbeatTXTName string) error {
+	findTXT, err := p.getTXTRecord(ctx, objMgr, heartbeatTXTName)
+	if err != nil {
+		return err
+	}
 	if findTXT != nil {
 		if len(findTXT.Ref) > 0 {
 			p.log.Info().
 				Str("TXTRecords", heartbeatTXTName).
 				Msg("Deleting split brain TXT record")
-			_, err := p.deleteTXTRecord(objMgr, findTXT.Ref)
+			_, err := p.deleteTXTRecord(ctx, objMgr, findTXT.Ref)
 			if err != nil {
 				return err
 			}
@@ -210,12 +271,55 @@ func (p *InfobloxProvider) Finalize(rs *mapper.LoopState) error {
 	return nil
 }
 
-func (p *InfobloxProvider) GetExternalTargets(host string) (targets assistant.Targets) {
-	return p.assistant.GetExternalTargets(host, p.config.GetExternalClusterNSNames())
+// removeClusterFromZoneDelegation removes cluster nameservers from delegated zone. The delegated zone
+// is deleted when no nameservers of any cluster are left
+func (p *InfobloxProvider) removeClusterFromZoneDelegation(ctx context.Context, objMgr *ibcl.ObjectManager) error {
+	findZone, err := p.getZoneDelegated(ctx, objMgr, p.config.DNSZone)
+	if err != nil {
+		return err
+	}
+	if findZone == nil {
+		return nil
+	}
+	err = p.checkZoneDelegated(findZone)
+	if err != nil {
+		return err
+	}
+	if len(findZone.Ref) == 0 {
+		return nil
+	}
+	remaining := p.filterOutDelegateTo(findZone.DelegateTo, p.config.GetClusterNSName())
+	if len(remaining) == 0 {
+		p.log.Info().
+			Str("DNSZone", p.config.DNSZone).
+			Msg("Deleting delegated zone")
+		_, err = p.deleteZoneDelegated(ctx, objMgr, findZone.Ref)
+		return err
+	}
+	if len(remaining) == len(findZone.DelegateTo) {
+		return nil
+	}
+	sortZones(remaining)
+	p.log.Info().
+		Str("DNSZone", p.config.DNSZone).
+		Interface("serverList", remaining).
+		Msg("Removing cluster nameservers from delegated zone")
+	_, err = p.updateZoneDelegated(ctx, objMgr, findZone, remaining)
+	if err != nil {
+		p.metrics.InfobloxIncrementZoneUpdateError(p.zoneNamespacedName())
+		return err
+	}
+	p.metrics.InfobloxIncrementZoneUpdate(p.zoneNamespacedName())
+	return nil
+}
+
+func (p *InfobloxProvider) GetExternalTargets(ctx context.Context, host string) (targets assistant.Targets, errs assistant.QueryErrors) {
+	config := depresolver.ConfigFromContext(ctx, p.config)
+	return p.assistant.GetExternalTargets(ctx, host, config.GetExternalClusterNSNames())
 }
 
-func (p *InfobloxProvider) SaveDNSEndpoint(rs *mapper.LoopState, i *externaldns.DNSEndpoint) error {
-	return p.assistant.SaveDNSEndpoint(rs.NamespacedName.Namespace, i)
+func (p *InfobloxProvider) SaveDNSEndpoint(ctx context.Context, rs *mapper.LoopState, i *externaldns.DNSEndpoint) error {
+	return p.assistant.SaveDNSEndpoint(ctx, rs.NamespacedName.Namespace, i)
 }
 
 func (p *InfobloxProvider) String() string {
@@ -226,37 +330,51 @@ func (p *InfobloxProvider) RequireFinalizer() bool {
 	return true
 }
 
-func (p *InfobloxProvider) saveHeartbeatTXTRecord(objMgr *ibcl.ObjectManager, rs *mapper.LoopState) (err error) {
+func (p *InfobloxProvider) saveHeartbeatTXTRecord(ctx context.Context, objMgr *ibcl.ObjectManager, resources []string) (err error) {
+	config := depresolver.ConfigFromContext(ctx, p.config)
 	var heartbeatTXTRecord *ibcl.RecordTXT
 	edgeTimestamp := fmt.Sprint(time.Now().UTC().Format("2006-01-02T15:04:05"))
-	heartbeatTXTName := p.config.GetClusterHeartbeatFQDN(rs.NamespacedName.Name)
-	heartbeatTXTRecord, err = p.getTXTRecord(objMgr, heartbeatTXTName)
-	if err != nil {
-		return
-	}
-	if heartbeatTXTRecord == nil {
-		p.log.Info().
-			Str("HeartbeatTXTName", heartbeatTXTName).
-			Msg("Creating split brain TXT record")
-		_, err = p.createTXTRecord(objMgr, heartbeatTXTName, edgeTimestamp, uint(rs.Spec.DNSTtlSeconds))
+	for _, heartbeatTXTName := range heartbeatFQDNs(config, resources) {
+		heartbeatTXTRecord, err = p.getTXTRecord(ctx, objMgr, heartbeatTXTName)
 		if err != nil {
-			p.metrics.InfobloxIncrementHeartbeatError(rs.NamespacedName)
 			return
 		}
-	} else {
-		p.log.Info().
-			Str("HeartbeatTXTName", heartbeatTXTName).
-			Msg("Updating split brain TXT record")
-		_, err = p.updateTXTRecord(objMgr, heartbeatTXTName, edgeTimestamp)
-		if err != nil {
-			p.metrics.InfobloxIncrementHeartbeatError(rs.NamespacedName)
-			return
+		if heartbeatTXTRecord == nil {
+			p.log.Info().
+				Str("HeartbeatTXTName", heartbeatTXTName).
+				Msg("Creating split brain TXT record")
+			_, err = p.createTXTRecord(ctx, objMgr, heartbeatTXTName, edgeTimestamp, uint(config.NSRecordTTL))
+			if err != nil {
+				p.metrics.InfobloxIncrementHeartbeatError(p.zoneNamespacedName())
+				return
+			}
+		} else {
+			p.log.Info().
+				Str("HeartbeatTXTName", heartbeatTXTName).
+				Msg("Updating split brain TXT record")
+			_, err = p.updateTXTRecord(ctx, objMgr, heartbeatTXTName, edgeTimestamp)
+			if err != nil {
+				p.metrics.InfobloxIncrementHeartbeatError(p.zoneNamespacedName())
+				return
+			}
 		}
 	}
-	p.metrics.InfobloxIncrementHeartbeat(rs.NamespacedName)
+	p.metrics.InfobloxIncrementHeartbeat(p.zoneNamespacedName())
 	return
 }
 
+// plan reports the changes in dry run mode; does nothing otherwise
+func (p *InfobloxProvider) plan(kind, name string, changes []string) {
+	if p.config.DryRun {
+		reportPlan(p.log, p.metrics, Plan{Kind: kind, Name: name, Changes: changes})
+	}
+}
+
+// zoneNamespacedName labels zone delegation metrics, the delegated zone is shared by all resources within the cluster
+func (p *InfobloxProvider) zoneNamespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: p.config.K8gbNamespace, Name: p.config.DNSZone}
+}
+
 func (p *InfobloxProvider) checkZoneDelegated(findZone *ibcl.ZoneDelegated) error {
 	if findZone.Fqdn != p.config.DNSZone {
 		err := fmt.Errorf("delegated zone returned from infoblox(%s) does not match requested gslb zone(%s)", findZone.Fqdn, p.config.DNSZone)
@@ -276,58 +394,114 @@ func (p *InfobloxProvider) filterOutDelegateTo(delegateTo []ibcl.NameServer, fqd
 	return
 }
 
-func (p *InfobloxProvider) createZoneDelegated(o *ibcl.ObjectManager, fqdn string, d []ibcl.NameServer) (res *ibcl.ZoneDelegated, err error) {
+// objectManager logs in WAPI within span. Canceled ctx stops the login before it is sent
+func (p *InfobloxProvider) objectManager(ctx context.Context) (objMgr *ibcl.ObjectManager, err error) {
+	_, span := tracing.Start(ctx, "Infoblox GetObjectManager")
+	defer func() { tracing.End(span, err) }()
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	return p.client.GetObjectManager()
+}
+
+// request sends WAPI request within span and observes its duration. Canceled ctx stops the request
+// before it is sent
+func (p *InfobloxProvider) request(ctx context.Context, request metrics.DNSProviderRequest, name string, send func() error) (err error) {
+	_, span := tracing.Start(ctx, "Infoblox "+string(request), tracing.RecordKey.String(name))
+	defer func() { tracing.End(span, err) }()
+	if err = ctx.Err(); err != nil {
+		return err
+	}
 	start := time.Now()
-	res, err = o.CreateZoneDelegated(fqdn, d)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.CreateZoneDelegated, err == nil)
+	err = send()
+	p.metrics.InfobloxObserveRequestDuration(start, request, err == nil)
+	return err
+}
+
+func (p *InfobloxProvider) createZoneDelegated(ctx context.Context, o *ibcl.ObjectManager, fqdn string, d []ibcl.NameServer) (res *ibcl.ZoneDelegated, err error) {
+	if p.config.DryRun {
+		p.plan(PlanKindZoneDelegation, fqdn, diffNameServers(nil, d))
+		return &ibcl.ZoneDelegated{Fqdn: fqdn, DelegateTo: d}, nil
+	}
+	err = p.request(ctx, metrics.CreateZoneDelegated, fqdn, func() (err error) {
+		res, err = o.CreateZoneDelegated(fqdn, d)
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) getZoneDelegated(o *ibcl.ObjectManager, fqdn string) (res *ibcl.ZoneDelegated, err error) {
-	start := time.Now()
-	res, err = o.GetZoneDelegated(fqdn)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.GetZoneDelegated, err == nil)
+func (p *InfobloxProvider) getZoneDelegated(ctx context.Context, o *ibcl.ObjectManager, fqdn string) (res *ibcl.ZoneDelegated, err error) {
+	err = p.request(ctx, metrics.GetZoneDelegated, fqdn, func() (err error) {
+		res, err = o.GetZoneDelegated(fqdn)
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) updateZoneDelegated(o *ibcl.ObjectManager, fqdn string, d []ibcl.NameServer) (res *ibcl.ZoneDelegated, err error) {
-	start := time.Now()
-	res, err = o.UpdateZoneDelegated(fqdn, d)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.UpdateZoneDelegated, err == nil)
+func (p *InfobloxProvider) updateZoneDelegated(ctx context.Context, o *ibcl.ObjectManager, zone *ibcl.ZoneDelegated, d []ibcl.NameServer) (res *ibcl.ZoneDelegated, err error) {
+	if p.config.DryRun {
+		p.plan(PlanKindZoneDelegation, zone.Fqdn, diffNameServers(zone.DelegateTo, d))
+		return zone, nil
+	}
+	err = p.request(ctx, metrics.UpdateZoneDelegated, zone.Fqdn, func() (err error) {
+		res, err = o.UpdateZoneDelegated(zone.Ref, d)
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) deleteZoneDelegated(o *ibcl.ObjectManager, fqdn string) (res string, err error) {
-	start := time.Now()
-	res, err = o.DeleteZoneDelegated(fqdn)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.DeleteZoneDelegated, err == nil)
+func (p *InfobloxProvider) deleteZoneDelegated(ctx context.Context, o *ibcl.ObjectManager, fqdn string) (res string, err error) {
+	if p.config.DryRun {
+		p.plan(PlanKindZoneDelegation, p.config.DNSZone, []string{fmt.Sprintf("- %s", p.config.DNSZone)})
+		return fqdn, nil
+	}
+	err = p.request(ctx, metrics.DeleteZoneDelegated, p.config.DNSZone, func() (err error) {
+		res, err = o.DeleteZoneDelegated(fqdn)
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) createTXTRecord(o *ibcl.ObjectManager, name string, text string, ttl uint) (res *ibcl.RecordTXT, err error) {
-	start := time.Now()
-	res, err = o.CreateTXTRecord(name, text, ttl, "default")
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.CreateTXTRecord, err == nil)
+func (p *InfobloxProvider) createTXTRecord(ctx context.Context, o *ibcl.ObjectManager, name string, text string, ttl uint) (res *ibcl.RecordTXT, err error) {
+	if p.config.DryRun {
+		p.plan(PlanKindHeartbeat, name, []string{fmt.Sprintf("+ %s %d IN TXT %s", name, ttl, text)})
+		return &ibcl.RecordTXT{Name: name, Text: text}, nil
+	}
+	err = p.request(ctx, metrics.CreateTXTRecord, name, func() (err error) {
+		res, err = o.CreateTXTRecord(name, text, ttl, "default")
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) getTXTRecord(o *ibcl.ObjectManager, name string) (res *ibcl.RecordTXT, err error) {
-	start := time.Now()
-	res, err = o.GetTXTRecord(name)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.GetTXTRecord, err == nil)
+func (p *InfobloxProvider) getTXTRecord(ctx context.Context, o *ibcl.ObjectManager, name string) (res *ibcl.RecordTXT, err error) {
+	err = p.request(ctx, metrics.GetTXTRecord, name, func() (err error) {
+		res, err = o.GetTXTRecord(name)
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) updateTXTRecord(o *ibcl.ObjectManager, name string, text string) (res *ibcl.RecordTXT, err error) {
-	start := time.Now()
-	res, err = o.UpdateTXTRecord(name, text)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.UpdateTXTRecord, err == nil)
+func (p *InfobloxProvider) updateTXTRecord(ctx context.Context, o *ibcl.ObjectManager, name string, text string) (res *ibcl.RecordTXT, err error) {
+	if p.config.DryRun {
+		p.plan(PlanKindHeartbeat, name, []string{fmt.Sprintf("~ %s IN TXT %s", name, text)})
+		return &ibcl.RecordTXT{Name: name, Text: text}, nil
+	}
+	err = p.request(ctx, metrics.UpdateTXTRecord, name, func() (err error) {
+		res, err = o.UpdateTXTRecord(name, text)
+		return err
+	})
 	return
 }
 
-func (p *InfobloxProvider) deleteTXTRecord(o *ibcl.ObjectManager, name string) (res string, err error) {
-	start := time.Now()
-	res, err = o.DeleteTXTRecord(name)
-	p.metrics.InfobloxObserveRequestDuration(start, metrics.DeleteTXTRecord, err == nil)
+func (p *InfobloxProvider) deleteTXTRecord(ctx context.Context, o *ibcl.ObjectManager, name string) (res string, err error) {
+	if p.config.DryRun {
+		p.plan(PlanKindHeartbeat, name, []string{fmt.Sprintf("- %s", name)})
+		return name, nil
+	}
+	err = p.request(ctx, metrics.DeleteTXTRecord, name, func() (err error) {
+		res, err = o.DeleteTXTRecord(name)
+		return err
+	})
 	return
 }
@@ -19,16 +19,22 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
 	"github.com/k8gb-io/k8gb-light/controllers/mocks"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/tracing"
 
 	"github.com/golang/mock/gomock"
 	ibclient "github.com/infobloxopen/infoblox-go-client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -78,11 +84,11 @@ func TestCanFilterOutDelegatedZoneEntryAccordingFQDNProvided(t *testing.T) {
 	customConfig := defaultConfig
 	customConfig.EdgeDNSZone = "example.com"
 	customConfig.ExtClustersGeoTags = []string{"za"}
-	a := assistant.NewGslbAssistant(nil, customConfig.K8gbNamespace, customConfig.EdgeDNSServers)
+	a := assistant.NewGslbAssistant(nil, customConfig.K8gbNamespace, customConfig.EdgeDNSServers, mx)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	m := mocks.NewMockInfobloxClient(ctrl)
-	provider := NewInfobloxDNS(customConfig, a, m, log, mx)
+	provider := NewInfobloxDNS(&customConfig, a, m, log, mx)
 	// act
 	extClusters := customConfig.GetExternalClusterNSNames()
 	got := provider.filterOutDelegateTo(delegateTo, extClusters["za"])
@@ -116,11 +122,11 @@ func TestCanSanitizeDelegatedZone(t *testing.T) {
 	customConfig.EdgeDNSZone = "example.com"
 	customConfig.ExtClustersGeoTags = []string{"za"}
 	customConfig.ClusterGeoTag = "eu"
-	a := assistant.NewGslbAssistant(nil, customConfig.K8gbNamespace, customConfig.EdgeDNSServers)
+	a := assistant.NewGslbAssistant(nil, customConfig.K8gbNamespace, customConfig.EdgeDNSServers, mx)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	m := mocks.NewMockInfobloxClient(ctrl)
-	provider := NewInfobloxDNS(customConfig, a, m, log, mx)
+	provider := NewInfobloxDNS(&customConfig, a, m, log, mx)
 	// act
 	got := provider.sanitizeDelegateZone(local, upstream)
 	// assert
@@ -156,18 +162,15 @@ func TestInfobloxCreateZoneDelegationForExternalDNS(t *testing.T) {
 	cl := mocks.NewMockInfobloxClient(ctrl)
 	con := mocks.NewMockIBConnector(ctrl)
 
-	mp := mocks.NewMockMapper(ctrl)
-	mp.EXPECT().GetExposedIPs().Return(ipRange, nil).Times(1)
-
 	con.EXPECT().CreateObject(gomock.Any()).Return(ref, nil).AnyTimes()
 	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).Times(1)
 	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).Return(nil)
 	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
 	config := defaultConfig
-	provider := NewInfobloxDNS(config, a, cl, log, mx)
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
 
 	// act
-	err := provider.CreateZoneDelegationForExternalDNS(defaultRs(mp))
+	err := provider.CreateZoneDelegationForExternalDNS(context.TODO(), ipRange, nil)
 	// assert
 	assert.NoError(t, err)
 }
@@ -179,25 +182,24 @@ func TestInfobloxCreateZoneDelegationForExternalDNSWithSplitBrainEnabled(t *test
 	a := mocks.NewMockAssistant(ctrl)
 	cl := mocks.NewMockInfobloxClient(ctrl)
 	con := mocks.NewMockIBConnector(ctrl)
-	a.EXPECT().InspectTXTThreshold(gomock.Any(), gomock.Any()).Do(func(fqdn string, arg1 interface{}) {
-		require.Equal(t, "test-infoblox-heartbeat-us-east-1.example.com", fqdn)
+	a.EXPECT().InspectTXTThreshold(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, geoTag, fqdn string, arg1 interface{}) {
+		require.Equal(t, "us-east-1", geoTag)
+		require.Equal(t, "cloud-heartbeat-us-east-1.example.com", fqdn)
 	}).Return(nil).Times(1)
-	mp := mocks.NewMockMapper(ctrl)
-	mp.EXPECT().GetExposedIPs().Return(ipRange, nil).Times(1)
 	con.EXPECT().CreateObject(gomock.Any()).Return(ref, nil).AnyTimes()
 	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).Times(2)
 	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).Return(nil)
 	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
 	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).
 		Return(nil).Do(func(arg0 *ibclient.RecordTXT, arg1, arg2 interface{}) {
-		require.Equal(t, "test-infoblox-heartbeat-us-west-1.example.com", arg0.Name)
+		require.Equal(t, "cloud-heartbeat-us-west-1.example.com", arg0.Name)
 	}).AnyTimes()
 	config := defaultConfig
 	config.SplitBrainCheck = true
-	provider := NewInfobloxDNS(config, a, cl, log, mx)
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
 
 	// act
-	err := provider.CreateZoneDelegationForExternalDNS(defaultRs(mp))
+	err := provider.CreateZoneDelegationForExternalDNS(context.TODO(), ipRange, nil)
 	// assert
 	assert.NoError(t, err)
 }
@@ -209,9 +211,7 @@ func TestInfobloxCreateZoneDelegationForExternalDNSWithSplitBrainEnabledCreating
 	a := mocks.NewMockAssistant(ctrl)
 	cl := mocks.NewMockInfobloxClient(ctrl)
 	con := mocks.NewMockIBConnector(ctrl)
-	mp := mocks.NewMockMapper(ctrl)
-	a.EXPECT().InspectTXTThreshold(gomock.Any(), gomock.Any()).Return(nil).Times(1)
-	mp.EXPECT().GetExposedIPs().Return(ipRange, nil).Times(1)
+	a.EXPECT().InspectTXTThreshold(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	con.EXPECT().CreateObject(gomock.Any()).Return(ref, nil).AnyTimes()
 	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).Times(1)
 	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).Return(nil)
@@ -219,14 +219,56 @@ func TestInfobloxCreateZoneDelegationForExternalDNSWithSplitBrainEnabledCreating
 	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{}).Return(nil).AnyTimes()
 	config := defaultConfig
 	config.SplitBrainCheck = true
-	provider := NewInfobloxDNS(config, a, cl, log, mx)
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
 
 	// act
-	err := provider.CreateZoneDelegationForExternalDNS(defaultRs(mp))
+	err := provider.CreateZoneDelegationForExternalDNS(context.TODO(), ipRange, nil)
 	// assert
 	assert.NoError(t, err)
 }
 
+func TestInfobloxCreateZoneDelegationForExternalDNSWithLegacyHeartbeats(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	var inspected, saved []string
+	// peer running previous release publishes heartbeat per resource only
+	a.EXPECT().InspectTXTThreshold(gomock.Any(), "us-east-1", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _, fqdn string, _ time.Duration) error {
+			inspected = append(inspected, fqdn)
+			if fqdn == "demo-heartbeat-us-east-1.example.com" {
+				return nil
+			}
+			return fmt.Errorf("not found")
+		}).Times(3)
+	con.EXPECT().CreateObject(gomock.Any()).Return(ref, nil).AnyTimes()
+	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).AnyTimes()
+	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).Return(nil)
+	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).
+		Return(nil).Do(func(arg0 *ibclient.RecordTXT, arg1, arg2 interface{}) {
+		// the record is read before and after update
+		if len(saved) == 0 || saved[len(saved)-1] != arg0.Name {
+			saved = append(saved, arg0.Name)
+		}
+	}).AnyTimes()
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	config := defaultConfig
+	config.SplitBrainCheck = true
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
+
+	// act
+	err := provider.CreateZoneDelegationForExternalDNS(context.TODO(), ipRange, []string{"app", "demo"})
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cloud-heartbeat-us-east-1.example.com", "app-heartbeat-us-east-1.example.com",
+		"demo-heartbeat-us-east-1.example.com"}, inspected)
+	assert.Equal(t, []string{"cloud-heartbeat-us-west-1.example.com", "app-heartbeat-us-west-1.example.com",
+		"demo-heartbeat-us-west-1.example.com"}, saved)
+}
+
 func TestInfobloxFinalize(t *testing.T) {
 	// arrange
 	ctrl := gomock.NewController(t)
@@ -234,26 +276,178 @@ func TestInfobloxFinalize(t *testing.T) {
 	a := mocks.NewMockAssistant(ctrl)
 	cl := mocks.NewMockInfobloxClient(ctrl)
 	con := mocks.NewMockIBConnector(ctrl)
+	mp := mocks.NewMockMapper(ctrl)
+	mp.EXPECT().HasOtherAnnotatedResources(gomock.Any()).Return(false, nil).Times(1)
 	con.EXPECT().DeleteObject(gomock.Any()).Return(ref, nil).Do(func(arg0 string) {
 		require.Equal(t, arg0, ref)
-	}).AnyTimes()
-	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).
-		Return(nil).Times(1)
+	}).Times(3)
+	gomock.InOrder(
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).
+			Return(nil).Do(func(arg0 *ibclient.RecordTXT, arg1, arg2 interface{}) {
+			require.Equal(t, "test-infoblox-heartbeat-us-west-1.example.com", arg0.Name)
+		}),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).
+			Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).
+			Return(nil).Do(func(arg0 *ibclient.RecordTXT, arg1, arg2 interface{}) {
+			require.Equal(t, "cloud-heartbeat-us-west-1.example.com", arg0.Name)
+		}),
+	)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	config := defaultConfig
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
+
+	// act
+	err := provider.Finalize(context.TODO(), defaultRs(mp))
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestInfobloxFinalizeRemovesOnlyClusterNameServers(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	mp := mocks.NewMockMapper(ctrl)
+	zone := defaultDelegatedZone
+	zone.DelegateTo = []ibclient.NameServer{
+		{Address: "10.0.0.1", Name: "gslb-ns-us-west-1-cloud.example.com"},
+		{Address: "10.0.0.2", Name: "gslb-ns-us-west-1-cloud.example.com"},
+		{Address: "10.1.0.1", Name: "gslb-ns-us-east-1-cloud.example.com"},
+	}
+	mp.EXPECT().HasOtherAnnotatedResources(gomock.Any()).Return(false, nil).Times(1)
+	gomock.InOrder(
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{}).Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{zone}).Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).Return(nil),
+	)
+	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).Do(func(arg0 *ibclient.ZoneDelegated, arg1 string) {
+		require.Equal(t, []ibclient.NameServer{{Address: "10.1.0.1", Name: "gslb-ns-us-east-1-cloud.example.com"}}, arg0.DelegateTo)
+	}).Times(1)
+	// only heartbeat TXT record is deleted
+	con.EXPECT().DeleteObject(ref).Return(ref, nil).Times(1)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	config := defaultConfig
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
+
+	// act
+	err := provider.Finalize(context.TODO(), defaultRs(mp))
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestInfobloxFinalizeKeepsZoneDelegationWhenOtherResourcesExist(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	mp := mocks.NewMockMapper(ctrl)
+	mp.EXPECT().HasOtherAnnotatedResources(gomock.Any()).Return(true, nil).Times(1)
+	// only heartbeat TXT record of the resource published by previous release is deleted; neither zone delegation
+	// nor cluster heartbeat TXT record is touched
 	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).
 		Return(nil).Do(func(arg0 *ibclient.RecordTXT, arg1, arg2 interface{}) {
 		require.Equal(t, "test-infoblox-heartbeat-us-west-1.example.com", arg0.Name)
 	}).Times(1)
+	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Times(0)
+	con.EXPECT().DeleteObject(ref).Return(ref, nil).Times(1)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	config := defaultConfig
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
+
+	// act
+	err := provider.Finalize(context.TODO(), defaultRs(mp))
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestInfobloxFinalizeError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	mp := mocks.NewMockMapper(ctrl)
+	mp.EXPECT().HasOtherAnnotatedResources(gomock.Any()).Return(false, fmt.Errorf("list error")).Times(1)
+	con.EXPECT().DeleteObject(gomock.Any()).Times(0)
 	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
 	config := defaultConfig
-	provider := NewInfobloxDNS(config, a, cl, log, mx)
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
 
 	// act
-	err := provider.Finalize(defaultRs())
+	err := provider.Finalize(context.TODO(), defaultRs(mp))
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestInfobloxWithdraw(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	zone := defaultDelegatedZone
+	zone.DelegateTo = []ibclient.NameServer{
+		{Address: "10.0.0.1", Name: "gslb-ns-us-west-1-cloud.example.com"},
+		{Address: "10.1.0.1", Name: "gslb-ns-us-east-1-cloud.example.com"},
+	}
+	withdrawn := defaultDelegatedZone
+	withdrawn.DelegateTo = []ibclient.NameServer{{Address: "10.1.0.1", Name: "gslb-ns-us-east-1-cloud.example.com"}}
+	gomock.InOrder(
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{zone}).Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{{Ref: ref}}).Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{withdrawn}).Return(nil),
+	)
+	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).Times(1)
+	con.EXPECT().DeleteObject(ref).Return(ref, nil).Times(1)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	provider := NewInfobloxDNS(&defaultConfig, a, cl, log, mx)
+
+	// act
+	err := provider.Withdraw(context.TODO(), nil)
 
 	// assert
 	assert.NoError(t, err)
 }
 
+func TestInfobloxWithdrawNotConfirmed(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	zone := defaultDelegatedZone
+	zone.DelegateTo = []ibclient.NameServer{
+		{Address: "10.0.0.1", Name: "gslb-ns-us-west-1-cloud.example.com"},
+		{Address: "10.1.0.1", Name: "gslb-ns-us-east-1-cloud.example.com"},
+	}
+	gomock.InOrder(
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{zone}).Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.RecordTXT{}).Return(nil),
+		con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{zone}).Return(nil),
+	)
+	con.EXPECT().UpdateObject(gomock.Any(), gomock.Any()).Return(ref, nil).Times(1)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	provider := NewInfobloxDNS(&defaultConfig, a, cl, log, mx)
+
+	// act
+	err := provider.Withdraw(context.TODO(), nil)
+
+	// assert
+	assert.Error(t, err)
+}
+
 func TestEmptySort(t *testing.T) {
 	// arrange
 	delegateTo := make([]ibclient.NameServer, 0)
@@ -271,3 +465,78 @@ func TestNilSort(t *testing.T) {
 	// assert
 	assert.Nil(t, delegateTo)
 }
+
+func TestInfobloxPing(t *testing.T) {
+	var tests = []struct {
+		name        string
+		clientError error
+		getError    error
+	}{
+		{name: "reachable"},
+		{name: "connector error", clientError: fmt.Errorf("connection refused")},
+		{name: "WAPI error", getError: fmt.Errorf("401 Unauthorized")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			a := mocks.NewMockAssistant(ctrl)
+			cl := mocks.NewMockInfobloxClient(ctrl)
+			con := mocks.NewMockIBConnector(ctrl)
+			con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).
+				Return(test.getError).AnyTimes()
+			cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), test.clientError).Times(1)
+			config := defaultConfig
+			provider := NewInfobloxDNS(&config, a, cl, log, mx)
+			// act
+			err := provider.Ping(context.TODO())
+			// assert
+			assert.Equal(t, test.clientError != nil || test.getError != nil, err != nil)
+		})
+	}
+}
+
+func TestInfobloxCanceledContext(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	cl.EXPECT().GetObjectManager().Times(0)
+	config := defaultConfig
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// act
+	err := provider.Withdraw(ctx, nil)
+	// assert
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestInfobloxRequestSpans(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := mocks.NewMockAssistant(ctrl)
+	cl := mocks.NewMockInfobloxClient(ctrl)
+	con := mocks.NewMockIBConnector(ctrl)
+	con.EXPECT().GetObject(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, []ibclient.ZoneDelegated{defaultDelegatedZone}).
+		Return(nil).Times(1)
+	cl.EXPECT().GetObjectManager().Return(ibclient.NewObjectManager(con, "k8gbclient", ""), nil).Times(1)
+	config := defaultConfig
+	provider := NewInfobloxDNS(&config, a, cl, log, mx)
+	recorder := tracetest.NewSpanRecorder()
+	ctx, parent := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test").Start(context.Background(), "parent")
+	// act
+	err := provider.Ping(ctx)
+	parent.End()
+	// assert
+	require.NoError(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "Infoblox GetObjectManager", spans[0].Name())
+	assert.Equal(t, "Infoblox ZoneRead", spans[1].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Contains(t, spans[1].Attributes(), tracing.RecordKey.String(config.DNSZone))
+}
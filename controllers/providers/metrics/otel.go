@@ -0,0 +1,421 @@
+package metrics
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/types"
+	externaldns "sigs.k8s.io/external-dns/endpoint"
+)
+
+const instrumentationName = "github.com/k8gb-io/k8gb"
+
+type instrumentKind int
+
+const (
+	counter instrumentKind = iota
+	histogram
+	gauge
+)
+
+// otelInstruments describes OpenTelemetry instruments; names and descriptions follow prometheus collectors,
+// so dashboards work with both exporters
+var otelInstruments = []struct {
+	name        string
+	description string
+	kind        instrumentKind
+	buckets     []float64
+}{
+	{name: K8gbRuntimeInfo, description: "K8gb runtime info.", kind: gauge},
+	{name: K8gbEndpointStatusNum, description: "Number of targets in DNS endpoint.", kind: gauge},
+	{name: K8gbGslbHealthyRecords, description: "Number of healthy records observed by K8GB.", kind: gauge},
+	{name: K8gbGslbServiceStatusNum, description: "Number of managed hosts observed by K8GB.", kind: gauge},
+	{name: K8gbGslbErrorsTotal, description: "Number of errors", kind: counter},
+	{name: K8gbGslbReconciliationLoopsTotal, description: "Number of successful reconciliation loops.", kind: counter},
+	{name: K8gbGslbStatusCountForFailover, description: "Gslb status count for Failover strategy.", kind: gauge},
+	{name: K8gbGslbStatusCountForRoundrobin, description: "Gslb status count for RoundRobin strategy.", kind: gauge},
+	{name: K8gbGslbStatusCountForGeoIP, description: "Gslb status count for GeoIP strategy.", kind: gauge},
+	{name: K8gbInfobloxRequestDuration, description: "How long it took for Infoblox requests to complete, partitioned by request type. " +
+		"Round-trip time of http communication is included.", kind: histogram, buckets: infobloxRequestDurationBuckets},
+	{name: K8gbInfobloxZoneUpdatesTotal, description: "Number of K8GB Infoblox zone updates.", kind: counter},
+	{name: K8gbInfobloxZoneUpdateErrorsTotal, description: "Number of K8GB Infoblox zone update errors.", kind: counter},
+	{name: K8gbInfobloxHeartbeatsTotal, description: "Number of K8GB Infoblox heartbeat TXT record updates.", kind: counter},
+	{name: K8gbInfobloxHeartbeatErrorsTotal, description: "Number of K8GB Infoblox TXT record errors.", kind: counter},
+	{name: K8gbZoneDelegationLoopsTotal, description: "Number of successful zone delegation loops.", kind: counter},
+	{name: K8gbZoneDelegationErrorsTotal, description: "Number of zone delegation loop errors.", kind: counter},
+	{name: K8gbDryRunPlannedChanges, description: "Number of changes planned by dry run which were not applied.", kind: gauge},
+	{name: K8gbConfigReloadsTotal, description: "Number of successfully applied configuration reloads.", kind: counter},
+	{name: K8gbConfigReloadErrorsTotal, description: "Number of rejected configuration reloads.", kind: counter},
+	{name: K8gbPeerQueryDuration, description: "How long it took for DNS queries sent on behalf of peer clusters to complete in seconds, " +
+		"partitioned by peer and query.", kind: histogram, buckets: peerQueryDurationBuckets},
+	{name: K8gbPeerQueryErrorsTotal, description: "Number of failed DNS queries sent on behalf of peer clusters, " +
+		"partitioned by peer, query and error type.", kind: counter},
+	{name: K8gbPeerTargets, description: "Number of targets of host returned by peer cluster.", kind: gauge},
+	{name: K8gbPeerUp, description: "Whether nameserver of peer cluster answered the last query for targets (1) or not (0).", kind: gauge},
+	{name: K8gbPeerHeartbeatAge, description: "Age of the last heartbeat TXT record of peer cluster in seconds.", kind: gauge},
+}
+
+// OtelMetrics implements Metrics by OpenTelemetry SDK. Metrics are pushed by the reader passed to the
+// meter provider, which is OTLP/HTTP exporter in production
+type OtelMetrics struct {
+	once       sync.Once
+	config     depresolver.Config
+	provider   *sdkmetric.MeterProvider
+	meter      metric.Meter
+	counters   map[string]syncint64.Counter
+	histograms map[string]syncfloat64.Histogram
+	gauges     map[string]asyncfloat64.Gauge
+	values     *gaugeValues
+}
+
+// NewOtelMetrics creates metrics pushed periodically to OTLP/HTTP collector on OtelExporterOtlpEndpoint
+func NewOtelMetrics(ctx context.Context, config depresolver.Config) (*OtelMetrics, error) {
+	exporter, err := otlpmetrichttp.New(ctx,
+		otlpmetrichttp.WithEndpoint(config.OtelExporterOtlpEndpoint),
+		otlpmetrichttp.WithInsecure(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("can't create OTLP metrics exporter: %w", err)
+	}
+	reader := sdkmetric.NewPeriodicReader(exporter,
+		sdkmetric.WithInterval(time.Duration(config.MetricsExportIntervalSeconds)*time.Second))
+	return newOtelMetrics(config, reader)
+}
+
+func newOtelMetrics(config depresolver.Config, reader sdkmetric.Reader) (m *OtelMetrics, err error) {
+	var views []view.View
+	for _, i := range otelInstruments {
+		if i.kind != histogram {
+			continue
+		}
+		v, err := view.New(view.MatchInstrumentName(i.name),
+			view.WithSetAggregation(aggregation.ExplicitBucketHistogram{Boundaries: i.buckets}))
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	m = &OtelMetrics{
+		config:     config,
+		counters:   map[string]syncint64.Counter{},
+		histograms: map[string]syncfloat64.Histogram{},
+		gauges:     map[string]asyncfloat64.Gauge{},
+		values:     newGaugeValues(),
+	}
+	m.provider = sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(reader, views...),
+		sdkmetric.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String("k8gb"))),
+	)
+	m.meter = m.provider.Meter(instrumentationName)
+	err = m.init()
+	return m, err
+}
+
+// init instantiates particular instruments
+func (m *OtelMetrics) init() (err error) {
+	for _, i := range otelInstruments {
+		desc := instrument.WithDescription(i.description)
+		switch i.kind {
+		case counter:
+			m.counters[i.name], err = m.meter.SyncInt64().Counter(i.name, desc)
+		case histogram:
+			m.histograms[i.name], err = m.meter.SyncFloat64().Histogram(i.name, desc)
+		case gauge:
+			m.gauges[i.name], err = m.meter.AsyncFloat64().Gauge(i.name, desc)
+		}
+		if err != nil {
+			return fmt.Errorf("can't create instrument %s: %w", i.name, err)
+		}
+	}
+	return nil
+}
+
+// Get is not supported, OpenTelemetry instruments can't be read back. Always returns nil
+func (m *OtelMetrics) Get(string) *MetricResult {
+	return nil
+}
+
+func (m *OtelMetrics) UpdateIngressHostsPerStatusMetric(n types.NamespacedName, serviceHealth map[string]HealthStatus) {
+	count := map[HealthStatus]int{Healthy: 0, Unhealthy: 0, NotFound: 0}
+	for _, hs := range serviceHealth {
+		switch hs {
+		case Healthy, Unhealthy:
+			count[hs]++
+		default:
+			count[NotFound]++
+		}
+	}
+	for status, c := range count {
+		m.set(K8gbGslbServiceStatusNum, float64(c), namespacedName(n, attribute.String("status", status.String()))...)
+	}
+}
+
+func (m *OtelMetrics) UpdateHealthyRecordsMetric(n types.NamespacedName, healthyRecords map[string][]string) {
+	var hrsCount int
+	for _, hrs := range healthyRecords {
+		hrsCount += len(hrs)
+	}
+	m.set(K8gbGslbHealthyRecords, float64(hrsCount), namespacedName(n)...)
+}
+
+func (m *OtelMetrics) UpdateEndpointStatus(ep *externaldns.DNSEndpoint) {
+	for _, e := range ep.Spec.Endpoints {
+		m.set(K8gbEndpointStatusNum, float64(e.Targets.Len()), attribute.String("namespace", ep.Namespace),
+			attribute.String("name", ep.Name), attribute.String("dns_name", e.DNSName))
+	}
+}
+
+func (m *OtelMetrics) UpdateFailoverStatus(n types.NamespacedName, isPrimary bool, healthy HealthStatus, targets []string) {
+	t := secondary
+	if isPrimary {
+		t = primary
+	}
+	m.updateRuntimeStatus(n, K8gbGslbStatusCountForFailover, healthy, targets, "_"+t)
+}
+
+func (m *OtelMetrics) UpdateRoundrobinStatus(n types.NamespacedName, healthy HealthStatus, targets []string) {
+	m.updateRuntimeStatus(n, K8gbGslbStatusCountForRoundrobin, healthy, targets, "")
+}
+
+func (m *OtelMetrics) UpdateGeoIPStatus(n types.NamespacedName, healthy HealthStatus, targets []string) {
+	m.updateRuntimeStatus(n, K8gbGslbStatusCountForGeoIP, healthy, targets, "")
+}
+
+func (m *OtelMetrics) IncrementError(n types.NamespacedName) {
+	m.inc(K8gbGslbErrorsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) IncrementReconciliation(n types.NamespacedName) {
+	m.inc(K8gbGslbReconciliationLoopsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) InfobloxIncrementZoneUpdate(n types.NamespacedName) {
+	m.inc(K8gbInfobloxZoneUpdatesTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) InfobloxIncrementZoneUpdateError(n types.NamespacedName) {
+	m.inc(K8gbInfobloxZoneUpdateErrorsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) InfobloxIncrementHeartbeat(n types.NamespacedName) {
+	m.inc(K8gbInfobloxHeartbeatsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) InfobloxIncrementHeartbeatError(n types.NamespacedName) {
+	m.inc(K8gbInfobloxHeartbeatErrorsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) InfobloxObserveRequestDuration(start time.Time, request DNSProviderRequest, success bool) {
+	m.histograms[K8gbInfobloxRequestDuration].Record(context.Background(), time.Since(start).Seconds(),
+		attribute.String("request", string(request)), attribute.Bool("success", success))
+}
+
+func (m *OtelMetrics) IncrementZoneDelegation() {
+	m.inc(K8gbZoneDelegationLoopsTotal, attribute.String("zone", m.config.DNSZone))
+}
+
+func (m *OtelMetrics) IncrementZoneDelegationError() {
+	m.inc(K8gbZoneDelegationErrorsTotal, attribute.String("zone", m.config.DNSZone))
+}
+
+func (m *OtelMetrics) SetDryRunPlannedChanges(kind, name string, changes int) {
+	m.set(K8gbDryRunPlannedChanges, float64(changes), attribute.String("kind", kind), attribute.String("name", name))
+}
+
+func (m *OtelMetrics) IncrementConfigReload(n types.NamespacedName) {
+	m.inc(K8gbConfigReloadsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) IncrementConfigReloadError(n types.NamespacedName) {
+	m.inc(K8gbConfigReloadErrorsTotal, namespacedName(n)...)
+}
+
+func (m *OtelMetrics) SetRuntimeInfo(version, commit string) {
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	m.set(K8gbRuntimeInfo, 1, attribute.String("namespace", m.config.K8gbNamespace),
+		attribute.String("go_version", runtime.Version()), attribute.String("arch", runtime.GOARCH),
+		attribute.String("os", runtime.GOOS), attribute.String("k8gb_version", version), attribute.String("git_sha", commit))
+}
+
+// ObservePeerQuery observes duration of DNS query sent on behalf of peer cluster; errorType is empty for successful query
+func (m *OtelMetrics) ObservePeerQuery(geoTag string, query PeerQuery, start time.Time, errorType string) {
+	m.histograms[K8gbPeerQueryDuration].Record(context.Background(), time.Since(start).Seconds(),
+		attribute.String("geotag", geoTag), attribute.String("query", string(query)), attribute.Bool("success", errorType == ""))
+	if errorType != "" {
+		m.inc(K8gbPeerQueryErrorsTotal, attribute.String("geotag", geoTag), attribute.String("query", string(query)),
+			attribute.String("type", errorType))
+	}
+}
+
+func (m *OtelMetrics) SetPeerUp(geoTag string, up bool) {
+	var value float64
+	if up {
+		value = 1
+	}
+	m.set(K8gbPeerUp, value, attribute.String("geotag", geoTag))
+}
+
+func (m *OtelMetrics) SetPeerTargets(geoTag, host string, targets int) {
+	m.set(K8gbPeerTargets, float64(targets), attribute.String("geotag", geoTag), attribute.String("host", host))
+}
+
+func (m *OtelMetrics) SetPeerHeartbeatAge(geoTag string, age time.Duration) {
+	m.set(K8gbPeerHeartbeatAge, age.Seconds(), attribute.String("geotag", geoTag))
+}
+
+// DeleteIngress removes gauge series of the ingress and its hosts. Cumulative sums of OpenTelemetry counters
+// can't be removed, they stop growing instead
+func (m *OtelMetrics) DeleteIngress(n types.NamespacedName, hosts []string) {
+	for _, name := range []string{K8gbGslbHealthyRecords, K8gbGslbServiceStatusNum, K8gbGslbStatusCountForFailover,
+		K8gbGslbStatusCountForRoundrobin, K8gbGslbStatusCountForGeoIP, K8gbEndpointStatusNum} {
+		m.values.deletePartialMatch(name, namespacedName(n)...)
+	}
+	m.values.deletePartialMatch(K8gbDryRunPlannedChanges, attribute.String("name", n.String()))
+	for _, host := range hosts {
+		m.DeleteHost(n, host)
+	}
+}
+
+func (m *OtelMetrics) DeleteHost(n types.NamespacedName, host string) {
+	for _, dnsName := range []string{host, localTargetsPrefix + host} {
+		m.values.deletePartialMatch(K8gbEndpointStatusNum, namespacedName(n, attribute.String("dns_name", dnsName))...)
+	}
+	m.values.deletePartialMatch(K8gbPeerTargets, attribute.String("host", host))
+}
+
+// Register registers callback reporting the last values of gauges. The registration happens only once
+func (m *OtelMetrics) Register() (err error) {
+	m.once.Do(func() {
+		instruments := make([]instrument.Asynchronous, 0, len(m.gauges))
+		for _, g := range m.gauges {
+			instruments = append(instruments, g)
+		}
+		err = m.meter.RegisterCallback(instruments, func(ctx context.Context) {
+			m.values.observe(func(name string, value float64, attrs []attribute.KeyValue) {
+				m.gauges[name].Observe(ctx, value, attrs...)
+			})
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("can't register OpenTelemetry metrics: %w", err)
+	}
+	return
+}
+
+// Unregister pushes pending metrics and stops the meter provider
+func (m *OtelMetrics) Unregister() {
+	_ = m.provider.Shutdown(context.Background())
+}
+
+func (m *OtelMetrics) inc(name string, attrs ...attribute.KeyValue) {
+	m.counters[name].Add(context.Background(), 1, attrs...)
+}
+
+func (m *OtelMetrics) set(name string, value float64, attrs ...attribute.KeyValue) {
+	m.values.set(name, value, attrs...)
+}
+
+func (m *OtelMetrics) updateRuntimeStatus(n types.NamespacedName, name string, healthStatus HealthStatus, targets []string, tag string) {
+	count := map[HealthStatus]int{Healthy: 0, Unhealthy: 0, NotFound: 0}
+	if _, found := count[healthStatus]; found {
+		count[healthStatus] = len(targets)
+	}
+	for status, c := range count {
+		m.set(name, float64(c), namespacedName(n, attribute.String("status", fmt.Sprintf("%s%s", status, tag)))...)
+	}
+}
+
+func namespacedName(n types.NamespacedName, attrs ...attribute.KeyValue) []attribute.KeyValue {
+	return append([]attribute.KeyValue{attribute.String("namespace", n.Namespace), attribute.String("name", n.Name)}, attrs...)
+}
+
+// gaugeValues keeps the last value of every gauge series. OpenTelemetry gauges are asynchronous,
+// the values are reported when the reader collects metrics
+type gaugeValues struct {
+	sync.Mutex
+	series map[string]map[attribute.Distinct]gaugeValue
+}
+
+type gaugeValue struct {
+	attrs attribute.Set
+	value float64
+}
+
+func newGaugeValues() *gaugeValues {
+	return &gaugeValues{series: map[string]map[attribute.Distinct]gaugeValue{}}
+}
+
+func (g *gaugeValues) set(name string, value float64, attrs ...attribute.KeyValue) {
+	set := attribute.NewSet(attrs...)
+	g.Lock()
+	defer g.Unlock()
+	if g.series[name] == nil {
+		g.series[name] = map[attribute.Distinct]gaugeValue{}
+	}
+	g.series[name][set.Equivalent()] = gaugeValue{attrs: set, value: value}
+}
+
+// deletePartialMatch deletes series of gauge whose attributes contain all of attrs
+func (g *gaugeValues) deletePartialMatch(name string, attrs ...attribute.KeyValue) {
+	g.Lock()
+	defer g.Unlock()
+	for key, v := range g.series[name] {
+		matches := true
+		for _, a := range attrs {
+			if value, found := v.attrs.Value(a.Key); !found || value != a.Value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			delete(g.series[name], key)
+		}
+	}
+}
+
+func (g *gaugeValues) observe(f func(name string, value float64, attrs []attribute.KeyValue)) {
+	g.Lock()
+	defer g.Unlock()
+	for name, series := range g.series {
+		for _, v := range series {
+			f(name, v.value, v.attrs.ToSlice())
+		}
+	}
+}
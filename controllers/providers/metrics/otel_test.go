@@ -0,0 +1,170 @@
+package metrics
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestOtelMetrics(t *testing.T) (*OtelMetrics, sdkmetric.Reader) {
+	reader := sdkmetric.NewManualReader()
+	m, err := newOtelMetrics(defaultConfig, reader)
+	require.NoError(t, err)
+	require.NoError(t, m.Register())
+	return m, reader
+}
+
+// collect returns aggregation of instrument by name
+func collect(t *testing.T, reader sdkmetric.Reader, name string) metricdata.Aggregation {
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m.Data
+			}
+		}
+	}
+	return nil
+}
+
+func collectGauge(t *testing.T, reader sdkmetric.Reader, name string, attrs ...attribute.KeyValue) (float64, bool) {
+	g, ok := collect(t, reader, name).(metricdata.Gauge[float64])
+	require.True(t, ok, "%s is not a gauge", name)
+	set := attribute.NewSet(attrs...)
+	for _, dp := range g.DataPoints {
+		if dp.Attributes.Equals(&set) {
+			return dp.Value, true
+		}
+	}
+	return 0, false
+}
+
+func TestOtelMetricsImplementsMetrics(t *testing.T) {
+	// arrange
+	m, _ := newTestOtelMetrics(t)
+	// act
+	// assert
+	var _ Metrics = m
+	assert.Len(t, otelInstruments, len(Prometheus().registry()), "OpenTelemetry instruments must follow prometheus collectors")
+	for name := range Prometheus().registry() {
+		found := m.counters[name] != nil || m.histograms[name] != nil || m.gauges[name] != nil
+		assert.True(t, found, "%s is missing in OpenTelemetry instruments", name)
+	}
+}
+
+func TestOtelCounter(t *testing.T) {
+	// arrange
+	m, reader := newTestOtelMetrics(t)
+	n := types.NamespacedName{Namespace: namespace, Name: gslbName}
+	// act
+	m.IncrementReconciliation(n)
+	m.IncrementReconciliation(n)
+	// assert
+	sum, ok := collect(t, reader, K8gbGslbReconciliationLoopsTotal).(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, sum.DataPoints, 1)
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, int64(2), sum.DataPoints[0].Value)
+}
+
+func TestOtelHistogram(t *testing.T) {
+	// arrange
+	m, reader := newTestOtelMetrics(t)
+	// act
+	m.ObservePeerQuery("za", PeerQueryTargets, time.Now(), "")
+	m.ObservePeerQuery("za", PeerQueryTargets, time.Now(), PeerErrorServfail)
+	// assert
+	h, ok := collect(t, reader, K8gbPeerQueryDuration).(metricdata.Histogram)
+	require.True(t, ok)
+	require.Len(t, h.DataPoints, 2)
+	assert.Equal(t, peerQueryDurationBuckets, h.DataPoints[0].Bounds)
+	errs, ok := collect(t, reader, K8gbPeerQueryErrorsTotal).(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, errs.DataPoints, 1)
+	typ, _ := errs.DataPoints[0].Attributes.Value("type")
+	assert.Equal(t, PeerErrorServfail, typ.AsString())
+}
+
+func TestOtelGauge(t *testing.T) {
+	// arrange
+	m, reader := newTestOtelMetrics(t)
+	n := types.NamespacedName{Namespace: namespace, Name: gslbName}
+	// act
+	m.SetPeerUp("za", true)
+	m.SetPeerUp("za", false)
+	m.UpdateFailoverStatus(n, true, Healthy, []string{"10.0.0.1", "10.0.0.2"})
+	// assert
+	up, found := collectGauge(t, reader, K8gbPeerUp, attribute.String("geotag", "za"))
+	assert.True(t, found)
+	assert.Equal(t, 0., up)
+	healthy, found := collectGauge(t, reader, K8gbGslbStatusCountForFailover, attribute.String("namespace", namespace),
+		attribute.String("name", gslbName), attribute.String("status", "Healthy_primary"))
+	assert.True(t, found)
+	assert.Equal(t, 2., healthy)
+	unhealthy, found := collectGauge(t, reader, K8gbGslbStatusCountForFailover, attribute.String("namespace", namespace),
+		attribute.String("name", gslbName), attribute.String("status", "Unhealthy_primary"))
+	assert.True(t, found)
+	assert.Equal(t, 0., unhealthy)
+}
+
+func TestNewOtlpMetrics(t *testing.T) {
+	// arrange
+	config := defaultConfig
+	config.MetricsExporter = depresolver.MetricsExporterOtlp
+	config.MetricsExportIntervalSeconds = 30
+	config.OtelExporterOtlpEndpoint = "localhost:4318"
+	// act
+	m, err := NewMetrics(context.Background(), &config)
+	// assert
+	assert.NoError(t, err)
+	assert.IsType(t, &OtelMetrics{}, m)
+	assert.NoError(t, m.Register())
+	m.Unregister()
+}
+
+func TestOtelDeleteIngress(t *testing.T) {
+	// arrange
+	m, reader := newTestOtelMetrics(t)
+	n := types.NamespacedName{Namespace: namespace, Name: gslbName}
+	other := types.NamespacedName{Namespace: namespace, Name: "other"}
+	m.UpdateHealthyRecordsMetric(n, map[string][]string{"app.cloud.example.com": {"10.0.0.1"}})
+	m.UpdateHealthyRecordsMetric(other, map[string][]string{"other.cloud.example.com": {"10.0.0.1"}})
+	m.SetPeerTargets("za", "app.cloud.example.com", 2)
+	// act
+	m.DeleteIngress(n, []string{"app.cloud.example.com"})
+	// assert
+	_, found := collectGauge(t, reader, K8gbGslbHealthyRecords, namespacedName(n)...)
+	assert.False(t, found)
+	_, found = collectGauge(t, reader, K8gbGslbHealthyRecords, namespacedName(other)...)
+	assert.True(t, found)
+	_, found = collectGauge(t, reader, K8gbPeerTargets, attribute.String("geotag", "za"), attribute.String("host", "app.cloud.example.com"))
+	assert.False(t, found)
+}
@@ -39,6 +39,8 @@ import (
 const (
 	primary   = "primary"
 	secondary = "secondary"
+	// localTargetsPrefix prefixes DNS names of cluster local targets in DNSEndpoint
+	localTargetsPrefix = "localtargets-"
 )
 
 // collectors contains list of metrics.
@@ -57,6 +59,16 @@ type collectors struct {
 	K8gbInfobloxHeartbeatErrorsTotal  *prometheus.CounterVec
 	K8gbEndpointStatusNum             *prometheus.GaugeVec
 	K8gbRuntimeInfo                   *prometheus.GaugeVec
+	K8gbZoneDelegationLoopsTotal      *prometheus.CounterVec
+	K8gbZoneDelegationErrorsTotal     *prometheus.CounterVec
+	K8gbDryRunPlannedChanges          *prometheus.GaugeVec
+	K8gbConfigReloadsTotal            *prometheus.CounterVec
+	K8gbConfigReloadErrorsTotal       *prometheus.CounterVec
+	K8gbPeerQueryDuration             *prometheus.HistogramVec
+	K8gbPeerQueryErrorsTotal          *prometheus.CounterVec
+	K8gbPeerTargets                   *prometheus.GaugeVec
+	K8gbPeerUp                        *prometheus.GaugeVec
+	K8gbPeerHeartbeatAge              *prometheus.GaugeVec
 }
 
 type PrometheusMetrics struct {
@@ -80,8 +92,35 @@ const (
 	DeleteTXTRecord = "TXTRecordDelete"
 )
 
+// PeerQuery is a label of DNS queries sent on behalf of peer cluster
+type PeerQuery string
+
+const (
+	// PeerQueryGlue resolves NS name of peer cluster through edge DNS
+	PeerQueryGlue PeerQuery = "glue"
+	// PeerQueryTargets resolves localtargets- record from peer cluster nameserver
+	PeerQueryTargets PeerQuery = "targets"
+	// PeerQueryHeartbeat resolves heartbeat TXT record of peer cluster through edge DNS
+	PeerQueryHeartbeat PeerQuery = "heartbeat"
+)
+
+// Types of failed peer queries
+const (
+	PeerErrorTimeout  = "timeout"
+	PeerErrorNetwork  = "network"
+	PeerErrorServfail = "servfail"
+	PeerErrorNXDomain = "nxdomain"
+	PeerErrorRefused  = "refused"
+	PeerErrorOther    = "other"
+)
+
 var regex = regexp.MustCompile("[A-Z]")
 
+var (
+	infobloxRequestDurationBuckets = prometheus.ExponentialBuckets(.2, 4, 5)
+	peerQueryDurationBuckets       = prometheus.ExponentialBuckets(.005, 3, 7)
+)
+
 // newPrometheusMetrics creates new prometheus metrics instance
 func newPrometheusMetrics(config depresolver.Config) (metrics *PrometheusMetrics) {
 	metrics = new(PrometheusMetrics)
@@ -193,6 +232,26 @@ func (m *PrometheusMetrics) InfobloxObserveRequestDuration(start time.Time, requ
 	m.metrics.K8gbInfobloxRequestDuration.With(prometheus.Labels{"request": string(request), "success": fmt.Sprintf("%t", success)}).Observe(duration)
 }
 
+func (m *PrometheusMetrics) IncrementZoneDelegation() {
+	m.metrics.K8gbZoneDelegationLoopsTotal.With(prometheus.Labels{"zone": m.config.DNSZone}).Inc()
+}
+
+func (m *PrometheusMetrics) IncrementZoneDelegationError() {
+	m.metrics.K8gbZoneDelegationErrorsTotal.With(prometheus.Labels{"zone": m.config.DNSZone}).Inc()
+}
+
+func (m *PrometheusMetrics) SetDryRunPlannedChanges(kind, name string, changes int) {
+	m.metrics.K8gbDryRunPlannedChanges.With(prometheus.Labels{"kind": kind, "name": name}).Set(float64(changes))
+}
+
+func (m *PrometheusMetrics) IncrementConfigReload(n types.NamespacedName) {
+	m.metrics.K8gbConfigReloadsTotal.With(prometheus.Labels{"namespace": n.Namespace, "name": n.Name}).Inc()
+}
+
+func (m *PrometheusMetrics) IncrementConfigReloadError(n types.NamespacedName) {
+	m.metrics.K8gbConfigReloadErrorsTotal.With(prometheus.Labels{"namespace": n.Namespace, "name": n.Name}).Inc()
+}
+
 func (m *PrometheusMetrics) SetRuntimeInfo(version, commit string) {
 	firstN := func(value string, n int) string {
 		if len(value) < n {
@@ -206,6 +265,54 @@ func (m *PrometheusMetrics) SetRuntimeInfo(version, commit string) {
 			"os": runtime.GOOS, "k8gb_version": version, "git_sha": firstN(commit, 7)}).Set(1)
 }
 
+// ObservePeerQuery observes duration of DNS query sent on behalf of peer cluster; errorType is empty for successful query
+func (m *PrometheusMetrics) ObservePeerQuery(geoTag string, query PeerQuery, start time.Time, errorType string) {
+	duration := time.Since(start).Seconds()
+	m.metrics.K8gbPeerQueryDuration.With(prometheus.Labels{"geotag": geoTag, "query": string(query),
+		"success": fmt.Sprintf("%t", errorType == "")}).Observe(duration)
+	if errorType != "" {
+		m.metrics.K8gbPeerQueryErrorsTotal.With(prometheus.Labels{"geotag": geoTag, "query": string(query), "type": errorType}).Inc()
+	}
+}
+
+func (m *PrometheusMetrics) SetPeerUp(geoTag string, up bool) {
+	var value float64
+	if up {
+		value = 1
+	}
+	m.metrics.K8gbPeerUp.With(prometheus.Labels{"geotag": geoTag}).Set(value)
+}
+
+func (m *PrometheusMetrics) SetPeerTargets(geoTag, host string, targets int) {
+	m.metrics.K8gbPeerTargets.With(prometheus.Labels{"geotag": geoTag, "host": host}).Set(float64(targets))
+}
+
+func (m *PrometheusMetrics) SetPeerHeartbeatAge(geoTag string, age time.Duration) {
+	m.metrics.K8gbPeerHeartbeatAge.With(prometheus.Labels{"geotag": geoTag}).Set(age.Seconds())
+}
+
+func (m *PrometheusMetrics) DeleteIngress(n types.NamespacedName, hosts []string) {
+	l := prometheus.Labels{"namespace": n.Namespace, "name": n.Name}
+	for _, vec := range []interface{ DeletePartialMatch(prometheus.Labels) int }{
+		m.metrics.K8gbGslbHealthyRecords, m.metrics.K8gbGslbServiceStatusNum, m.metrics.K8gbGslbStatusCountForFailover,
+		m.metrics.K8gbGslbStatusCountForRoundrobin, m.metrics.K8gbGslbStatusCountForGeoip, m.metrics.K8gbGslbErrorsTotal,
+		m.metrics.K8gbGslbReconciliationLoopsTotal, m.metrics.K8gbEndpointStatusNum,
+	} {
+		vec.DeletePartialMatch(l)
+	}
+	m.metrics.K8gbDryRunPlannedChanges.DeletePartialMatch(prometheus.Labels{"name": n.String()})
+	for _, host := range hosts {
+		m.DeleteHost(n, host)
+	}
+}
+
+func (m *PrometheusMetrics) DeleteHost(n types.NamespacedName, host string) {
+	for _, dnsName := range []string{host, localTargetsPrefix + host} {
+		m.metrics.K8gbEndpointStatusNum.DeletePartialMatch(prometheus.Labels{"namespace": n.Namespace, "name": n.Name, "dns_name": dnsName})
+	}
+	m.metrics.K8gbPeerTargets.DeletePartialMatch(prometheus.Labels{"host": host})
+}
+
 // Register prometheus metrics. Read register documentation, but shortly:
 // You can register metric with given name only once
 func (m *PrometheusMetrics) Register() (err error) {
@@ -305,7 +412,7 @@ func (m *PrometheusMetrics) init() {
 		prometheus.HistogramOpts{
 			Name:    K8gbInfobloxRequestDuration,
 			Help:    "How long it took for Infoblox requests to complete, partitioned by request type. Round-trip time of http communication is included.",
-			Buckets: prometheus.ExponentialBuckets(.2, 4, 5),
+			Buckets: infobloxRequestDurationBuckets,
 		},
 		[]string{"request", "success"},
 	)
@@ -339,6 +446,77 @@ func (m *PrometheusMetrics) init() {
 		},
 		[]string{"namespace", "name"},
 	)
+	m.metrics.K8gbZoneDelegationLoopsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: K8gbZoneDelegationLoopsTotal,
+			Help: "Number of successful zone delegation loops.",
+		},
+		[]string{"zone"},
+	)
+	m.metrics.K8gbZoneDelegationErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: K8gbZoneDelegationErrorsTotal,
+			Help: "Number of zone delegation loop errors.",
+		},
+		[]string{"zone"},
+	)
+	m.metrics.K8gbDryRunPlannedChanges = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: K8gbDryRunPlannedChanges,
+			Help: "Number of changes planned by dry run which were not applied.",
+		},
+		[]string{"kind", "name"},
+	)
+	m.metrics.K8gbConfigReloadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: K8gbConfigReloadsTotal,
+			Help: "Number of successfully applied configuration reloads.",
+		},
+		[]string{"namespace", "name"},
+	)
+	m.metrics.K8gbConfigReloadErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: K8gbConfigReloadErrorsTotal,
+			Help: "Number of rejected configuration reloads.",
+		},
+		[]string{"namespace", "name"},
+	)
+	m.metrics.K8gbPeerQueryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    K8gbPeerQueryDuration,
+			Help:    "How long it took for DNS queries sent on behalf of peer clusters to complete in seconds, partitioned by peer and query.",
+			Buckets: peerQueryDurationBuckets,
+		},
+		[]string{"geotag", "query", "success"},
+	)
+	m.metrics.K8gbPeerQueryErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: K8gbPeerQueryErrorsTotal,
+			Help: "Number of failed DNS queries sent on behalf of peer clusters, partitioned by peer, query and error type.",
+		},
+		[]string{"geotag", "query", "type"},
+	)
+	m.metrics.K8gbPeerTargets = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: K8gbPeerTargets,
+			Help: "Number of targets of host returned by peer cluster.",
+		},
+		[]string{"geotag", "host"},
+	)
+	m.metrics.K8gbPeerUp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: K8gbPeerUp,
+			Help: "Whether nameserver of peer cluster answered the last query for targets (1) or not (0).",
+		},
+		[]string{"geotag"},
+	)
+	m.metrics.K8gbPeerHeartbeatAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: K8gbPeerHeartbeatAge,
+			Help: "Age of the last heartbeat TXT record of peer cluster in seconds.",
+		},
+		[]string{"geotag"},
+	)
 }
 
 // registry is helper function reading fields from m.metrics structure and builds metrics map
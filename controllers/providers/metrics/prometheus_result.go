@@ -39,3 +39,7 @@ func (c *MetricResult) AsGaugeVec() *prometheus.GaugeVec {
 func (c *MetricResult) AsCounterVec() *prometheus.CounterVec {
 	return c.value.(*prometheus.CounterVec)
 }
+
+func (c *MetricResult) AsHistogramVec() *prometheus.HistogramVec {
+	return c.value.(*prometheus.HistogramVec)
+}
@@ -24,6 +24,7 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
 
@@ -77,7 +78,9 @@ func TestPrometheusRegistry(t *testing.T) {
 		K8gbGslbServiceStatusNum, K8gbGslbStatusCountForFailover, K8gbGslbStatusCountForRoundrobin,
 		K8gbGslbStatusCountForGeoIP, K8gbInfobloxHeartbeatsTotal, K8gbInfobloxHeartbeatErrorsTotal,
 		K8gbInfobloxRequestDuration, K8gbInfobloxZoneUpdatesTotal, K8gbInfobloxZoneUpdateErrorsTotal,
-		K8gbEndpointStatusNum, K8gbRuntimeInfo}
+		K8gbEndpointStatusNum, K8gbRuntimeInfo, K8gbZoneDelegationLoopsTotal, K8gbZoneDelegationErrorsTotal,
+		K8gbDryRunPlannedChanges, K8gbConfigReloadsTotal, K8gbConfigReloadErrorsTotal, K8gbPeerQueryDuration,
+		K8gbPeerQueryErrorsTotal, K8gbPeerTargets, K8gbPeerUp, K8gbPeerHeartbeatAge}
 	// act
 	registry := m.registry()
 	// assert
@@ -202,6 +205,162 @@ func TestInfobloxZoneUpdateIncrement(t *testing.T) {
 	assert.Equal(t, cnt1+1.0, cnt2)
 }
 
+func TestZoneDelegationIncrement(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	cnt1 := testutil.ToFloat64(m.Get(K8gbZoneDelegationLoopsTotal).AsCounterVec().
+		With(prometheus.Labels{"zone": defaultConfig.DNSZone}))
+	// act
+	m.IncrementZoneDelegation()
+	// assert
+	cnt2 := testutil.ToFloat64(m.Get(K8gbZoneDelegationLoopsTotal).AsCounterVec().
+		With(prometheus.Labels{"zone": defaultConfig.DNSZone}))
+	assert.Equal(t, cnt1+1.0, cnt2)
+}
+
+func TestZoneDelegationErrorIncrement(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	cnt1 := testutil.ToFloat64(m.Get(K8gbZoneDelegationErrorsTotal).AsCounterVec().
+		With(prometheus.Labels{"zone": defaultConfig.DNSZone}))
+	// act
+	m.IncrementZoneDelegationError()
+	// assert
+	cnt2 := testutil.ToFloat64(m.Get(K8gbZoneDelegationErrorsTotal).AsCounterVec().
+		With(prometheus.Labels{"zone": defaultConfig.DNSZone}))
+	assert.Equal(t, cnt1+1.0, cnt2)
+}
+
+func TestConfigReloadIncrement(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	n := types.NamespacedName{Namespace: "k8gb", Name: "k8gb-config"}
+	cnt1 := testutil.ToFloat64(m.Get(K8gbConfigReloadsTotal).AsCounterVec().
+		With(prometheus.Labels{"namespace": n.Namespace, "name": n.Name}))
+	// act
+	m.IncrementConfigReload(n)
+	// assert
+	cnt2 := testutil.ToFloat64(m.Get(K8gbConfigReloadsTotal).AsCounterVec().
+		With(prometheus.Labels{"namespace": n.Namespace, "name": n.Name}))
+	assert.Equal(t, cnt1+1.0, cnt2)
+}
+
+func TestConfigReloadErrorIncrement(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	n := types.NamespacedName{Namespace: "k8gb", Name: "k8gb-config"}
+	cnt1 := testutil.ToFloat64(m.Get(K8gbConfigReloadErrorsTotal).AsCounterVec().
+		With(prometheus.Labels{"namespace": n.Namespace, "name": n.Name}))
+	// act
+	m.IncrementConfigReloadError(n)
+	// assert
+	cnt2 := testutil.ToFloat64(m.Get(K8gbConfigReloadErrorsTotal).AsCounterVec().
+		With(prometheus.Labels{"namespace": n.Namespace, "name": n.Name}))
+	assert.Equal(t, cnt1+1.0, cnt2)
+}
+
+func TestSetDryRunPlannedChanges(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	l := prometheus.Labels{"kind": "DNSEndpoint", "name": "test-namespace/test-gslb"}
+	// act
+	m.SetDryRunPlannedChanges("DNSEndpoint", "test-namespace/test-gslb", 3)
+	// assert
+	assert.Equal(t, 3., testutil.ToFloat64(m.Get(K8gbDryRunPlannedChanges).AsGaugeVec().With(l)))
+	// act
+	m.SetDryRunPlannedChanges("DNSEndpoint", "test-namespace/test-gslb", 0)
+	// assert
+	assert.Equal(t, 0., testutil.ToFloat64(m.Get(K8gbDryRunPlannedChanges).AsGaugeVec().With(l)))
+}
+
+func TestObservePeerQuery(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	errLabels := prometheus.Labels{"geotag": "za", "query": string(PeerQueryTargets), "type": PeerErrorTimeout}
+	// act
+	m.ObservePeerQuery("za", PeerQueryTargets, time.Now(), "")
+	m.ObservePeerQuery("za", PeerQueryTargets, time.Now(), PeerErrorTimeout)
+	m.ObservePeerQuery("za", PeerQueryTargets, time.Now(), PeerErrorTimeout)
+	// assert
+	assert.Equal(t, 2, testutil.CollectAndCount(m.Get(K8gbPeerQueryDuration).AsHistogramVec()))
+	assert.Equal(t, 2., testutil.ToFloat64(m.Get(K8gbPeerQueryErrorsTotal).AsCounterVec().With(errLabels)))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.Get(K8gbPeerQueryErrorsTotal).AsCounterVec()))
+}
+
+func TestSetPeerUp(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	l := prometheus.Labels{"geotag": "za"}
+	// act
+	m.SetPeerUp("za", true)
+	// assert
+	assert.Equal(t, 1., testutil.ToFloat64(m.Get(K8gbPeerUp).AsGaugeVec().With(l)))
+	// act
+	m.SetPeerUp("za", false)
+	// assert
+	assert.Equal(t, 0., testutil.ToFloat64(m.Get(K8gbPeerUp).AsGaugeVec().With(l)))
+}
+
+func TestSetPeerTargetsAndHeartbeatAge(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	// act
+	m.SetPeerTargets("za", "roundrobin.cloud.example.com", 3)
+	m.SetPeerHeartbeatAge("za", 90*time.Second)
+	// assert
+	assert.Equal(t, 3., testutil.ToFloat64(m.Get(K8gbPeerTargets).AsGaugeVec().
+		With(prometheus.Labels{"geotag": "za", "host": "roundrobin.cloud.example.com"})))
+	assert.Equal(t, 90., testutil.ToFloat64(m.Get(K8gbPeerHeartbeatAge).AsGaugeVec().With(prometheus.Labels{"geotag": "za"})))
+}
+
+func TestDeleteIngress(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	other := types.NamespacedName{Namespace: namespace, Name: "other"}
+	ep := &externaldns.DNSEndpoint{}
+	ep.Namespace, ep.Name = namespace, gslbName
+	ep.Spec.Endpoints = []*externaldns.Endpoint{
+		{DNSName: "app.cloud.example.com", Targets: externaldns.Targets{"10.0.0.1"}},
+		{DNSName: "localtargets-app.cloud.example.com", Targets: externaldns.Targets{"10.0.0.1"}},
+	}
+	m.UpdateIngressHostsPerStatusMetric(NamespacedName, map[string]HealthStatus{"app.cloud.example.com": Healthy})
+	m.UpdateIngressHostsPerStatusMetric(other, map[string]HealthStatus{"other.cloud.example.com": Healthy})
+	m.UpdateFailoverStatus(NamespacedName, true, Healthy, []string{"10.0.0.1"})
+	m.UpdateEndpointStatus(ep)
+	m.IncrementReconciliation(NamespacedName)
+	m.SetDryRunPlannedChanges("DNSEndpoint", NamespacedName.String(), 1)
+	m.SetPeerTargets("za", "app.cloud.example.com", 2)
+	// act
+	m.DeleteIngress(NamespacedName, []string{"app.cloud.example.com"})
+	// assert
+	assert.Equal(t, 3, testutil.CollectAndCount(m.Get(K8gbGslbServiceStatusNum).AsGaugeVec()), "series of other ingress must stay")
+	assert.Equal(t, 0, testutil.CollectAndCount(m.Get(K8gbGslbStatusCountForFailover).AsGaugeVec()))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.Get(K8gbEndpointStatusNum).AsGaugeVec()))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.Get(K8gbGslbReconciliationLoopsTotal).AsCounterVec()))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.Get(K8gbDryRunPlannedChanges).AsGaugeVec()))
+	assert.Equal(t, 0, testutil.CollectAndCount(m.Get(K8gbPeerTargets).AsGaugeVec()))
+}
+
+func TestDeleteHost(t *testing.T) {
+	// arrange
+	m := newPrometheusMetrics(defaultConfig)
+	ep := &externaldns.DNSEndpoint{}
+	ep.Namespace, ep.Name = namespace, gslbName
+	ep.Spec.Endpoints = []*externaldns.Endpoint{
+		{DNSName: "app.cloud.example.com", Targets: externaldns.Targets{"10.0.0.1"}},
+		{DNSName: "localtargets-app.cloud.example.com", Targets: externaldns.Targets{"10.0.0.1"}},
+		{DNSName: "web.cloud.example.com", Targets: externaldns.Targets{"10.0.0.1"}},
+	}
+	m.UpdateEndpointStatus(ep)
+	m.SetPeerTargets("za", "app.cloud.example.com", 2)
+	m.SetPeerTargets("za", "web.cloud.example.com", 2)
+	// act
+	m.DeleteHost(NamespacedName, "app.cloud.example.com")
+	// assert
+	assert.Equal(t, 1, testutil.CollectAndCount(m.Get(K8gbEndpointStatusNum).AsGaugeVec()))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.Get(K8gbPeerTargets).AsGaugeVec()))
+}
+
 func TestUpgradeIngressHost(t *testing.T) {
 	// arrange
 	m := newPrometheusMetrics(defaultConfig)
@@ -19,8 +19,11 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"time"
 
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -40,6 +43,16 @@ const (
 	K8gbInfobloxZoneUpdateErrorsTotal = "k8gb_infoblox_zone_update_errors_total"
 	K8gbEndpointStatusNum             = "k8gb_endpoint_status_num"
 	K8gbRuntimeInfo                   = "k8gb_runtime_info"
+	K8gbZoneDelegationLoopsTotal      = "k8gb_zone_delegation_loops_total"
+	K8gbZoneDelegationErrorsTotal     = "k8gb_zone_delegation_errors_total"
+	K8gbDryRunPlannedChanges          = "k8gb_dry_run_planned_changes"
+	K8gbConfigReloadsTotal            = "k8gb_config_reloads_total"
+	K8gbConfigReloadErrorsTotal       = "k8gb_config_reload_errors_total"
+	K8gbPeerQueryDuration             = "k8gb_peer_query_duration"
+	K8gbPeerQueryErrorsTotal          = "k8gb_peer_query_errors_total"
+	K8gbPeerTargets                   = "k8gb_peer_targets"
+	K8gbPeerUp                        = "k8gb_peer_up"
+	K8gbPeerHeartbeatAge              = "k8gb_peer_heartbeat_age"
 )
 
 type Metrics interface {
@@ -65,7 +78,33 @@ type Metrics interface {
 	InfobloxIncrementHeartbeat(n types.NamespacedName)
 	InfobloxIncrementHeartbeatError(n types.NamespacedName)
 	InfobloxObserveRequestDuration(start time.Time, request DNSProviderRequest, success bool)
+	IncrementZoneDelegation()
+	IncrementZoneDelegationError()
+	SetDryRunPlannedChanges(kind, name string, changes int)
+	IncrementConfigReload(n types.NamespacedName)
+	IncrementConfigReloadError(n types.NamespacedName)
 	SetRuntimeInfo(version, commit string)
+	ObservePeerQuery(geoTag string, query PeerQuery, start time.Time, errorType string)
+	SetPeerUp(geoTag string, up bool)
+	SetPeerTargets(geoTag, host string, targets int)
+	SetPeerHeartbeatAge(geoTag string, age time.Duration)
+	// DeleteIngress removes series of the ingress and its hosts when the ingress leaves GSLB management
+	DeleteIngress(n types.NamespacedName, hosts []string)
+	// DeleteHost removes series of the host when it is removed from the ingress
+	DeleteHost(n types.NamespacedName, host string)
 	Register() (err error)
 	Unregister()
 }
+
+// NewMetrics returns metrics implementation selected by MetricsExporter
+func NewMetrics(ctx context.Context, config *depresolver.Config) (Metrics, error) {
+	if config.MetricsExporter != depresolver.MetricsExporterOtlp {
+		Init(config)
+		return Prometheus(), nil
+	}
+	m, err := NewOtelMetrics(ctx, *config)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
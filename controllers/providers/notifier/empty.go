@@ -0,0 +1,38 @@
+package notifier
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import "context"
+
+// EmptyNotifier drops all notifications, it is used when no endpoint is configured
+type EmptyNotifier struct{}
+
+func NewEmptyNotifier() *EmptyNotifier {
+	return &EmptyNotifier{}
+}
+
+func (n *EmptyNotifier) Notify(Notification) {}
+
+func (n *EmptyNotifier) Start(context.Context) error {
+	return nil
+}
+
+func (n *EmptyNotifier) String() string {
+	return "EMPTY"
+}
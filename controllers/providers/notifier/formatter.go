@@ -0,0 +1,56 @@
+package notifier
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"encoding/json"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+)
+
+// Formatter builds request body of notification, so notifications can target generic webhooks as well as chat tools
+type Formatter interface {
+	// Format returns request body and its content type
+	Format(Notification) (body []byte, contentType string, err error)
+}
+
+// JSONFormatter posts the notification as JSON document
+type JSONFormatter struct{}
+
+func (f JSONFormatter) Format(n Notification) ([]byte, string, error) {
+	body, err := json.Marshal(n)
+	return body, "application/json", err
+}
+
+// SlackFormatter posts the notification as Slack compatible message, which is accepted also by Mattermost
+// and Rocket.Chat incoming webhooks
+type SlackFormatter struct{}
+
+func (f SlackFormatter) Format(n Notification) ([]byte, string, error) {
+	body, err := json.Marshal(map[string]string{"text": n.Message()})
+	return body, "application/json", err
+}
+
+// NewFormatter returns formatter for NOTIFIER_FORMAT value; JSONFormatter is used for unknown formats
+func NewFormatter(format string) Formatter {
+	if format == depresolver.NotifierSlackFormat {
+		return SlackFormatter{}
+	}
+	return JSONFormatter{}
+}
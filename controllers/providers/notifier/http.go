@@ -0,0 +1,195 @@
+package notifier
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/rs/zerolog"
+)
+
+// SignatureHeader carries HMAC-SHA256 of the request body, signed by NOTIFIER_SECRET
+const SignatureHeader = "X-K8gb-Signature"
+
+// queueSize is the number of notifications waiting for delivery to single endpoint. Further notifications
+// are dropped until the endpoint catches up
+const queueSize = 100
+
+// message is a formatted notification waiting in the queue of endpoint
+type message struct {
+	notification Notification
+	body         []byte
+	contentType  string
+}
+
+// HTTPNotifier posts notifications to configured HTTP endpoints
+type HTTPNotifier struct {
+	endpoints []string
+	queues    map[string]chan message
+	secret    string
+	retries   int
+	timeout   time.Duration
+	backoff   time.Duration
+	formatter Formatter
+	client    *http.Client
+	log       *zerolog.Logger
+}
+
+func NewHTTPNotifier(config *depresolver.Config, formatter Formatter, log *zerolog.Logger) *HTTPNotifier {
+	queues := make(map[string]chan message, len(config.Notifier.Endpoints))
+	for _, endpoint := range config.Notifier.Endpoints {
+		queues[endpoint] = make(chan message, queueSize)
+	}
+	return &HTTPNotifier{
+		endpoints: config.Notifier.Endpoints,
+		queues:    queues,
+		secret:    config.Notifier.Secret,
+		retries:   config.Notifier.Retries,
+		timeout:   time.Duration(config.Notifier.TimeoutSeconds) * time.Second,
+		backoff:   time.Second,
+		formatter: formatter,
+		client:    &http.Client{},
+		log:       log,
+	}
+}
+
+// Notify queues notification for all endpoints, so the reconciliation is never blocked by slow or unavailable
+// receivers. The notification is dropped for endpoints with full queue
+func (n *HTTPNotifier) Notify(notification Notification) {
+	body, contentType, err := n.formatter.Format(notification)
+	if err != nil {
+		n.log.Err(err).Str("host", notification.Host).Msg("can't format notification")
+		return
+	}
+	for _, endpoint := range n.endpoints {
+		select {
+		case n.queues[endpoint] <- message{notification: notification, body: body, contentType: contentType}:
+		default:
+			n.log.Warn().
+				Str("endpoint", endpoint).
+				Str("host", notification.Host).
+				Str("reason", notification.Reason).
+				Msg("notification queue is full, dropping notification")
+		}
+	}
+}
+
+// Start implements manager.Runnable. Every endpoint is served by a single worker, so notifications are delivered
+// in the order they were queued. Workers stop, including pending retries, when ctx is cancelled
+func (n *HTTPNotifier) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for endpoint, queue := range n.queues {
+		wg.Add(1)
+		go func(endpoint string, queue <-chan message) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case m := <-queue:
+					if err := n.send(ctx, endpoint, m.body, m.contentType); err != nil {
+						n.log.Err(err).
+							Str("host", m.notification.Host).
+							Str("reason", m.notification.Reason).
+							Msg("can't send notification")
+					}
+				}
+			}
+		}(endpoint, queue)
+	}
+	wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the leader queues notifications,
+// the workers of other replicas stay idle
+func (n *HTTPNotifier) NeedLeaderElection() bool {
+	return false
+}
+
+// send posts the body to endpoint; it retries with exponential backoff on network errors,
+// 429 and 5xx responses
+func (n *HTTPNotifier) send(ctx context.Context, endpoint string, body []byte, contentType string) (err error) {
+	backoff := n.backoff
+	for attempt := 0; attempt <= n.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%s: %w", endpoint, ctx.Err())
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		var retry bool
+		retry, err = n.post(ctx, endpoint, body, contentType)
+		if err == nil || !retry {
+			return err
+		}
+		n.log.Debug().Err(err).Str("endpoint", endpoint).Int("attempt", attempt+1).Msg("notification failed")
+	}
+	return fmt.Errorf("%s: giving up after %d attempts: %w", endpoint, n.retries+1, err)
+}
+
+func (n *HTTPNotifier) post(ctx context.Context, endpoint string, body []byte, contentType string) (retry bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "k8gb")
+	if n.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(n.secret, body))
+	}
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("%s: unexpected status %s", endpoint, resp.Status)
+	default:
+		return false, fmt.Errorf("%s: unexpected status %s", endpoint, resp.Status)
+	}
+}
+
+func (n *HTTPNotifier) String() string {
+	return fmt.Sprintf("HTTP %v", n.endpoints)
+}
+
+// Sign returns value of SignatureHeader for body, receivers compute the same value to verify the sender
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
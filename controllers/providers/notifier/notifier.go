@@ -0,0 +1,102 @@
+package notifier
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/rs/zerolog"
+)
+
+// Reasons of notifications
+const (
+	// ReasonFailover failover strategy moved the host away from the previously active cluster
+	ReasonFailover = "Failover"
+	// ReasonFailback failover strategy moved the host back to the primary cluster
+	ReasonFailback = "Failback"
+	// ReasonHealthyClustersChanged set of clusters serving the host has changed
+	ReasonHealthyClustersChanged = "HealthyClustersChanged"
+)
+
+// Notification describes change of clusters serving the host
+type Notification struct {
+	// Host which is affected
+	Host string `json:"host"`
+	// Namespace of the annotated resource
+	Namespace string `json:"namespace"`
+	// Ingress name of the annotated resource
+	Ingress string `json:"ingress"`
+	// Reason of the notification; Failover, Failback or HealthyClustersChanged
+	Reason string `json:"reason"`
+	// OldActiveGeoTag cluster serving the host before failover or failback
+	OldActiveGeoTag string `json:"oldActiveGeoTag,omitempty"`
+	// NewActiveGeoTag cluster serving the host after failover or failback
+	NewActiveGeoTag string `json:"newActiveGeoTag,omitempty"`
+	// OldHealthyGeoTags clusters serving the host before the change
+	OldHealthyGeoTags []string `json:"oldHealthyGeoTags,omitempty"`
+	// NewHealthyGeoTags clusters serving the host after the change
+	NewHealthyGeoTags []string `json:"newHealthyGeoTags,omitempty"`
+	// ClusterGeoTag of the cluster which observed the change
+	ClusterGeoTag string `json:"clusterGeoTag"`
+	// DryRun is true when the operator doesn't apply DNS changes
+	DryRun bool `json:"dryRun,omitempty"`
+	// Timestamp when the change was observed
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Message returns human readable description of the notification
+func (n Notification) Message() string {
+	var msg string
+	switch n.Reason {
+	case ReasonFailover:
+		msg = fmt.Sprintf("host %s failed over from cluster %s to cluster %s", n.Host, n.OldActiveGeoTag, n.NewActiveGeoTag)
+	case ReasonFailback:
+		msg = fmt.Sprintf("host %s failed back from cluster %s to primary cluster %s", n.Host, n.OldActiveGeoTag, n.NewActiveGeoTag)
+	default:
+		msg = fmt.Sprintf("host %s is served by clusters [%s], previously [%s]", n.Host,
+			strings.Join(n.NewHealthyGeoTags, ", "), strings.Join(n.OldHealthyGeoTags, ", "))
+	}
+	if n.DryRun {
+		msg += " (dry run)"
+	}
+	return fmt.Sprintf("k8gb %s: %s, observed by cluster %s", n.Reason, msg, n.ClusterGeoTag)
+}
+
+// Notifier sends notifications about failover
+type Notifier interface {
+	// Notify sends notification asynchronously; failures are logged
+	Notify(Notification)
+	// Start delivers notifications until ctx is cancelled; implements manager.Runnable
+	Start(ctx context.Context) error
+	String() string
+}
+
+// NewNotifier returns notifier configured by NOTIFIER_* environment variables. Notifications are
+// dropped when no endpoint is configured
+func NewNotifier(config *depresolver.Config, log *zerolog.Logger) Notifier {
+	if len(config.Notifier.Endpoints) == 0 {
+		return NewEmptyNotifier()
+	}
+	return NewHTTPNotifier(config, NewFormatter(config.Notifier.Format), log)
+}
@@ -0,0 +1,296 @@
+package notifier
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	log = zerolog.Nop()
+
+	failover = Notification{
+		Host:            "roundrobin.cloud.example.com",
+		Namespace:       "demo",
+		Ingress:         "roundrobin",
+		Reason:          ReasonFailover,
+		OldActiveGeoTag: "eu",
+		NewActiveGeoTag: "us",
+		ClusterGeoTag:   "us",
+		Timestamp:       time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+)
+
+type received struct {
+	body      []byte
+	signature string
+}
+
+func newNotifier(endpoint, format, secret string, retries int) *HTTPNotifier {
+	config := &depresolver.Config{Notifier: depresolver.Notifier{
+		Endpoints:      []string{endpoint},
+		Format:         format,
+		Secret:         secret,
+		Retries:        retries,
+		TimeoutSeconds: 1,
+	}}
+	n := NewHTTPNotifier(config, NewFormatter(format), &log)
+	n.backoff = time.Millisecond
+	return n
+}
+
+func TestNotifyJSON(t *testing.T) {
+	// arrange
+	ch := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		ch <- received{body: body, signature: r.Header.Get(SignatureHeader)}
+	}))
+	defer server.Close()
+	n := newNotifier(server.URL, depresolver.NotifierJSONFormat, "s3cr3t", 0)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go func() { _ = n.Start(ctx) }()
+	// act
+	n.Notify(failover)
+	// assert
+	select {
+	case r := <-ch:
+		var notification Notification
+		require.NoError(t, json.Unmarshal(r.body, &notification))
+		assert.Equal(t, failover, notification)
+		assert.Equal(t, Sign("s3cr3t", r.body), r.signature)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not received")
+	}
+}
+
+func TestNotifySlack(t *testing.T) {
+	// arrange
+	ch := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ch <- received{body: body, signature: r.Header.Get(SignatureHeader)}
+	}))
+	defer server.Close()
+	n := newNotifier(server.URL, depresolver.NotifierSlackFormat, "", 0)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go func() { _ = n.Start(ctx) }()
+	// act
+	n.Notify(failover)
+	// assert
+	select {
+	case r := <-ch:
+		message := map[string]string{}
+		require.NoError(t, json.Unmarshal(r.body, &message))
+		assert.Equal(t, "k8gb Failover: host roundrobin.cloud.example.com failed over from cluster eu to cluster us, "+
+			"observed by cluster us", message["text"])
+		assert.Empty(t, r.signature)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notification was not received")
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	var tests = []struct {
+		name          string
+		status        int
+		retries       int
+		expectedCalls int32
+		expectedError bool
+	}{
+		{name: "success", status: http.StatusOK, retries: 3, expectedCalls: 1},
+		{name: "server error is retried", status: http.StatusInternalServerError, retries: 3, expectedCalls: 4, expectedError: true},
+		{name: "too many requests is retried", status: http.StatusTooManyRequests, retries: 1, expectedCalls: 2, expectedError: true},
+		{name: "client error is not retried", status: http.StatusBadRequest, retries: 3, expectedCalls: 1, expectedError: true},
+		{name: "no retries", status: http.StatusBadGateway, retries: 0, expectedCalls: 1, expectedError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+			n := newNotifier(server.URL, depresolver.NotifierJSONFormat, "", test.retries)
+			// act
+			err := n.send(context.TODO(), server.URL, []byte("{}"), "application/json")
+			// assert
+			assert.Equal(t, test.expectedError, err != nil)
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestSendRecoversAfterFailure(t *testing.T) {
+	// arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	n := newNotifier(server.URL, depresolver.NotifierJSONFormat, "", 3)
+	// act
+	err := n.send(context.TODO(), server.URL, []byte("{}"), "application/json")
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestSendUnreachableEndpoint(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+	n := newNotifier(server.URL, depresolver.NotifierJSONFormat, "", 1)
+	// act
+	err := n.send(context.TODO(), server.URL, []byte("{}"), "application/json")
+	// assert
+	assert.Error(t, err)
+}
+
+func TestNotifyPreservesOrder(t *testing.T) {
+	// arrange
+	failback := failover
+	failback.Reason = ReasonFailback
+	ch := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification Notification
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &notification)
+		ch <- notification.Reason
+	}))
+	defer server.Close()
+	n := newNotifier(server.URL, depresolver.NotifierJSONFormat, "", 0)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go func() { _ = n.Start(ctx) }()
+	// act
+	n.Notify(failover)
+	n.Notify(failback)
+	// assert
+	for _, expected := range []string{ReasonFailover, ReasonFailback} {
+		select {
+		case reason := <-ch:
+			assert.Equal(t, expected, reason)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification was not received")
+		}
+	}
+}
+
+func TestNotifyDropsWhenQueueIsFull(t *testing.T) {
+	// arrange
+	n := newNotifier("https://hooks.example.com/k8gb", depresolver.NotifierJSONFormat, "", 0)
+	// act
+	for i := 0; i < queueSize+10; i++ {
+		n.Notify(failover)
+	}
+	// assert
+	assert.Equal(t, queueSize, len(n.queues["https://hooks.example.com/k8gb"]))
+}
+
+func TestSendStopsRetryingWhenCancelled(t *testing.T) {
+	// arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	n := newNotifier(server.URL, depresolver.NotifierJSONFormat, "", 3)
+	n.backoff = time.Hour
+	ctx, cancel := context.WithCancel(context.TODO())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	// act
+	err := n.send(ctx, server.URL, []byte("{}"), "application/json")
+	// assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestStartReturnsWhenCancelled(t *testing.T) {
+	// arrange
+	n := newNotifier("https://hooks.example.com/k8gb", depresolver.NotifierJSONFormat, "", 0)
+	ctx, cancel := context.WithCancel(context.TODO())
+	done := make(chan error)
+	go func() { done <- n.Start(ctx) }()
+	// act
+	cancel()
+	// assert
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("notifier did not stop")
+	}
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, Sign("s3cr3t", []byte("{}")), Sign("s3cr3t", []byte("{}")))
+	assert.NotEqual(t, Sign("s3cr3t", []byte("{}")), Sign("other", []byte("{}")))
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", Sign("s3cr3t", []byte("{}")))
+}
+
+func TestNewNotifier(t *testing.T) {
+	// arrange
+	config := &depresolver.Config{}
+	// act
+	empty := NewNotifier(config, &log)
+	config.Notifier.Endpoints = []string{"https://hooks.example.com/k8gb"}
+	httpNotifier := NewNotifier(config, &log)
+	// assert
+	assert.IsType(t, &EmptyNotifier{}, empty)
+	assert.IsType(t, &HTTPNotifier{}, httpNotifier)
+	assert.Equal(t, "HTTP [https://hooks.example.com/k8gb]", httpNotifier.String())
+}
+
+func TestMessage(t *testing.T) {
+	// arrange
+	failback := failover
+	failback.Reason = ReasonFailback
+	failback.OldActiveGeoTag, failback.NewActiveGeoTag = "us", "eu"
+	failback.DryRun = true
+	healthy := Notification{Host: "roundrobin.cloud.example.com", Reason: ReasonHealthyClustersChanged, ClusterGeoTag: "us",
+		OldHealthyGeoTags: []string{"eu", "us"}, NewHealthyGeoTags: []string{"us"}}
+	// act
+	// assert
+	assert.Equal(t, "k8gb Failback: host roundrobin.cloud.example.com failed back from cluster us to primary cluster eu "+
+		"(dry run), observed by cluster us", failback.Message())
+	assert.Equal(t, "k8gb HealthyClustersChanged: host roundrobin.cloud.example.com is served by clusters [us], "+
+		"previously [eu, us], observed by cluster us", healthy.Message())
+}
@@ -20,17 +20,27 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/audit"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/dns"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/notifier"
+	"github.com/k8gb-io/k8gb-light/controllers/tracing"
 	"github.com/k8gb-io/k8gb-light/controllers/utils"
 
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+	netv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	externaldns "sigs.k8s.io/external-dns/endpoint"
@@ -48,33 +58,62 @@ type AnnoReconciler struct {
 	ReconcilerResult *utils.ReconcileResultHandler
 	Log              *zerolog.Logger
 	Metrics          metrics.Metrics
+	Recorder         record.EventRecorder
+	Notifier         notifier.Notifier
+	Auditor          audit.Auditor
+	Shutdown         <-chan struct{}
+	transitions      transitions
+	explanations     explanations
 }
 
 func (r *AnnoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	ctx, span := r.Tracer.Start(ctx, "Reconcile")
 	defer span.End()
+	ctx = depresolver.WithConfig(ctx, snapshotConfig(r.Config))
 
 	// == handle request
 	if req.NamespacedName.Name == "" || req.NamespacedName.Namespace == "" {
 		return r.ReconcilerResult.Requeue()
 	}
 
-	rs, rr, err := r.Mapper.Get(req.NamespacedName)
+	rs, rr, err := r.Mapper.Get(ctx, req.NamespacedName)
 	switch rr {
 	case mapper.ResultNotFound:
+		r.forget(req.NamespacedName)
 		r.Log.Info().
 			Str("Namespace", req.NamespacedName.Namespace).
 			Str("Ingress", req.NamespacedName.Name).
 			Str("Annotation", mapper.AnnotationStrategy).
 			Msg("Ingress or annotation not found. Stop...")
 		return r.ReconcilerResult.Stop()
-	case mapper.ResultExistsButNotAnnotationFound:
-		if rx, _ := rs.TryRemoveDNSEndpoint(); rx == mapper.ResultEndpointDeleted {
+	case mapper.ResultExistsButNotAnnotationFound, mapper.ResultExistsButNotSelected:
+		// ingress which stopped matching the selector is cleaned up the same way as ingress without annotation
+		r.forget(req.NamespacedName)
+		if r.Config.DryRun {
+			r.Log.Info().
+				Str("Namespace", req.NamespacedName.Namespace).
+				Str("Endpoint", req.NamespacedName.Name).
+				Msg("Dry run: Ingress annotation removed, DNSEndpoint is not deleted")
+			return r.ReconcilerResult.Stop()
+		}
+		rx, _ := rs.TryRemoveDNSEndpoint(ctx)
+		// finalizer is not needed anymore, otherwise deletion of the ingress would hang
+		if r.DNSProvider.RequireFinalizer() {
+			if fx, err := rs.TryRemoveFinalizer(ctx, r.DNSProvider.Finalize); fx == mapper.ResultError {
+				r.Metrics.IncrementError(req.NamespacedName)
+				r.Log.Warn().
+					Str("finalizer", mapper.Finalizer).
+					AnErr("error", err).
+					Msg("Removing finalizer error")
+				return r.ReconcilerResult.RequeueError(err)
+			}
+		}
+		if rx == mapper.ResultEndpointDeleted {
 			r.Log.Debug().
 				Str("Namespace", req.NamespacedName.Namespace).
 				Str("Endpoint", req.NamespacedName.Name).
 				Str("Annotation", mapper.AnnotationStrategy).
-				Msg("Ingress annotation removed, DNSEndpoint deleted")
+				Msg("Ingress annotation removed or ingress not selected, DNSEndpoint deleted")
 			return r.ReconcilerResult.Stop()
 		}
 		r.Log.Info().
@@ -89,29 +128,51 @@ func (r *AnnoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 			Str("Namespace", req.NamespacedName.Namespace).
 			Str("Ingress", req.NamespacedName.Name).
 			Msg("reading Ingress error")
+		if errors.Is(err, mapper.ErrInvalidAnnotations) {
+			ing := &netv1.Ingress{}
+			if r.Get(ctx, req.NamespacedName, ing) == nil {
+				r.recordError(req.NamespacedName, ing, "annotations", EventReasonInvalidAnnotations, err)
+			}
+		}
 		return r.ReconcilerResult.Requeue()
 	}
+	r.recordError(rs.NamespacedName, rs.Ingress, "annotations", EventReasonInvalidAnnotations, nil)
 
-	// == handle finalizers
-	if r.DNSProvider.RequireFinalizer() {
-		_, fSpan := r.Tracer.Start(ctx, "Handle finalizer")
-		result, err := r.handleFinalizer(rs)
+	// == logger of reconciliation is passed down to mapper and providers; debugged resources log at trace level
+	log := r.Log
+	if rs.Debug() {
+		l := logging.Debug(r.Log).With().
+			Str("Namespace", rs.NamespacedName.Namespace).
+			Str("Ingress", rs.NamespacedName.Name).
+			Logger()
+		log = &l
+	}
+	ctx = logging.WithLogger(ctx, log)
+	log.Debug().
+		Interface("spec", rs.Spec).
+		Interface("specSources", rs.SpecSources).
+		Msg("Resolved spec")
+
+	// == handle finalizers; dry run doesn't modify the resource
+	if r.DNSProvider.RequireFinalizer() && !r.Config.DryRun {
+		fCtx, fSpan := r.Tracer.Start(ctx, "Handle finalizer")
+		result, err := r.handleFinalizer(fCtx, rs)
 		switch result {
 		case mapper.ResultContinue:
 			fSpan.End()
 		case mapper.ResultFinalizerInstalled:
-			r.Log.Info().
+			log.Info().
 				Str("finalizer", mapper.Finalizer).
 				Msg("Injected finalizer")
 			fSpan.End()
 		case mapper.ResultFinalizerRemoved:
-			r.Log.Info().
+			log.Info().
 				Str("finalizer", mapper.Finalizer).
 				Msg("Remove injected finalizer")
 			fSpan.End()
 			return r.ReconcilerResult.Stop()
 		case mapper.ResultError:
-			r.Log.Warn().
+			log.Warn().
 				Str("finalizer", mapper.Finalizer).
 				AnErr("error", err).
 				Msg("Injecting finalizer error")
@@ -123,57 +184,63 @@ func (r *AnnoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 	}
 
-	r.Log.Info().
+	log.Info().
 		Str("EdgeDNSZone", r.Config.DNSZone).
 		Msg("* Starting Reconciliation")
 
 	// == external-dns dnsendpoints CRs ==
-	dnsEndpoint, err := r.getDNSEndpoint(rs)
+	dnsEndpoint, observations, err := r.getDNSEndpoint(ctx, rs)
 	if err != nil {
 		r.Metrics.IncrementError(rs.NamespacedName)
 		return r.ReconcilerResult.RequeueError(err)
 	}
 
-	_, s := r.Tracer.Start(ctx, "SaveDNSEndpoint")
-	err = r.DNSProvider.SaveDNSEndpoint(rs, dnsEndpoint)
+	// == decommissioned cluster is not announced to peers again, e.g. by the leader elected during shutdown
+	withdrawn, err := decommissioned(ctx, r.Client, r.Config, r.Shutdown)
 	if err != nil {
 		r.Metrics.IncrementError(rs.NamespacedName)
 		return r.ReconcilerResult.RequeueError(err)
 	}
-	s.End()
+	if withdrawn {
+		log.Info().
+			Str("annotation", mapper.AnnotationDecommission).
+			Msg("Cluster is decommissioned, localtargets records are not published")
+		dnsEndpoint.Spec.Endpoints = withoutLocalTargets(dnsEndpoint.Spec.Endpoints)
+	}
 
-	// == handle delegated zone in Edge DNS
-	_, szd := r.Tracer.Start(ctx, "CreateZoneDelegationForExternalDNS")
-	err = r.DNSProvider.CreateZoneDelegationForExternalDNS(rs)
+	sCtx, s := r.Tracer.Start(ctx, "SaveDNSEndpoint")
+	err = r.DNSProvider.SaveDNSEndpoint(sCtx, rs, dnsEndpoint)
+	tracing.End(s, err)
+	r.recordError(rs.NamespacedName, rs.Ingress, "dns", EventReasonDNSUpdateFailed, err)
 	if err != nil {
-		r.Log.Err(err).Msg("Unable to create zone delegation")
 		r.Metrics.IncrementError(rs.NamespacedName)
-		return r.ReconcilerResult.Requeue()
+		return r.ReconcilerResult.RequeueError(err)
 	}
-	szd.End()
 
 	// == Status =
-	err = r.updateStatus(rs, dnsEndpoint)
+	storeObservations(rs, observations)
+	err = r.updateStatus(ctx, rs, dnsEndpoint)
 	if err != nil {
 		r.Metrics.IncrementError(rs.NamespacedName)
 		return r.ReconcilerResult.RequeueError(err)
 	}
+	r.record(ctx, rs, observations)
 	// == Finish ==========
 	// Everything went fine, requeue after RECONCILE_REQUEUE_SECONDS
 	r.Metrics.IncrementReconciliation(rs.NamespacedName)
 	return r.ReconcilerResult.Requeue()
 }
 
-func (r *AnnoReconciler) handleFinalizer(rs *mapper.LoopState) (mapper.Result, error) {
+func (r *AnnoReconciler) handleFinalizer(ctx context.Context, rs *mapper.LoopState) (mapper.Result, error) {
 
 	// Inject finalizer if doesn't exists
-	result, err := rs.TryInjectFinalizer()
+	result, err := rs.TryInjectFinalizer(ctx)
 	if result.IsIn(mapper.ResultFinalizerInstalled, mapper.ResultError) {
 		return result, err
 	}
 
 	// Try remove if isMarkedToBeDeleted
-	result, err = rs.TryRemoveFinalizer(r.DNSProvider.Finalize)
+	result, err = rs.TryRemoveFinalizer(ctx, r.DNSProvider.Finalize)
 	if result.IsIn(mapper.ResultFinalizerRemoved, mapper.ResultError) {
 		return result, err
 	}
@@ -181,10 +248,49 @@ func (r *AnnoReconciler) handleFinalizer(rs *mapper.LoopState) (mapper.Result, e
 	return mapper.ResultContinue, nil
 }
 
-func (r *AnnoReconciler) updateStatus(rs *mapper.LoopState, ep *externaldns.DNSEndpoint) (err error) {
-	status := rs.GetStatus()
+// forget drops everything remembered about the ingress which left GSLB management, including its metric series
+func (r *AnnoReconciler) forget(nn types.NamespacedName) {
+	hosts, _ := r.transitions.value(nn, "hosts")
+	r.Metrics.DeleteIngress(nn, splitHosts(hosts))
+	r.transitions.forget(nn)
+	r.explanations.forget(nn)
+}
+
+// forgetRemovedHosts deletes metric series of hosts which were removed from the ingress since the last reconciliation
+func (r *AnnoReconciler) forgetRemovedHosts(rs *mapper.LoopState, status mapper.Status) {
+	var hosts []string
+	for host := range status.ServiceHealth {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	previous, _ := r.transitions.observe(rs.NamespacedName, "hosts", strings.Join(hosts, ","))
+	for _, host := range splitHosts(previous) {
+		if !utils.Contains(hosts, host) {
+			r.Metrics.DeleteHost(rs.NamespacedName, host)
+		}
+	}
+}
+
+func splitHosts(hosts string) []string {
+	if hosts == "" {
+		return nil
+	}
+	return strings.Split(hosts, ",")
+}
+
+func (r *AnnoReconciler) updateStatus(ctx context.Context, rs *mapper.LoopState, ep *externaldns.DNSEndpoint) (err error) {
+	status := rs.GetStatus(ctx)
+	r.forgetRemovedHosts(rs, status)
 	r.Metrics.UpdateIngressHostsPerStatusMetric(rs.NamespacedName, status.ServiceHealth)
 	r.Metrics.UpdateHealthyRecordsMetric(rs.NamespacedName, status.HealthyRecords)
 	r.Metrics.UpdateEndpointStatus(ep)
-	return rs.UpdateStatusAnnotation()
+	if r.Config.DryRun {
+		logging.FromContext(ctx).Info().
+			Str("Namespace", rs.NamespacedName.Namespace).
+			Str("Ingress", rs.NamespacedName.Name).
+			Interface("status", status).
+			Msg("Dry run: status annotation is not updated")
+		return nil
+	}
+	return rs.UpdateStatusAnnotation(ctx)
 }
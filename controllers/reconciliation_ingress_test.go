@@ -28,6 +28,9 @@ import (
 	"github.com/k8gb-io/k8gb-light/controllers/logging"
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
 	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/audit"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/notifier"
 	"github.com/k8gb-io/k8gb-light/controllers/utils"
 
 	"github.com/golang/mock/gomock"
@@ -37,6 +40,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -106,6 +110,7 @@ func TestReconcileRequest(t *testing.T) {
 		Return(nil).AnyTimes()
 	cl.Client.(*mocks.MockClient).EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil).AnyTimes()
+	cl.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(false).AnyTimes()
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
@@ -146,10 +151,10 @@ func TestFinalizerInReconciliation(t *testing.T) {
 				r.Tracer.(*mocks.MockTracer).EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), span).Times(2)
 
 				m := mocks.NewMockMapper(ctrl)
-				m.EXPECT().TryInjectFinalizer().Return(mapper.ResultContinue, nil).Times(1)
-				m.EXPECT().TryRemoveFinalizer(gomock.Any()).Return(mapper.ResultFinalizerRemoved, nil).Times(1)
+				m.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultContinue, nil).Times(1)
+				m.EXPECT().TryRemoveFinalizer(gomock.Any(), gomock.Any()).Return(mapper.ResultFinalizerRemoved, nil).Times(1)
 
-				r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any()).Return(&mapper.LoopState{Mapper: m}, mapper.ResultExists, nil).Times(1)
+				r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), gomock.Any()).Return(&mapper.LoopState{Mapper: m}, mapper.ResultExists, nil).Times(1)
 				r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(true).Times(1)
 			},
 		},
@@ -167,12 +172,12 @@ func TestFinalizerInReconciliation(t *testing.T) {
 				fspan.EXPECT().End(gomock.Any()).Return().Times(1)
 
 				m := mocks.NewMockMapper(ctrl)
-				m.EXPECT().TryInjectFinalizer().Return(mapper.ResultError, ferr).Times(1)
+				m.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultError, ferr).Times(1)
 
 				r.Tracer = mocks.NewMockTracer(ctrl)
 				r.Tracer.(*mocks.MockTracer).EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), span).Times(1)
 				r.Tracer.(*mocks.MockTracer).EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), fspan).Times(1)
-				r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any()).Return(&mapper.LoopState{Mapper: m}, mapper.ResultExists, nil).Times(1)
+				r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), gomock.Any()).Return(&mapper.LoopState{Mapper: m}, mapper.ResultExists, nil).Times(1)
 				r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(true).Times(1)
 			},
 		},
@@ -192,6 +197,135 @@ func TestFinalizerInReconciliation(t *testing.T) {
 	}
 }
 
+func TestReconcileNotManagedRemovesFinalizer(t *testing.T) {
+	var ferr = fmt.Errorf("finalizer err")
+	var tests = []struct {
+		name           string
+		result         mapper.Result
+		finalizeResult mapper.Result
+		finalizeErr    error
+		hasError       bool
+	}{
+		{name: "Annotation Removed", result: mapper.ResultExistsButNotAnnotationFound, finalizeResult: mapper.ResultFinalizerRemoved},
+		{name: "Not Selected", result: mapper.ResultExistsButNotSelected, finalizeResult: mapper.ResultFinalizerRemoved},
+		{name: "Not Selected Without Finalizer", result: mapper.ResultExistsButNotSelected, finalizeResult: mapper.ResultContinue},
+		{name: "Finalizer Error", result: mapper.ResultExistsButNotSelected, finalizeResult: mapper.ResultError,
+			finalizeErr: ferr, hasError: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := mocks.NewMockMapper(ctrl)
+			m.EXPECT().TryRemoveDNSEndpoint(gomock.Any()).Return(mapper.ResultEndpointDeleted, nil).Times(1)
+			m.EXPECT().TryRemoveFinalizer(gomock.Any(), gomock.Any()).Return(test.finalizeResult, test.finalizeErr).Times(1)
+			r := fakeMapper(ctrl)
+			r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), gomock.Any()).
+				Return(&mapper.LoopState{Mapper: m}, test.result, nil).Times(1)
+			r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Return(true).Times(1)
+
+			// act
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "exists", Name: "ing"}})
+
+			// assert
+			assert.Equal(t, test.hasError, err != nil)
+		})
+	}
+}
+
+func TestDryRunReconciliation(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockMapper(ctrl)
+	m.EXPECT().TryInjectFinalizer(gomock.Any()).Times(0)
+	m.EXPECT().TryRemoveDNSEndpoint(gomock.Any()).Times(0)
+	r := fakeMapper(ctrl)
+	r.Config.DryRun = true
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), gomock.Any()).
+		Return(&mapper.LoopState{Mapper: m}, mapper.ResultExistsButNotAnnotationFound, nil).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().RequireFinalizer().Times(0)
+
+	// act
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "exists", Name: "ing"}})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+}
+
+func TestDryRunUpdateStatus(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockMapper(ctrl)
+	m.EXPECT().GetStatus(gomock.Any()).Return(mapper.Status{}).Times(1)
+	m.EXPECT().UpdateStatusAnnotation(gomock.Any()).Times(0)
+	r := fakeMapper(ctrl)
+	r.Config.DryRun = true
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateIngressHostsPerStatusMetric(gomock.Any(), gomock.Any()).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateHealthyRecordsMetric(gomock.Any(), gomock.Any()).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateEndpointStatus(gomock.Any()).Times(1)
+
+	// act
+	err := r.updateStatus(context.TODO(), &mapper.LoopState{Mapper: m}, nil)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestUpdateStatusDeletesRemovedHosts(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nn := types.NamespacedName{Namespace: "exists", Name: "ing"}
+	m := mocks.NewMockMapper(ctrl)
+	m.EXPECT().GetStatus(gomock.Any()).Return(mapper.Status{ServiceHealth: map[string]metrics.HealthStatus{
+		"app.cloud.example.com": metrics.Healthy,
+		"web.cloud.example.com": metrics.Healthy,
+	}}).Times(1)
+	m.EXPECT().GetStatus(gomock.Any()).Return(mapper.Status{ServiceHealth: map[string]metrics.HealthStatus{
+		"app.cloud.example.com": metrics.Healthy,
+	}}).Times(1)
+	r := fakeMapper(ctrl)
+	r.Config.DryRun = true
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateIngressHostsPerStatusMetric(gomock.Any(), gomock.Any()).Times(2)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateHealthyRecordsMetric(gomock.Any(), gomock.Any()).Times(2)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().UpdateEndpointStatus(gomock.Any()).Times(2)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().DeleteHost(nn, "web.cloud.example.com").Times(1)
+
+	// act
+	errFirst := r.updateStatus(context.TODO(), &mapper.LoopState{NamespacedName: nn, Mapper: m}, nil)
+	errSecond := r.updateStatus(context.TODO(), &mapper.LoopState{NamespacedName: nn, Mapper: m}, nil)
+
+	// assert
+	assert.NoError(t, errFirst)
+	assert.NoError(t, errSecond)
+}
+
+func TestForgetDeletesIngressMetrics(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nn := types.NamespacedName{Namespace: "exists", Name: "ing"}
+	r := fakeMapper(ctrl)
+	r.transitions.observe(nn, "hosts", "app.cloud.example.com,web.cloud.example.com")
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().Get(gomock.Any(), gomock.Any()).
+		Return(nil, mapper.ResultNotFound, nil).Times(1)
+	metricsMock := mocks.NewMockMetrics(ctrl)
+	metricsMock.EXPECT().DeleteIngress(nn, []string{"app.cloud.example.com", "web.cloud.example.com"}).Times(1)
+	r.Metrics = metricsMock
+
+	// act
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn})
+
+	// assert
+	assert.NoError(t, err)
+	_, found := r.transitions.value(nn, "hosts")
+	assert.False(t, found)
+}
+
 func TestHandleFinalizer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -206,38 +340,38 @@ func TestHandleFinalizer(t *testing.T) {
 			Name:           "Inject Finalizer",
 			ExpectedResult: mapper.ResultFinalizerInstalled,
 			SetMocks: func(c *mocks.MockMapper) {
-				c.EXPECT().TryInjectFinalizer().Return(mapper.ResultFinalizerInstalled, nil).Times(1)
+				c.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultFinalizerInstalled, nil).Times(1)
 			},
 		},
 		{
 			Name:           "Inject Finalizer Error",
 			ExpectedResult: mapper.ResultError,
 			SetMocks: func(c *mocks.MockMapper) {
-				c.EXPECT().TryInjectFinalizer().Return(mapper.ResultError, ferr).Times(1)
+				c.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultError, ferr).Times(1)
 			},
 		},
 		{
 			Name:           "Remove Finalizer",
 			ExpectedResult: mapper.ResultFinalizerRemoved,
 			SetMocks: func(c *mocks.MockMapper) {
-				c.EXPECT().TryInjectFinalizer().Return(mapper.ResultContinue, nil).Times(1)
-				c.EXPECT().TryRemoveFinalizer(gomock.Any()).Return(mapper.ResultFinalizerRemoved, nil).Times(1)
+				c.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultContinue, nil).Times(1)
+				c.EXPECT().TryRemoveFinalizer(gomock.Any(), gomock.Any()).Return(mapper.ResultFinalizerRemoved, nil).Times(1)
 			},
 		},
 		{
 			Name:           "Remove Finalizer Error",
 			ExpectedResult: mapper.ResultError,
 			SetMocks: func(c *mocks.MockMapper) {
-				c.EXPECT().TryInjectFinalizer().Return(mapper.ResultContinue, nil).Times(1)
-				c.EXPECT().TryRemoveFinalizer(gomock.Any()).Return(mapper.ResultError, ferr).Times(1)
+				c.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultContinue, nil).Times(1)
+				c.EXPECT().TryRemoveFinalizer(gomock.Any(), gomock.Any()).Return(mapper.ResultError, ferr).Times(1)
 			},
 		},
 		{
 			Name:           "Finalizer Skipped",
 			ExpectedResult: mapper.ResultContinue,
 			SetMocks: func(c *mocks.MockMapper) {
-				c.EXPECT().TryInjectFinalizer().Return(mapper.ResultContinue, nil).Times(1)
-				c.EXPECT().TryRemoveFinalizer(gomock.Any()).Return(mapper.ResultContinue, ferr).Times(1)
+				c.EXPECT().TryInjectFinalizer(gomock.Any()).Return(mapper.ResultContinue, nil).Times(1)
+				c.EXPECT().TryRemoveFinalizer(gomock.Any(), gomock.Any()).Return(mapper.ResultContinue, ferr).Times(1)
 			},
 		},
 	}
@@ -249,7 +383,7 @@ func TestHandleFinalizer(t *testing.T) {
 			r := fakeMapper(ctrl)
 			m := mocks.NewMockMapper(ctrl)
 			test.SetMocks(m)
-			result, err := r.handleFinalizer(&mapper.LoopState{Mapper: m})
+			result, err := r.handleFinalizer(context.TODO(), &mapper.LoopState{Mapper: m})
 			assert.Equal(t, test.ExpectedResult, result)
 			if result == mapper.ResultError {
 				assert.Error(t, err)
@@ -263,7 +397,7 @@ func TestHandleFinalizer(t *testing.T) {
 // if you want to mock client use fakeClient
 func fakeClient(ctrl *gomock.Controller, config depresolver.Config) *AnnoReconciler {
 	r := fakeMapper(ctrl)
-	r.Mapper = mapper.NewCommonProvider(r.Client, &config)
+	r.Mapper = mapper.NewCommonProvider(r.Client, nil, &config)
 	return r
 }
 
@@ -288,9 +422,12 @@ func fakeMapper(ctrl *gomock.Controller) *AnnoReconciler {
 		Config:           config,
 		Mapper:           m,
 		Tracer:           defaultTracer,
-		ReconcilerResult: utils.NewReconcileResultHandler(config.ReconcileRequeueSeconds),
+		ReconcilerResult: utils.NewReconcileResultHandler(config.ReconcileRequeueSeconds, config.ReconcileRequeueJitterPercent),
 		Log:              logging.Logger(),
 		Metrics:          defaultMetrics,
+		Recorder:         &record.FakeRecorder{},
+		Notifier:         notifier.NewEmptyNotifier(),
+		Auditor:          audit.NewEmptyAuditor(),
 	}
 	// providing default tracer and span
 	defaultTracerSpan.EXPECT().End(gomock.Any()).Return().AnyTimes()
@@ -298,5 +435,6 @@ func fakeMapper(ctrl *gomock.Controller) *AnnoReconciler {
 
 	// providing default metrics
 	defaultMetrics.EXPECT().IncrementError(gomock.Any()).AnyTimes()
+	defaultMetrics.EXPECT().DeleteIngress(gomock.Any(), gomock.Any()).AnyTimes()
 	return reconciler
 }
@@ -20,32 +20,55 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 
 import (
 	"context"
+	"time"
 
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
 
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 	externaldns "sigs.k8s.io/external-dns/endpoint"
 )
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *AnnoReconciler) SetupWithManager(mgr ctrl.Manager) error {
+// SetupWithManager sets up the controller with the Manager. Map functions of watches take no context,
+// so they use ctx of the manager and their API calls are cancelled on shutdown
+func (r *AnnoReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	selector := mapper.NewIngressSelector(r.Config)
+
+	selectedIngress := selectedIngresses(selector)
+
+	watchedNamespace := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return selector.MatchesNamespace(o.GetNamespace())
+	})
 
 	ingressHandler := handler.EnqueueRequestsFromMapFunc(
 		func(a client.Object) []reconcile.Request {
-			rs1, err := r.Mapper.FromIngress(a.(*netv1.Ingress))
+			// ingress which stopped matching the selector is reconciled once more to clean it up
+			if !selector.Matches(a.(*netv1.Ingress)) {
+				return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(a)}}
+			}
+			rs1, err := r.Mapper.FromIngress(ctx, a.(*netv1.Ingress))
 			if err != nil {
 				return nil
 			}
-			rs2, result, _ := r.Mapper.Get(rs1.NamespacedName)
+			rs2, result, _ := r.Mapper.Get(ctx, rs1.NamespacedName)
 			switch result {
 			case mapper.ResultExists:
 				if !rs1.Equal(rs2) {
@@ -57,31 +80,227 @@ func (r *AnnoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return nil
 		})
 
-	serviceEndpointHandler := handler.EnqueueRequestsFromMapFunc(
+	serviceHandler := r.serviceHandler(ctx, selector)
+
+	err := mgr.GetFieldIndexer().IndexField(ctx, &netv1.Ingress{}, ingressBackendServiceIndex, ingressBackendServices)
+	if err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.MaxConcurrentReconciles,
+			RateLimiter:             newRateLimiter(r.Config.RateLimiter),
+		}).
+		For(&netv1.Ingress{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}, selectedIngress)).
+		Owns(&externaldns.DNSEndpoint{}, builder.WithPredicates(watchedNamespace)).
+		Watches(&source.Kind{Type: &netv1.Ingress{}}, ingressHandler, builder.WithPredicates(selectedIngress)).
+		Watches(&source.Kind{Type: &corev1.Endpoints{}}, serviceHandler,
+			builder.WithPredicates(endpointsChanged, watchedNamespace)).
+		Watches(&source.Kind{Type: &corev1.Service{}}, serviceHandler,
+			builder.WithPredicates(serviceCreatedOrDeleted, watchedNamespace))
+
+	// ingresses are re-evaluated when their defaults change
+	if r.Config.DefaultsConfigMapName != "" {
+		isDefaultsConfigMap := predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return o.GetNamespace() == r.Config.K8gbNamespace && o.GetName() == r.Config.DefaultsConfigMapName
+		})
+		b = b.Watches(&source.Kind{Type: &corev1.ConfigMap{}}, r.defaultsHandler(ctx, selector),
+			builder.WithPredicates(isDefaultsConfigMap))
+	}
+	if r.Config.NamespaceDefaultsEnabled {
+		watchedNamespaceObject := predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return selector.MatchesNamespace(o.GetName())
+		})
+		b = b.Watches(&source.Kind{Type: &corev1.Namespace{}}, r.defaultsHandler(ctx, selector),
+			builder.WithPredicates(namespaceAnnotationsChanged, watchedNamespaceObject))
+	}
+	return b.Complete(r)
+}
+
+// newRateLimiter combines per-item exponential backoff of failed requests with overall token bucket,
+// the same way as workqueue.DefaultControllerRateLimiter does
+func newRateLimiter(config depresolver.RateLimiter) ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(
+			time.Duration(config.BaseDelayMilliseconds)*time.Millisecond,
+			time.Duration(config.MaxDelaySeconds)*time.Second),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(config.QPS), config.Burst)},
+	)
+}
+
+// ingressBackendServiceIndex indexes ingresses by names of backend services
+const ingressBackendServiceIndex = "k8gb.io/backend-service"
+
+// selectedIngresses passes events of ingresses matching the selector. Update events pass also when
+// ingress stops matching the selector, so its DNSEndpoint and finalizer can be removed
+func selectedIngresses(selector *mapper.IngressSelector) predicate.Funcs {
+	matches := func(o client.Object) bool {
+		ing, ok := o.(*netv1.Ingress)
+		return ok && selector.Matches(ing)
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return matches(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return matches(e.ObjectOld) || matches(e.ObjectNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return matches(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return matches(e.Object) },
+	}
+}
+
+// serviceHandler maps Services and their Endpoints to every selected ingress referencing the service
+// as a rule or default backend
+func (r *AnnoReconciler) serviceHandler(ctx context.Context, selector *mapper.IngressSelector) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
 		func(a client.Object) []reconcile.Request {
-			ingList := &netv1.IngressList{}
-			c := mgr.GetClient()
-			err := c.List(context.TODO(), ingList, client.InNamespace(a.GetNamespace()))
+			requests, err := ingressRequestsForService(ctx, r.Client, selector, a)
 			if err != nil {
 				r.Log.Info().Msg("Can't fetch ingress objects")
 				return nil
 			}
-			for _, ing := range ingList.Items {
-				for _, rule := range ing.Spec.Rules {
-					for _, path := range rule.HTTP.Paths {
-						if path.Backend.Service != nil && path.Backend.Service.Name == a.GetName() {
-							return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: a.GetNamespace(), Name: ing.Name}}}
-						}
-					}
-				}
+			return requests
+		})
+}
+
+// endpointsChanged passes Endpoints updates changing addresses or ports. Endpoints have no generation,
+// so GenerationChangedPredicate would drop all of them
+var endpointsChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldEp, okOld := e.ObjectOld.(*corev1.Endpoints)
+		newEp, okNew := e.ObjectNew.(*corev1.Endpoints)
+		return !okOld || !okNew || !equality.Semantic.DeepEqual(oldEp.Subsets, newEp.Subsets)
+	},
+}
+
+// defaultsHandler maps the defaults ConfigMap to every selected annotated ingress, and a namespace
+// to selected annotated ingresses in the namespace
+func (r *AnnoReconciler) defaultsHandler(ctx context.Context, selector *mapper.IngressSelector) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(
+		func(a client.Object) []reconcile.Request {
+			namespace := ""
+			if _, ok := a.(*corev1.Namespace); ok {
+				namespace = a.GetName()
 			}
-			return nil
+			requests, err := annotatedIngressRequests(ctx, r.Client, selector, namespace)
+			if err != nil {
+				r.Log.Info().Msg("Can't fetch ingress objects")
+				return nil
+			}
+			return requests
 		})
+}
+
+// namespaceAnnotationsChanged passes namespace updates changing annotations only. Ingresses of created
+// namespace are reconciled when they are created
+var namespaceAnnotationsChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !equality.Semantic.DeepEqual(e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations())
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// serviceCreatedOrDeleted passes Service create and delete events only, Endpoints cover the rest
+var serviceCreatedOrDeleted = predicate.Funcs{
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// ingressBackendServices returns unique names of services referenced by ingress default backend and rules
+func ingressBackendServices(o client.Object) []string {
+	ing, ok := o.(*netv1.Ingress)
+	if !ok {
+		return nil
+	}
+	var services []string
+	add := func(backend *netv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || backend.Service.Name == "" {
+			return
+		}
+		for _, s := range services {
+			if s == backend.Service.Name {
+				return
+			}
+		}
+		services = append(services, backend.Service.Name)
+	}
+	add(ing.Spec.DefaultBackend)
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return services
+}
+
+// ingressRequestsForService returns requests for all selected ingresses referencing the service
+func ingressRequestsForService(ctx context.Context, c client.Reader, selector *mapper.IngressSelector, svc client.Object) ([]reconcile.Request, error) {
+	ingList := &netv1.IngressList{}
+	err := c.List(ctx, ingList, client.InNamespace(svc.GetNamespace()),
+		client.MatchingFields{ingressBackendServiceIndex: svc.GetName()},
+		client.MatchingLabelsSelector{Selector: selector.Labels()})
+	if err != nil {
+		return nil, err
+	}
+	var requests []reconcile.Request
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if !selector.Matches(ing) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}})
+	}
+	return requests, nil
+}
+
+// annotatedIngressRequests returns requests for all selected ingresses annotated by k8gb strategy
+// in namespace, or in all namespaces when namespace is empty
+func annotatedIngressRequests(ctx context.Context, c client.Reader, selector *mapper.IngressSelector, namespace string) ([]reconcile.Request, error) {
+	ingList := &netv1.IngressList{}
+	err := c.List(ctx, ingList, client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: selector.Labels()})
+	if err != nil {
+		return nil, err
+	}
+	var requests []reconcile.Request
+	for i := range ingList.Items {
+		ing := &ingList.Items[i]
+		if _, found := ing.GetAnnotations()[mapper.AnnotationStrategy]; !found || !selector.Matches(ing) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: ing.Namespace, Name: ing.Name}})
+	}
+	return requests, nil
+}
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&netv1.Ingress{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Owns(&externaldns.DNSEndpoint{}).
-		Watches(&source.Kind{Type: &netv1.Ingress{}}, ingressHandler).
-		Watches(&source.Kind{Type: &corev1.Endpoints{}}, serviceEndpointHandler, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
-		Complete(r)
+// NewCache restricts the informer cache to WatchNamespaces and ingresses matching IngressLabelSelector,
+// so objects which are not handled by this k8gb instance are not even cached. The k8gb namespace
+// is always watched. ConfigMaps are restricted to the configuration ConfigMap.
+func NewCache(config *depresolver.Config) cache.NewCacheFunc {
+	return func(c *rest.Config, opts cache.Options) (cache.Cache, error) {
+		selector := mapper.NewIngressSelector(config)
+		opts.SelectorsByObject = cache.SelectorsByObject{}
+		if !selector.Labels().Empty() {
+			opts.SelectorsByObject[&netv1.Ingress{}] = cache.ObjectSelector{Label: selector.Labels()}
+		}
+		// configuration and defaults ConfigMaps are read from k8gb namespace only
+		if config.ConfigMapName != "" || config.DefaultsConfigMapName != "" {
+			opts.SelectorsByObject[&corev1.ConfigMap{}] = cache.ObjectSelector{
+				Field: fields.OneTermEqualSelector("metadata.namespace", config.K8gbNamespace),
+			}
+		}
+		if len(config.WatchNamespaces) == 0 {
+			return cache.New(c, opts)
+		}
+		namespaces := []string{config.K8gbNamespace}
+		for _, ns := range config.WatchNamespaces {
+			if ns != config.K8gbNamespace {
+				namespaces = append(namespaces, ns)
+			}
+		}
+		return cache.MultiNamespacedCacheBuilder(namespaces)(c, opts)
+	}
 }
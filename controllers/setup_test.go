@@ -0,0 +1,305 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestIngressBackendServices(t *testing.T) {
+	backend := func(name string) netv1.IngressBackend {
+		return netv1.IngressBackend{Service: &netv1.IngressServiceBackend{Name: name}}
+	}
+	paths := func(backends ...netv1.IngressBackend) *netv1.HTTPIngressRuleValue {
+		v := &netv1.HTTPIngressRuleValue{}
+		for _, b := range backends {
+			v.Paths = append(v.Paths, netv1.HTTPIngressPath{Backend: b})
+		}
+		return v
+	}
+	defaultBackend := backend("default")
+	var tests = []struct {
+		name     string
+		object   client.Object
+		expected []string
+	}{
+		{name: "not ingress", object: &corev1.Service{}, expected: nil},
+		{name: "empty ingress", object: &netv1.Ingress{}, expected: nil},
+		{name: "default backend only", object: &netv1.Ingress{Spec: netv1.IngressSpec{DefaultBackend: &defaultBackend}},
+			expected: []string{"default"}},
+		{name: "rules without http", object: &netv1.Ingress{Spec: netv1.IngressSpec{Rules: []netv1.IngressRule{{Host: "a"}}}},
+			expected: nil},
+		{name: "resource backend", object: &netv1.Ingress{Spec: netv1.IngressSpec{Rules: []netv1.IngressRule{
+			{IngressRuleValue: netv1.IngressRuleValue{HTTP: paths(netv1.IngressBackend{Resource: &corev1.TypedLocalObjectReference{Name: "bucket"}})}}}}},
+			expected: nil},
+		{name: "default backend and rules with duplicates", object: &netv1.Ingress{Spec: netv1.IngressSpec{DefaultBackend: &defaultBackend,
+			Rules: []netv1.IngressRule{
+				{IngressRuleValue: netv1.IngressRuleValue{HTTP: paths(backend("frontend"), backend("api"))}},
+				{IngressRuleValue: netv1.IngressRuleValue{HTTP: paths(backend("frontend"), backend("default"))}},
+			}}},
+			expected: []string{"default", "frontend", "api"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// act
+			services := ingressBackendServices(test.object)
+			// assert
+			assert.Equal(t, test.expected, services)
+		})
+	}
+}
+
+func TestIngressRequestsForService(t *testing.T) {
+	// arrange
+	nginx := "nginx"
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "frontend"}}
+	ingresses := []netv1.Ingress{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing1"}, Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing2"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing3"}, Spec: netv1.IngressSpec{IngressClassName: &nginx}},
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			assert.Equal(t, "demo", lo.Namespace)
+			assert.Equal(t, ingressBackendServiceIndex+"=frontend", lo.FieldSelector.String())
+			list.Items = ingresses
+			return nil
+		})
+	selector := mapper.NewIngressSelector(&depresolver.Config{IngressClasses: []string{"nginx"}})
+
+	// act
+	requests, err := ingressRequestsForService(context.TODO(), c, selector, svc)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing3"}},
+	}, requests)
+}
+
+func TestIngressRequestsForServiceError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("list error"))
+	selector := mapper.NewIngressSelector(&depresolver.Config{})
+
+	// act
+	requests, err := ingressRequestsForService(context.TODO(), c, selector, &corev1.Service{})
+
+	// assert
+	assert.Error(t, err)
+	assert.Nil(t, requests)
+}
+
+func TestEndpointsUpdateEnqueuesReferencingIngresses(t *testing.T) {
+	// arrange
+	old := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "frontend", ResourceVersion: "1"},
+		Subsets: []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}}}
+	scaled := old.DeepCopy()
+	scaled.ResourceVersion = "2"
+	scaled.Subsets[0].Addresses = append(scaled.Subsets[0].Addresses, corev1.EndpointAddress{IP: "10.0.0.2"})
+	resynced := old.DeepCopy()
+	resynced.ResourceVersion = "3"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, opts ...client.ListOption) error {
+			list.Items = []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing1"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing2"}},
+			}
+			return nil
+		}).Times(2) // both old and new object are mapped
+	r := &AnnoReconciler{Client: c, Log: logging.Logger()}
+	h := r.serviceHandler(context.TODO(), mapper.NewIngressSelector(&depresolver.Config{}))
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	updated := event.UpdateEvent{ObjectOld: old, ObjectNew: scaled}
+
+	// act
+	if endpointsChanged.Update(updated) {
+		h.Update(updated, q)
+	}
+
+	// assert
+	assert.False(t, endpointsChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: resynced}))
+	assert.Equal(t, 2, q.Len())
+	var requests []reconcile.Request
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		requests = append(requests, item.(reconcile.Request))
+		q.Done(item)
+	}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing2"}},
+	}, requests)
+}
+
+func TestDefaultsConfigMapChangeEnqueuesAnnotatedIngresses(t *testing.T) {
+	// arrange
+	annotated := map[string]string{mapper.AnnotationStrategy: depresolver.RoundRobinStrategy}
+	old := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "k8gb", Name: "k8gb-defaults"},
+		Data: map[string]string{mapper.AnnotationDNSTTLSeconds: "30"}}
+	updated := old.DeepCopy()
+	updated.Data[mapper.AnnotationDNSTTLSeconds] = "60"
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			assert.Equal(t, "", lo.Namespace)
+			list.Items = []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing1", Annotations: annotated}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "plain"}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "ing2", Annotations: annotated}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "unwatched", Name: "ing3", Annotations: annotated}},
+			}
+			return nil
+		}).Times(2) // both old and new object are mapped
+	r := &AnnoReconciler{Client: c, Log: logging.Logger()}
+	h := r.defaultsHandler(context.TODO(), mapper.NewIngressSelector(&depresolver.Config{WatchNamespaces: []string{"demo", "other"}}))
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	// act
+	h.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: updated}, q)
+
+	// assert
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing1"}},
+		{NamespacedName: types.NamespacedName{Namespace: "other", Name: "ing2"}},
+	}, drainRequests(q))
+}
+
+func TestNamespaceAnnotationChangeEnqueuesIngresses(t *testing.T) {
+	// arrange
+	annotated := map[string]string{mapper.AnnotationStrategy: depresolver.RoundRobinStrategy}
+	old := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "demo", ResourceVersion: "1"}}
+	annotatedNs := old.DeepCopy()
+	annotatedNs.ResourceVersion = "2"
+	annotatedNs.Annotations = map[string]string{mapper.AnnotationDNSTTLSeconds: "60"}
+	labeledNs := old.DeepCopy()
+	labeledNs.ResourceVersion = "3"
+	labeledNs.Labels = map[string]string{"team": "a"}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(arg0 interface{}, list *netv1.IngressList, opts ...client.ListOption) error {
+			lo := &client.ListOptions{}
+			lo.ApplyOptions(opts)
+			assert.Equal(t, "demo", lo.Namespace)
+			list.Items = []netv1.Ingress{
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing1", Annotations: annotated}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "plain"}},
+			}
+			return nil
+		}).Times(2) // both old and new object are mapped
+	r := &AnnoReconciler{Client: c, Log: logging.Logger()}
+	h := r.defaultsHandler(context.TODO(), mapper.NewIngressSelector(&depresolver.Config{}))
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	updated := event.UpdateEvent{ObjectOld: old, ObjectNew: annotatedNs}
+
+	// act
+	if namespaceAnnotationsChanged.Update(updated) {
+		h.Update(updated, q)
+	}
+
+	// assert
+	assert.False(t, namespaceAnnotationsChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: labeledNs}))
+	assert.False(t, namespaceAnnotationsChanged.Create(event.CreateEvent{Object: annotatedNs}))
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing1"}},
+	}, drainRequests(q))
+}
+
+func drainRequests(q workqueue.RateLimitingInterface) (requests []reconcile.Request) {
+	for q.Len() > 0 {
+		item, _ := q.Get()
+		requests = append(requests, item.(reconcile.Request))
+		q.Done(item)
+	}
+	return requests
+}
+
+func TestSelectedIngresses(t *testing.T) {
+	// arrange
+	selected := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing", Labels: map[string]string{"owner": "team-a"}}}
+	deselected := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "ing", Labels: map[string]string{"owner": "team-b"}}}
+	p := selectedIngresses(mapper.NewIngressSelector(&depresolver.Config{IngressLabelSelector: "owner=team-a"}))
+
+	// act, assert
+	assert.True(t, p.Create(event.CreateEvent{Object: selected}))
+	assert.False(t, p.Create(event.CreateEvent{Object: deselected}))
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: selected, ObjectNew: deselected}), "transition must pass, so ingress is cleaned up")
+	assert.True(t, p.Update(event.UpdateEvent{ObjectOld: deselected, ObjectNew: selected}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: deselected, ObjectNew: deselected}))
+	assert.True(t, p.Delete(event.DeleteEvent{Object: selected}))
+	assert.False(t, p.Delete(event.DeleteEvent{Object: deselected}))
+	assert.False(t, p.Generic(event.GenericEvent{Object: &corev1.Service{}}))
+}
+
+func TestRateLimiterBackoff(t *testing.T) {
+	// arrange
+	rl := newRateLimiter(depresolver.RateLimiter{BaseDelayMilliseconds: 10, MaxDelaySeconds: 1, QPS: 10, Burst: 100})
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "demo", Name: "ing1"}}
+
+	// act, assert
+	assert.Equal(t, 10*time.Millisecond, rl.When(item))
+	assert.Equal(t, 20*time.Millisecond, rl.When(item))
+	assert.Equal(t, 40*time.Millisecond, rl.When(item))
+	for i := 0; i < 10; i++ {
+		rl.When(item)
+	}
+	assert.Equal(t, time.Second, rl.When(item))
+	rl.Forget(item)
+	assert.Equal(t, 10*time.Millisecond, rl.When(item))
+}
@@ -0,0 +1,172 @@
+package strategy
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+	"github.com/k8gb-io/k8gb-light/controllers/utils"
+)
+
+// Cluster is the state of a single k8gb cluster in simulation
+type Cluster struct {
+	GeoTag string `json:"geoTag"`
+	// Healthy is health of the host in the cluster; unhealthy cluster doesn't publish localtargets- record
+	Healthy bool `json:"healthy"`
+	// Targets are IPs exposed by the cluster
+	Targets []string `json:"targets"`
+	// Unreachable contains geo tags of clusters whose localtargets- records can't be resolved from this cluster
+	Unreachable []string `json:"unreachable,omitempty"`
+}
+
+// Scenario describes spec of the host and the state of all clusters
+type Scenario struct {
+	Spec     mapper.Spec `json:"spec"`
+	Clusters []Cluster   `json:"clusters"`
+}
+
+// Answer is DNS answer for the host served by a cluster
+type Answer struct {
+	GeoTag        string               `json:"geoTag"`
+	Targets       []string             `json:"targets"`
+	FailoverOrder mapper.PrimaryGeotag `json:"failoverOrder,omitempty"`
+	ActiveGeoTag  string               `json:"activeGeoTag,omitempty"`
+	Labels        map[string]string    `json:"labels,omitempty"`
+}
+
+// Variant is a distinct answer together with clusters serving it
+type Variant struct {
+	Targets []string `json:"targets"`
+	GeoTags []string `json:"geoTags"`
+}
+
+// Simulation contains answers of every cluster in order of scenario clusters. Clusters disagree when there is
+// more than one variant of answer; edge DNS then returns different answers depending on the queried nameserver
+type Simulation struct {
+	Answers  []Answer  `json:"answers"`
+	Variants []Variant `json:"variants"`
+}
+
+// Agree returns true when all clusters serve the same answer
+func (s *Simulation) Agree() bool {
+	return len(s.Variants) <= 1
+}
+
+// Simulate computes answer of every cluster in the same way as reconciliation does. Spec is validated
+// against geo tags of simulated clusters
+func Simulate(scenario Scenario) (*Simulation, error) {
+	geoTags, err := scenario.validate()
+	if err != nil {
+		return nil, err
+	}
+	published := assistant.NewTargets()
+	for _, c := range scenario.Clusters {
+		if c.Healthy && len(c.Targets) > 0 {
+			published.Append(c.GeoTag, append([]string{}, c.Targets...))
+		}
+	}
+	simulation := &Simulation{Answers: []Answer{}, Variants: []Variant{}}
+	variants := map[string]int{}
+	for _, c := range scenario.Clusters {
+		unreachable := map[string]bool{c.GeoTag: true}
+		for _, tag := range c.Unreachable {
+			unreachable[tag] = true
+		}
+		external := assistant.NewTargets()
+		for tag, t := range published {
+			if !unreachable[tag] {
+				external.Append(tag, append([]string{}, t.IPs...))
+			}
+		}
+		result := Compute(Input{
+			Spec:               scenario.Spec,
+			ClusterGeoTag:      c.GeoTag,
+			ExtClustersGeoTags: excludeGeoTag(geoTags, c.GeoTag),
+			Healthy:            c.Healthy,
+			LocalTargets:       append([]string{}, c.Targets...),
+			ExternalTargets:    external,
+		})
+		answer := Answer{
+			GeoTag:        c.GeoTag,
+			Targets:       result.Targets.GetIPs(),
+			FailoverOrder: result.FailoverOrder,
+			ActiveGeoTag:  result.ActiveGeoTag,
+			Labels:        result.Labels,
+		}
+		sort.Strings(answer.Targets)
+		simulation.Answers = append(simulation.Answers, answer)
+
+		key := strings.Join(answer.Targets, ",")
+		if i, found := variants[key]; found {
+			simulation.Variants[i].GeoTags = append(simulation.Variants[i].GeoTags, c.GeoTag)
+			continue
+		}
+		variants[key] = len(simulation.Variants)
+		simulation.Variants = append(simulation.Variants, Variant{Targets: answer.Targets, GeoTags: []string{c.GeoTag}})
+	}
+	return simulation, nil
+}
+
+// validate returns geo tags of all clusters
+func (s Scenario) validate() (geoTags []string, err error) {
+	if len(s.Clusters) == 0 {
+		return nil, fmt.Errorf("scenario has no clusters")
+	}
+	var strategies = []string{depresolver.GeoStrategy, depresolver.FailoverStrategy, depresolver.RoundRobinStrategy}
+	if !utils.Contains(strategies, s.Spec.Type) {
+		return nil, fmt.Errorf("unsupported strategy '%s'", s.Spec.Type)
+	}
+	known := map[string]bool{}
+	for _, c := range s.Clusters {
+		if c.GeoTag == "" {
+			return nil, fmt.Errorf("cluster without geo tag")
+		}
+		if known[c.GeoTag] {
+			return nil, fmt.Errorf("duplicate cluster '%s'", c.GeoTag)
+		}
+		known[c.GeoTag] = true
+		geoTags = append(geoTags, c.GeoTag)
+	}
+	for _, c := range s.Clusters {
+		for _, tag := range c.Unreachable {
+			if !known[tag] {
+				return nil, fmt.Errorf("cluster '%s' has unknown unreachable cluster '%s'", c.GeoTag, tag)
+			}
+		}
+	}
+	config := &depresolver.Config{ClusterGeoTag: geoTags[0], ExtClustersGeoTags: geoTags[1:]}
+	if err = mapper.ValidateSpec(s.Spec, config); err != nil {
+		return nil, err
+	}
+	return geoTags, nil
+}
+
+func excludeGeoTag(tags []string, tag string) (r []string) {
+	for _, t := range tags {
+		if t != tag {
+			r = append(r, t)
+		}
+	}
+	return r
+}
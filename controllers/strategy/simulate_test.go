@@ -0,0 +1,135 @@
+package strategy
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateRoundRobin(t *testing.T) {
+	// arrange
+	scenario := Scenario{
+		Spec: mapper.Spec{Type: "roundRobin", DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300},
+		Clusters: []Cluster{
+			{GeoTag: "eu", Healthy: true, Targets: []string{"10.0.0.1"}},
+			{GeoTag: "us", Healthy: false, Targets: []string{"10.1.0.1"}},
+			{GeoTag: "za", Healthy: true, Targets: []string{"10.2.0.1"}},
+		},
+	}
+	// act
+	simulation, err := Simulate(scenario)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, simulation.Agree())
+	assert.Equal(t, []Variant{{Targets: []string{"10.0.0.1", "10.2.0.1"}, GeoTags: []string{"eu", "us", "za"}}}, simulation.Variants)
+	assert.Len(t, simulation.Answers, 3)
+}
+
+func TestSimulateFailover(t *testing.T) {
+	// arrange
+	scenario := Scenario{
+		Spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "us", DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300},
+		Clusters: []Cluster{
+			{GeoTag: "eu", Healthy: true, Targets: []string{"10.0.0.1"}},
+			{GeoTag: "us", Healthy: false, Targets: []string{"10.1.0.1"}},
+			{GeoTag: "za", Healthy: true, Targets: []string{"10.2.0.1"}},
+		},
+	}
+	// act
+	simulation, err := Simulate(scenario)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, simulation.Agree())
+	for _, answer := range simulation.Answers {
+		assert.Equal(t, []string{"10.0.0.1"}, answer.Targets)
+		assert.Equal(t, "eu", answer.ActiveGeoTag)
+		assert.Equal(t, mapper.PrimaryGeotag{"us", "eu", "za"}, answer.FailoverOrder)
+	}
+}
+
+func TestSimulateDisagreement(t *testing.T) {
+	// arrange
+	scenario := Scenario{
+		Spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "eu", DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300},
+		Clusters: []Cluster{
+			{GeoTag: "eu", Healthy: true, Targets: []string{"10.0.0.1"}},
+			{GeoTag: "us", Healthy: true, Targets: []string{"10.1.0.1"}, Unreachable: []string{"eu"}},
+		},
+	}
+	// act
+	simulation, err := Simulate(scenario)
+	// assert
+	require.NoError(t, err)
+	assert.False(t, simulation.Agree())
+	assert.Equal(t, []Variant{
+		{Targets: []string{"10.0.0.1"}, GeoTags: []string{"eu"}},
+		{Targets: []string{"10.1.0.1"}, GeoTags: []string{"us"}},
+	}, simulation.Variants)
+}
+
+func TestSimulateUnhealthyClusterWithoutPeers(t *testing.T) {
+	// arrange
+	scenario := Scenario{
+		Spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "eu", DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300},
+		Clusters: []Cluster{
+			{GeoTag: "eu", Healthy: false, Targets: []string{"10.0.0.1"}},
+			{GeoTag: "us", Healthy: false, Targets: []string{"10.1.0.1"}},
+		},
+	}
+	// act
+	simulation, err := Simulate(scenario)
+	// assert
+	require.NoError(t, err)
+	assert.True(t, simulation.Agree())
+	assert.Equal(t, []string{}, simulation.Variants[0].Targets)
+}
+
+func TestSimulateInvalidScenario(t *testing.T) {
+	spec := mapper.Spec{Type: "roundRobin", DNSTtlSeconds: 30, SplitBrainThresholdSeconds: 300}
+	var tests = []struct {
+		name     string
+		scenario Scenario
+		expected string
+	}{
+		{name: "no clusters", scenario: Scenario{Spec: spec}, expected: "scenario has no clusters"},
+		{name: "unsupported strategy", scenario: Scenario{Spec: mapper.Spec{Type: "random"}, Clusters: []Cluster{{GeoTag: "eu"}}},
+			expected: "unsupported strategy 'random'"},
+		{name: "duplicate cluster", scenario: Scenario{Spec: spec, Clusters: []Cluster{{GeoTag: "eu"}, {GeoTag: "eu"}}},
+			expected: "duplicate cluster 'eu'"},
+		{name: "unknown unreachable", scenario: Scenario{Spec: spec, Clusters: []Cluster{{GeoTag: "eu", Unreachable: []string{"us"}}}},
+			expected: "cluster 'eu' has unknown unreachable cluster 'us'"},
+		{name: "unknown primary", scenario: Scenario{Spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "us", DNSTtlSeconds: 30,
+			SplitBrainThresholdSeconds: 300}, Clusters: []Cluster{{GeoTag: "eu"}}},
+			expected: "'k8gb.io/primary-geotag' contains unknown geo tag 'us', allowed geo tags [eu]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			// act
+			_, err := Simulate(test.scenario)
+			// assert
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
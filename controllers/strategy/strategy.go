@@ -0,0 +1,98 @@
+package strategy
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"fmt"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+)
+
+// Input of target computation for a single host from the point of view of one cluster
+type Input struct {
+	Spec               mapper.Spec
+	ClusterGeoTag      string
+	ExtClustersGeoTags []string
+	// Healthy is health of the host in the local cluster
+	Healthy bool
+	// LocalTargets are IPs exposed by the local cluster
+	LocalTargets []string
+	// ExternalTargets are localtargets- records resolved from external clusters
+	ExternalTargets assistant.Targets
+}
+
+// Result of target computation
+type Result struct {
+	// Targets of the host DNS record
+	Targets assistant.Targets
+	// FailoverOrder and ActiveGeoTag are set by failover strategy when external targets exist
+	FailoverOrder mapper.PrimaryGeotag
+	ActiveGeoTag  string
+	// IsPrimary is true when failover strategy selects the local cluster
+	IsPrimary bool
+	// Labels of the host DNS record; strategy and weights
+	Labels map[string]string
+}
+
+// Compute returns targets of the host DNS record. Healthy cluster serves own targets; external targets are added
+// for roundRobin and geoip strategies, while failover strategy serves only targets of the first available cluster
+// in failover order. The function has no side effects, so it is shared by reconciliation and simulation
+func Compute(in Input) (result Result) {
+	result.Targets = assistant.NewTargets()
+	if in.Healthy {
+		result.Targets.Append(in.ClusterGeoTag, append([]string{}, in.LocalTargets...))
+	}
+	if len(in.ExternalTargets) > 0 {
+		// targets are appended by reference, so work on a copy not to modify caller's targets
+		external := in.ExternalTargets.Copy()
+		switch in.Spec.Type {
+		case depresolver.RoundRobinStrategy, depresolver.GeoStrategy:
+			external.Sort()
+			result.Targets.AppendTargets(external)
+		case depresolver.FailoverStrategy:
+			result.Targets.AppendTargets(external)
+			result.FailoverOrder = (&mapper.LoopState{Spec: in.Spec}).GetFailoverOrderedGeotagList(in.ClusterGeoTag, in.ExtClustersGeoTags)
+			result.Targets, result.ActiveGeoTag = result.Targets.FailoverProjection(result.FailoverOrder)
+			result.IsPrimary = result.ActiveGeoTag == in.ClusterGeoTag
+		}
+	}
+	result.Labels = map[string]string{"strategy": in.Spec.Type}
+	for k, v := range getWeightLabels(in.Spec, result.Targets) {
+		result.Labels[k] = v
+	}
+	return result
+}
+
+// getWeightLabels map of where key identifies region and weight, value identifies IP.
+func getWeightLabels(spec mapper.Spec, targets assistant.Targets) (labels map[string]string) {
+	labels = make(map[string]string, 0)
+	for k, v := range spec.Weights {
+		t, found := targets[k]
+		if !found {
+			continue
+		}
+		for i, ip := range t.IPs {
+			l := fmt.Sprintf("weight-%s-%v-%v", k, i, v)
+			labels[l] = ip
+		}
+	}
+	return labels
+}
@@ -0,0 +1,106 @@
+package strategy
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/assistant"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	var tests = []struct {
+		name              string
+		spec              mapper.Spec
+		healthy           bool
+		external          assistant.Targets
+		expectedTargets   assistant.Targets
+		expectedActive    string
+		expectedIsPrimary bool
+	}{
+		{name: "round robin healthy", spec: mapper.Spec{Type: "roundRobin"}, healthy: true,
+			external:        assistant.Targets{"us": {IPs: []string{"10.1.0.2", "10.1.0.1"}}},
+			expectedTargets: assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}, "us": {IPs: []string{"10.1.0.1", "10.1.0.2"}}}},
+		{name: "round robin unhealthy", spec: mapper.Spec{Type: "roundRobin"}, healthy: false,
+			external:        assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}},
+			expectedTargets: assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}}},
+		{name: "geoip without external targets", spec: mapper.Spec{Type: "geoip"}, healthy: true,
+			external:        assistant.Targets{},
+			expectedTargets: assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}}},
+		{name: "failover healthy primary", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "eu"}, healthy: true,
+			external:        assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}},
+			expectedTargets: assistant.Targets{"eu": {IPs: []string{"10.0.0.1"}}}, expectedActive: "eu", expectedIsPrimary: true},
+		{name: "failover unhealthy primary", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "eu"}, healthy: false,
+			external:        assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}},
+			expectedTargets: assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}}, expectedActive: "us"},
+		{name: "failover healthy secondary", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "us"}, healthy: true,
+			external:        assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}},
+			expectedTargets: assistant.Targets{"us": {IPs: []string{"10.1.0.1"}}}, expectedActive: "us"},
+		{name: "failover unhealthy without external targets", spec: mapper.Spec{Type: "failover", PrimaryGeoTag: "eu"},
+			external: assistant.Targets{}, expectedTargets: assistant.Targets{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			in := Input{Spec: test.spec, ClusterGeoTag: "eu", ExtClustersGeoTags: []string{"us"}, Healthy: test.healthy,
+				LocalTargets: []string{"10.0.0.1"}, ExternalTargets: test.external}
+			// act
+			result := Compute(in)
+			// assert
+			assert.Equal(t, test.expectedTargets, result.Targets)
+			assert.Equal(t, test.expectedActive, result.ActiveGeoTag)
+			assert.Equal(t, test.expectedIsPrimary, result.IsPrimary)
+			assert.Equal(t, test.spec.Type, result.Labels["strategy"])
+		})
+	}
+}
+
+func TestComputeDoesNotModifyInput(t *testing.T) {
+	// arrange
+	local := []string{"10.0.0.2", "10.0.0.1"}
+	external := assistant.Targets{"us": {IPs: []string{"10.1.0.2", "10.1.0.1"}}}
+	in := Input{Spec: mapper.Spec{Type: "roundRobin"}, ClusterGeoTag: "eu", ExtClustersGeoTags: []string{"us"}, Healthy: true,
+		LocalTargets: local, ExternalTargets: external}
+	// act
+	result := Compute(in)
+	result.Targets["eu"].IPs[0] = "10.0.0.3"
+	// assert
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.1"}, local)
+	assert.Equal(t, assistant.Targets{"us": {IPs: []string{"10.1.0.2", "10.1.0.1"}}}, external)
+	assert.Equal(t, []string{"10.1.0.1", "10.1.0.2"}, result.Targets["us"].IPs)
+}
+
+func TestComputeWeightLabels(t *testing.T) {
+	// arrange
+	in := Input{Spec: mapper.Spec{Type: "roundRobin", Weights: map[string]int{"eu": 30, "us": 70, "za": 0}}, ClusterGeoTag: "eu",
+		ExtClustersGeoTags: []string{"us", "za"}, Healthy: true, LocalTargets: []string{"10.0.0.1"},
+		ExternalTargets: assistant.Targets{"us": {IPs: []string{"10.1.0.1", "10.1.0.2"}}}}
+	// act
+	result := Compute(in)
+	// assert
+	assert.Equal(t, map[string]string{
+		"strategy":       "roundRobin",
+		"weight-eu-0-30": "10.0.0.1",
+		"weight-us-0-70": "10.1.0.1",
+		"weight-us-1-70": "10.1.0.2",
+	}, result.Labels)
+}
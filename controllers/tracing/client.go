@@ -0,0 +1,83 @@
+package tracing
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Client starts span for every Kubernetes API call. Spans are children of the span carried by context
+// of the call, calls are not traced when the context carries no span
+type Client struct {
+	client.Client
+}
+
+// NewClient wraps c by Client
+func NewClient(c client.Client) *Client {
+	return &Client{Client: c}
+}
+
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) (err error) {
+	ctx, span := start(ctx, "Get", obj, key.Namespace, key.Name)
+	defer func() { End(span, err) }()
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) (err error) {
+	listOpts := (&client.ListOptions{}).ApplyOptions(opts)
+	ctx, span := start(ctx, "List", list, listOpts.Namespace, "")
+	defer func() { End(span, err) }()
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) (err error) {
+	ctx, span := start(ctx, "Create", obj, obj.GetNamespace(), obj.GetName())
+	defer func() { End(span, err) }()
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) (err error) {
+	ctx, span := start(ctx, "Update", obj, obj.GetNamespace(), obj.GetName())
+	defer func() { End(span, err) }()
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) (err error) {
+	ctx, span := start(ctx, "Patch", obj, obj.GetNamespace(), obj.GetName())
+	defer func() { End(span, err) }()
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) (err error) {
+	ctx, span := start(ctx, "Delete", obj, obj.GetNamespace(), obj.GetName())
+	defer func() { End(span, err) }()
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+// start names the span by verb and kind of obj, e.g. "Get Ingress"
+func start(ctx context.Context, verb string, obj interface{}, namespace, name string) (context.Context, trace.Span) {
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+	return Start(ctx, fmt.Sprintf("%s %s", verb, kind),
+		KindKey.String(kind), NamespaceKey.String(namespace), NameKey.String(name))
+}
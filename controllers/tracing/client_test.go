@@ -0,0 +1,73 @@
+package tracing
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClientSpans(t *testing.T) {
+	// arrange
+	scheme := runtime.NewScheme()
+	require.NoError(t, netv1.AddToScheme(scheme))
+	ing := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "app"}}
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(ing).Build())
+	ctx, recorder := parentContext()
+	// act
+	errGet := c.Get(ctx, types.NamespacedName{Namespace: "demo", Name: "app"}, &netv1.Ingress{})
+	errList := c.List(ctx, &netv1.IngressList{}, client.InNamespace("demo"))
+	errDelete := c.Delete(ctx, &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "missing"}})
+	// assert
+	assert.NoError(t, errGet)
+	assert.NoError(t, errList)
+	assert.Error(t, errDelete)
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "Get Ingress", spans[0].Name())
+	assert.ElementsMatch(t, spans[0].Attributes(), []attribute.KeyValue{
+		KindKey.String("Ingress"), NamespaceKey.String("demo"), NameKey.String("app")})
+	assert.Equal(t, "List IngressList", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), NamespaceKey.String("demo"))
+	assert.Equal(t, "Delete Ingress", spans[2].Name())
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
+
+func TestClientWithoutParentSpan(t *testing.T) {
+	// arrange
+	scheme := runtime.NewScheme()
+	require.NoError(t, netv1.AddToScheme(scheme))
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+	// act
+	err := c.Create(context.Background(), &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "demo", Name: "app"}})
+	// assert
+	assert.NoError(t, err)
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "demo", Name: "app"}, &netv1.Ingress{}))
+}
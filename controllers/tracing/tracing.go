@@ -20,44 +20,92 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"math"
+	"os"
 
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.6.1"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	instrumentationName = "github.com/k8gb-io/k8gb"
 )
 
+// exporter protocols
+const (
+	ProtocolHTTP = "http/protobuf"
+	ProtocolGRPC = "grpc"
+)
+
+// span attributes
+const (
+	GeoTagKey     = attribute.Key("k8gb.geotag")
+	RecordKey     = attribute.Key("dns.record")
+	NameserverKey = attribute.Key("dns.nameserver")
+	KindKey       = attribute.Key("k8s.kind")
+	NamespaceKey  = attribute.Key("k8s.namespace")
+	NameKey       = attribute.Key("k8s.name")
+)
+
+// resource attributes
+const (
+	DNSZoneKey = attribute.Key("k8gb.dns_zone")
+	CommitKey  = attribute.Key("k8gb.commit")
+)
+
 type Settings struct {
 	Enabled       bool
 	Endpoint      string
 	SamplingRatio float64
 	Commit        string
 	AppVersion    string
+	// Protocol of the exporter; ProtocolHTTP or ProtocolGRPC
+	Protocol string
+	// Insecure sends spans without TLS
+	Insecure bool
+	// CAFile verifies the collector; system roots are used when empty
+	CAFile string
+	// ClientCertFile and ClientKeyFile authenticate k8gb to the collector
+	ClientCertFile string
+	ClientKeyFile  string
+	// Headers are sent with each export request
+	Headers map[string]string
+	// ResourceAttributes are added to the resource. They can't override attributes set by k8gb
+	ResourceAttributes map[string]string
+	GeoTag             string
+	DNSZone            string
 }
 
-func SetupTracing(ctx context.Context, cfg Settings, log *zerolog.Logger) (func(), trace.Tracer) {
+func SetupTracing(ctx context.Context, cfg Settings, log *zerolog.Logger) (func(), trace.Tracer, error) {
 	if !cfg.Enabled {
 		log.Info().Msg("OTLP tracing is disabled")
-		return func() {}, trace.NewNoopTracerProvider().Tracer(instrumentationName)
+		return func() {}, trace.NewNoopTracerProvider().Tracer(instrumentationName), nil
+	}
+	log.Info().
+		Str("protocol", cfg.Protocol).
+		Str("endpoint", cfg.Endpoint).
+		Bool("insecure", cfg.Insecure).
+		Msg("OTLP tracing is ON")
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, nil, err
 	}
-	log.Info().Msg("OTLP tracing is ON")
-	client := otlptracehttp.NewClient(
-		otlptracehttp.WithEndpoint(cfg.Endpoint),
-		otlptracehttp.WithInsecure(),
-	)
 	exporter, err := otlptrace.New(ctx, client)
 	if err != nil {
-		log.Err(err).Msg("creating OTLP trace exporter")
+		return nil, nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
 	}
 	var samplerOption sdktrace.TracerProviderOption
 	eps := 0.0001
@@ -71,7 +119,7 @@ func SetupTracing(ctx context.Context, cfg Settings, log *zerolog.Logger) (func(
 
 	tracerProvider := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(newResource(cfg.Commit)),
+		sdktrace.WithResource(newResource(cfg)),
 		samplerOption,
 	)
 	otel.SetTracerProvider(tracerProvider)
@@ -84,13 +132,91 @@ func SetupTracing(ctx context.Context, cfg Settings, log *zerolog.Logger) (func(
 		if err := tracerProvider.Shutdown(ctx); err != nil {
 			log.Err(err).Msg("stopping tracer provider")
 		}
-	}, tracer
+	}, tracer, nil
 }
 
-func newResource(commit string) *resource.Resource {
-	return resource.NewWithAttributes(
-		semconv.SchemaURL,
+// newClient creates OTLP client of the protocol from cfg
+func newClient(cfg Settings) (otlptrace.Client, error) {
+	var tlsCfg *tls.Config
+	if !cfg.Insecure {
+		var err error
+		tlsCfg, err = newTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch cfg.Protocol {
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint), otlptracegrpc.WithHeaders(cfg.Headers)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		} else {
+			opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+		}
+		return otlptracegrpc.NewClient(opts...), nil
+	case ProtocolHTTP, "":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint), otlptracehttp.WithHeaders(cfg.Headers)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		} else {
+			opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+		}
+		return otlptracehttp.NewClient(opts...), nil
+	}
+	return nil, fmt.Errorf("unsupported OTLP protocol '%s'", cfg.Protocol)
+}
+
+// newTLSConfig loads CA and client certificates from cfg
+func newTLSConfig(cfg Settings) (*tls.Config, error) {
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading tracing CA file: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificate found in tracing CA file '%s'", cfg.CAFile)
+		}
+	}
+	if cfg.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading tracing client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
+func newResource(cfg Settings) *resource.Resource {
+	attrs := make([]attribute.KeyValue, 0, len(cfg.ResourceAttributes)+5)
+	for k, v := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	// the last value of duplicated key is kept, so k8gb attributes take precedence over configured ones
+	attrs = append(attrs,
 		semconv.ServiceNameKey.String("k8gb"),
-		semconv.ServiceVersionKey.String(commit),
+		semconv.ServiceVersionKey.String(cfg.AppVersion),
+		CommitKey.String(cfg.Commit),
+		GeoTagKey.String(cfg.GeoTag),
+		DNSZoneKey.String(cfg.DNSZone),
 	)
+	return resource.NewWithAttributes(semconv.SchemaURL, attrs...)
+}
+
+// Start starts child span of the span carried by ctx. The child is created by tracer provider of the parent,
+// so nothing is recorded when tracing is disabled or ctx carries no span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationName).
+		Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err in span, if any, and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
 }
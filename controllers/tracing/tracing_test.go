@@ -0,0 +1,232 @@
+package tracing
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.6.1"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// parentContext returns context carrying span of tracer provider which records ended spans
+func parentContext() (context.Context, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	ctx, _ := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test").Start(context.Background(), "parent")
+	return ctx, recorder
+}
+
+func TestStartWithoutParent(t *testing.T) {
+	// arrange
+	// act
+	ctx, span := Start(context.Background(), "child")
+	End(span, nil)
+	// assert
+	assert.False(t, span.IsRecording())
+	assert.False(t, span.SpanContext().IsValid())
+	assert.NotNil(t, ctx)
+}
+
+func TestStartWithParent(t *testing.T) {
+	// arrange
+	ctx, recorder := parentContext()
+	// act
+	_, span := Start(ctx, "child", GeoTagKey.String("za"))
+	End(span, nil)
+	// assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "child", spans[0].Name())
+	assert.Equal(t, oteltrace.SpanContextFromContext(ctx).SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), GeoTagKey.String("za"))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+}
+
+func TestEndWithError(t *testing.T) {
+	// arrange
+	ctx, recorder := parentContext()
+	_, span := Start(ctx, "child")
+	// act
+	End(span, fmt.Errorf("timeout"))
+	// assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "timeout", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+func TestSetupTracingDisabled(t *testing.T) {
+	// arrange
+	log := zerolog.Nop()
+	// act
+	cleanup, tracer, err := SetupTracing(context.Background(), Settings{Enabled: false}, &log)
+	// assert
+	require.NoError(t, err)
+	defer cleanup()
+	_, span := tracer.Start(context.Background(), "reconcile")
+	assert.False(t, span.IsRecording())
+}
+
+func TestSetupTracing(t *testing.T) {
+	caFile, certFile, keyFile := writeCertificate(t)
+	var tests = []struct {
+		name     string
+		settings Settings
+	}{
+		{name: "insecure http", settings: Settings{Protocol: ProtocolHTTP, Insecure: true}},
+		{name: "insecure grpc", settings: Settings{Protocol: ProtocolGRPC, Insecure: true}},
+		{name: "http with system roots", settings: Settings{Protocol: ProtocolHTTP}},
+		{name: "grpc with mTLS", settings: Settings{Protocol: ProtocolGRPC, CAFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile,
+			Headers: map[string]string{"authorization": "Bearer abc"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			log := zerolog.Nop()
+			test.settings.Enabled = true
+			test.settings.Endpoint = "localhost:4317"
+			test.settings.SamplingRatio = 1.0
+			// act
+			cleanup, tracer, err := SetupTracing(context.Background(), test.settings, &log)
+			// assert
+			require.NoError(t, err)
+			// span is not ended, so cleanup has nothing to flush to the missing collector
+			_, span := tracer.Start(context.Background(), "reconcile")
+			assert.True(t, span.IsRecording())
+			cleanup()
+		})
+	}
+}
+
+func TestSetupTracingWithInvalidSettings(t *testing.T) {
+	caFile, certFile, _ := writeCertificate(t)
+	var tests = []struct {
+		name     string
+		settings Settings
+	}{
+		{name: "unknown protocol", settings: Settings{Protocol: "thrift", Insecure: true}},
+		{name: "missing CA file", settings: Settings{Protocol: ProtocolGRPC, CAFile: filepath.Join(t.TempDir(), "ca.crt")}},
+		{name: "CA file without certificate", settings: Settings{Protocol: ProtocolHTTP, CAFile: writeFile(t, "ca.crt", "not a certificate")}},
+		{name: "client key mismatch", settings: Settings{Protocol: ProtocolHTTP, CAFile: caFile, ClientCertFile: certFile, ClientKeyFile: caFile}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			log := zerolog.Nop()
+			test.settings.Enabled = true
+			test.settings.Endpoint = "localhost:4318"
+			// act
+			_, _, err := SetupTracing(context.Background(), test.settings, &log)
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	// arrange
+	caFile, certFile, keyFile := writeCertificate(t)
+	// act
+	tlsCfg, err := newTLSConfig(Settings{CAFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile})
+	// assert
+	require.NoError(t, err)
+	assert.NotNil(t, tlsCfg.RootCAs)
+	assert.Len(t, tlsCfg.Certificates, 1)
+}
+
+func TestNewResource(t *testing.T) {
+	// arrange
+	cfg := Settings{
+		AppVersion: "v0.11.4",
+		Commit:     "6a8b2f1",
+		GeoTag:     "eu",
+		DNSZone:    "cloud.example.com",
+		ResourceAttributes: map[string]string{
+			"deployment.environment": "prod",
+			"k8gb.geotag":            "us",
+		},
+	}
+	// act
+	r := newResource(cfg)
+	// assert
+	attrs := r.Set()
+	for _, expected := range []attribute.KeyValue{
+		semconv.ServiceNameKey.String("k8gb"),
+		semconv.ServiceVersionKey.String("v0.11.4"),
+		CommitKey.String("6a8b2f1"),
+		GeoTagKey.String("eu"),
+		DNSZoneKey.String("cloud.example.com"),
+		attribute.String("deployment.environment", "prod"),
+	} {
+		v, found := attrs.Value(expected.Key)
+		assert.True(t, found, expected.Key)
+		assert.Equal(t, expected.Value, v, expected.Key)
+	}
+}
+
+// writeCertificate writes self-signed certificate and its key to temporary directory. The certificate is used
+// both as CA and client certificate
+func writeCertificate(t *testing.T) (caFile, certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "k8gb"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return writeFile(t, "ca.crt", certPEM), writeFile(t, "tls.crt", certPEM), writeFile(t, "tls.key", keyPEM)
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -35,14 +36,14 @@ func NewUDPDig(edgeDNSServers ...DNSServer) *UDPDig {
 
 // DigA returns a list of IP A-addresses for a given FQDN by using the dns servers from edgeDNSServers
 // dns servers are tried one by one from the edgeDNSServers and if there is a non-error response it is returned and the rest is not tried
-func (u *UDPDig) DigA(fqdn string) (ips []string, err error) {
+func (u *UDPDig) DigA(ctx context.Context, fqdn string) (ips []string, err error) {
 	if len(fqdn) == 0 {
 		return
 	}
 	fqdn = dns.Fqdn(fqdn)
 	msg := new(dns.Msg)
 	msg.SetQuestion(fqdn, dns.TypeA)
-	ack, err := u.exchange(msg)
+	ack, err := u.exchange(ctx, msg)
 	if err != nil {
 		return nil, fmt.Errorf("dig error: %s", err)
 	}
@@ -53,13 +54,16 @@ func (u *UDPDig) DigA(fqdn string) (ips []string, err error) {
 	return
 }
 
-func (u *UDPDig) exchange(m *dns.Msg) (msg *dns.Msg, err error) {
+func (u *UDPDig) exchange(ctx context.Context, m *dns.Msg) (msg *dns.Msg, err error) {
 	for _, ns := range u.edgeDNSServers {
 		if ns.Host == "" {
 			return nil, fmt.Errorf("empty edgeDNSServer.Host in the list")
 		}
-		msg, err = dns.Exchange(m, ns.String())
+		msg, err = dns.ExchangeContext(ctx, m, ns.String())
 		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
 			continue
 		}
 		return
@@ -19,6 +19,7 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 */
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -36,7 +37,7 @@ type Digger interface {
 	// DigA returns a list of IP A-addresses for a given FQDN by using the dns servers from edgeDNSServers
 	// dns servers are tried one by one from the edgeDNSServers and if there is a non-error response it is returned and
 	// the rest is not tried
-	DigA(fqdn string) (ips []string, err error)
+	DigA(ctx context.Context, fqdn string) (ips []string, err error)
 }
 
 func (s DNSServer) String() string {
@@ -51,7 +52,9 @@ func (l DNSList) String() string {
 	return strings.Join(aux, ",")
 }
 
-func Exchange(m *dns.Msg, edgeDNSServers []DNSServer) (msg *dns.Msg, err error) {
+// Exchange sends m to edgeDNSServers one by one and returns the first answer. The servers left are not tried
+// when ctx is canceled
+func Exchange(ctx context.Context, m *dns.Msg, edgeDNSServers []DNSServer) (msg *dns.Msg, err error) {
 	if len(edgeDNSServers) == 0 {
 		return nil, fmt.Errorf("empty edgeDNSServers, provide at least one")
 	}
@@ -59,11 +62,14 @@ func Exchange(m *dns.Msg, edgeDNSServers []DNSServer) (msg *dns.Msg, err error)
 		if ns.Host == "" {
 			return nil, fmt.Errorf("empty edgeDNSServer.Host in the list")
 		}
-		msg, err = dns.Exchange(m, ns.String())
+		msg, err = dns.ExchangeContext(ctx, m, ns.String())
 		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
 			continue
 		}
 		return
 	}
-	return nil, fmt.Errorf("exchange error: all dns servers were tried and none of them were able to resolve, err: %s", err)
+	return nil, fmt.Errorf("exchange error: all dns servers were tried and none of them were able to resolve, err: %w", err)
 }
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,7 +44,7 @@ func TestValidDigFQDNWithDot(t *testing.T) {
 	}
 	fqdn := defaultFqdn
 	// act
-	result, err := NewUDPDig(defaultEdgeDNSServer).DigA(fqdn + ".")
+	result, err := NewUDPDig(defaultEdgeDNSServer).DigA(context.TODO(), fqdn+".")
 	// assert
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -57,7 +58,7 @@ func TestValidDig(t *testing.T) {
 	}
 	fqdn := defaultFqdn
 	// act
-	result, err := NewUDPDig(defaultEdgeDNSServer).DigA(fqdn)
+	result, err := NewUDPDig(defaultEdgeDNSServer).DigA(context.TODO(), fqdn)
 	// assert
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -71,7 +72,7 @@ func TestEmptyFQDNButValidEdgeDNS(t *testing.T) {
 	}
 	fqdn := ""
 	// act
-	result, err := NewUDPDig(defaultEdgeDNSServer).DigA(fqdn)
+	result, err := NewUDPDig(defaultEdgeDNSServer).DigA(context.TODO(), fqdn)
 	// assert
 	assert.NoError(t, err)
 	assert.Nil(t, result)
@@ -81,7 +82,7 @@ func TestEmptyEdgeDNS(t *testing.T) {
 	// arrange
 	fqdn := "whatever"
 	// act
-	result, err := NewUDPDig(DNSServer{Host: "", Port: 53}).DigA(fqdn)
+	result, err := NewUDPDig(DNSServer{Host: "", Port: 53}).DigA(context.TODO(), fqdn)
 	// assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -91,7 +92,7 @@ func TestEmptyDNSList(t *testing.T) {
 	// arrange
 	fqdn := "whatever"
 	// act
-	result, err := NewUDPDig([]DNSServer{}...).DigA(fqdn)
+	result, err := NewUDPDig([]DNSServer{}...).DigA(context.TODO(), fqdn)
 	// assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -110,7 +111,7 @@ func TestOneValidEdgeDNSInTheList(t *testing.T) {
 	}
 	fqdn := defaultFqdn
 	// act
-	result, err := NewUDPDig(edgeDNSServers...).DigA(fqdn)
+	result, err := NewUDPDig(edgeDNSServers...).DigA(context.TODO(), fqdn)
 	// assert
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -126,7 +127,7 @@ func TestNoValidEdgeDNSInTheList(t *testing.T) {
 	}
 	fqdn := defaultFqdn
 	// act
-	result, err := NewUDPDig(edgeDNSServers...).DigA(fqdn)
+	result, err := NewUDPDig(edgeDNSServers...).DigA(context.TODO(), fqdn)
 	// assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -141,7 +142,7 @@ func TestEmptyEdgeDNSInTheList(t *testing.T) {
 	}
 	fqdn := defaultFqdn
 	// act
-	result, err := NewUDPDig(edgeDNSServers...).DigA(fqdn)
+	result, err := NewUDPDig(edgeDNSServers...).DigA(context.TODO(), fqdn)
 	// assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -159,7 +160,7 @@ func TestMultipleValidEdgeDNSInTheList(t *testing.T) {
 	}
 	fqdn := defaultFqdn
 	// act
-	result, err := NewUDPDig(edgeDNSServers...).DigA(fqdn)
+	result, err := NewUDPDig(edgeDNSServers...).DigA(context.TODO(), fqdn)
 	// assert
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -171,7 +172,7 @@ func TestValidEdgeDNSButNonExistingFQDN(t *testing.T) {
 	edgeDNSServer := "localhost"
 	fqdn := "some-valid-ip-fqdn-123"
 	// act
-	result, err := NewUDPDig(DNSServer{Host: edgeDNSServer, Port: 53}).DigA(fqdn)
+	result, err := NewUDPDig(DNSServer{Host: edgeDNSServer, Port: 53}).DigA(context.TODO(), fqdn)
 	// assert
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -192,3 +193,20 @@ func connected() (ok bool) {
 	}
 	return res.Body.Close() == nil
 }
+
+func TestExchangeCanceled(t *testing.T) {
+	// arrange
+	edgeDNSServers := []DNSServer{
+		{Host: "127.0.0.1", Port: 153},
+		{Host: "", Port: 53}, // not reached
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(defaultFqdn), dns.TypeA)
+	// act
+	result, err := Exchange(ctx, m, edgeDNSServers)
+	// assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, result)
+}
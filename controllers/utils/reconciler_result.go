@@ -21,16 +21,20 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 import (
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 type ReconcileResultHandler struct {
-	delayedResult ctrl.Result
+	requeueAfter time.Duration
+	jitterFactor float64
 }
 
-func NewReconcileResultHandler(reconcileAfter int) *ReconcileResultHandler {
+// NewReconcileResultHandler requeues loop after reconcileAfter seconds, randomly prolonged by up to jitterPercent
+func NewReconcileResultHandler(reconcileAfter, jitterPercent int) *ReconcileResultHandler {
 	return &ReconcileResultHandler{
-		delayedResult: ctrl.Result{RequeueAfter: time.Second * time.Duration(reconcileAfter)},
+		requeueAfter: time.Second * time.Duration(reconcileAfter),
+		jitterFactor: float64(jitterPercent) / 100,
 	}
 }
 
@@ -46,12 +50,16 @@ func (r *ReconcileResultHandler) RequeueError(err error) (ctrl.Result, error) {
 	return ctrl.Result{}, err
 }
 
-// Requeue requeue loop after config.ReconcileRequeueSeconds
+// Requeue requeue loop after config.ReconcileRequeueSeconds prolonged by jitter,
+// so requeued ingresses don't query Edge DNS at the same time.
 // this apply in case you didn't modify request resources.
 // If so, reconciliation starts immediately
 // see: https://github.com/operator-framework/operator-sdk/issues/1164
 func (r *ReconcileResultHandler) Requeue() (ctrl.Result, error) {
-	return r.delayedResult, nil
+	if r.jitterFactor <= 0 {
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+	return ctrl.Result{RequeueAfter: wait.Jitter(r.requeueAfter, r.jitterFactor)}, nil
 }
 
 func (r *ReconcileResultHandler) RequeueNow() (ctrl.Result, error) {
@@ -0,0 +1,61 @@
+package utils
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequeueWithoutJitter(t *testing.T) {
+	// arrange
+	r := NewReconcileResultHandler(30, 0)
+	// act
+	result, err := r.Requeue()
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, result.RequeueAfter)
+}
+
+func TestRequeueWithJitter(t *testing.T) {
+	// arrange
+	r := NewReconcileResultHandler(30, 10)
+	for i := 0; i < 100; i++ {
+		// act
+		result, err := r.Requeue()
+		// assert
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, result.RequeueAfter, 30*time.Second)
+		assert.LessOrEqual(t, result.RequeueAfter, 33*time.Second)
+	}
+}
+
+func TestRequeueError(t *testing.T) {
+	// arrange
+	r := NewReconcileResultHandler(30, 10)
+	// act
+	result, err := r.RequeueError(fmt.Errorf("reconcile error"))
+	// assert
+	assert.Error(t, err)
+	assert.Equal(t, time.Duration(0), result.RequeueAfter)
+	assert.False(t, result.Requeue)
+}
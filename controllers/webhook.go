@@ -0,0 +1,113 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+
+	"github.com/rs/zerolog"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// IngressValidator is validating admission webhook rejecting ingresses with invalid k8gb annotations.
+// It runs the same parsing as reconciliation, including defaults, followed by validation against the operator configuration
+type IngressValidator struct {
+	Config   *depresolver.Config
+	Defaults *mapper.DefaultsResolver
+	Log      *zerolog.Logger
+}
+
+// ValidateCreate validates annotations of created ingress
+func (v *IngressValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates annotations of updated ingress. Ingresses with unchanged k8gb annotations are accepted,
+// so the operator and other controllers can update ingresses which were valid before the configuration changed
+func (v *IngressValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldIng, ok := oldObj.(*netv1.Ingress)
+	if !ok {
+		return fmt.Errorf("expected Ingress but got %T", oldObj)
+	}
+	newIng, ok := newObj.(*netv1.Ingress)
+	if !ok {
+		return fmt.Errorf("expected Ingress but got %T", newObj)
+	}
+	if !specAnnotationsChanged(oldIng, newIng) {
+		return nil
+	}
+	return v.validate(ctx, newIng)
+}
+
+// ValidateDelete accepts every deletion
+func (v *IngressValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// SetupWebhookWithManager registers the webhook on /validate-networking-k8s-io-v1-ingress path of the webhook server
+func (v *IngressValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&netv1.Ingress{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *IngressValidator) validate(ctx context.Context, obj runtime.Object) error {
+	ing, ok := obj.(*netv1.Ingress)
+	if !ok {
+		return fmt.Errorf("expected Ingress but got %T", obj)
+	}
+	config := snapshotConfig(v.Config)
+	if !mapper.NewIngressSelector(config).Matches(ing) {
+		return nil
+	}
+	defaults, err := v.Defaults.Resolve(ctx, ing.Namespace)
+	if err != nil {
+		return err
+	}
+	spec, err := mapper.ParseSpec(ing.GetAnnotations(), defaults)
+	if err == nil {
+		err = mapper.ValidateSpec(spec, config)
+	}
+	if err != nil {
+		v.Log.Info().
+			Str("ingress", ing.Namespace+"/"+ing.Name).
+			Err(err).
+			Msg("Rejecting ingress with invalid k8gb annotations")
+		return fmt.Errorf("invalid k8gb annotations: %w", err)
+	}
+	return nil
+}
+
+func specAnnotationsChanged(oldIng, newIng *netv1.Ingress) bool {
+	for _, a := range mapper.SpecAnnotations {
+		oldValue, oldFound := oldIng.GetAnnotations()[a]
+		newValue, newFound := newIng.GetAnnotations()[a]
+		if oldFound != newFound || oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestIngressValidatorCreate(t *testing.T) {
+	var tests = []struct {
+		name        string
+		namespace   string
+		annotations map[string]string
+		isValid     bool
+	}{
+		{name: "no annotations", namespace: "team-a", annotations: map[string]string{}, isValid: true},
+		{name: "valid failover", namespace: "team-a", annotations: map[string]string{mapper.AnnotationStrategy: depresolver.FailoverStrategy,
+			mapper.AnnotationPrimaryGeoTag: "eu"}, isValid: true},
+		{name: "unknown strategy", namespace: "team-a", annotations: map[string]string{mapper.AnnotationStrategy: "random"}, isValid: false},
+		{name: "failover without primary geotag", namespace: "team-a",
+			annotations: map[string]string{mapper.AnnotationStrategy: depresolver.FailoverStrategy}, isValid: false},
+		{name: "unparsable weights", namespace: "team-a", annotations: map[string]string{mapper.AnnotationStrategy: depresolver.RoundRobinStrategy,
+			mapper.AnnotationWeightJSON: "eu=5,us=5"}, isValid: false},
+		{name: "unknown primary geotag", namespace: "team-a", annotations: map[string]string{mapper.AnnotationStrategy: depresolver.FailoverStrategy,
+			mapper.AnnotationPrimaryGeoTag: "cz"}, isValid: false},
+		{name: "not watched namespace", namespace: "team-b", annotations: map[string]string{mapper.AnnotationStrategy: "random"}, isValid: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// arrange
+			v := fakeIngressValidator()
+			ing := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: test.namespace, Annotations: test.annotations}}
+			// act
+			err := v.ValidateCreate(context.TODO(), ing)
+			// assert
+			assert.Equal(t, test.isValid, err == nil)
+		})
+	}
+}
+
+func TestIngressValidatorUpdate(t *testing.T) {
+	// arrange
+	v := fakeIngressValidator()
+	invalid := map[string]string{mapper.AnnotationStrategy: depresolver.FailoverStrategy, mapper.AnnotationPrimaryGeoTag: "cz"}
+	oldIng := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "team-a", Annotations: invalid}}
+	statusUpdate := oldIng.DeepCopy()
+	statusUpdate.Annotations[mapper.AnnotationStatus] = "{}"
+	statusUpdate.Finalizers = []string{mapper.Finalizer}
+	annotationUpdate := oldIng.DeepCopy()
+	annotationUpdate.Annotations[mapper.AnnotationDNSTTLSeconds] = "60"
+	// act
+	errStatus := v.ValidateUpdate(context.TODO(), oldIng, statusUpdate)
+	errAnnotation := v.ValidateUpdate(context.TODO(), oldIng, annotationUpdate)
+	// assert
+	assert.NoError(t, errStatus)
+	assert.Error(t, errAnnotation)
+}
+
+func TestIngressValidatorDelete(t *testing.T) {
+	// arrange
+	v := fakeIngressValidator()
+	ing := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "team-a",
+		Annotations: map[string]string{mapper.AnnotationStrategy: "random"}}}
+	// act
+	err := v.ValidateDelete(context.TODO(), ing)
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestIngressValidatorWrongType(t *testing.T) {
+	// arrange
+	v := fakeIngressValidator()
+	// act
+	err := v.ValidateCreate(context.TODO(), &corev1.Service{})
+	// assert
+	assert.Error(t, err)
+}
+
+func TestIngressValidatorNamespaceDefaults(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	v := fakeIngressValidator()
+	v.Config.NamespaceDefaultsEnabled = true
+	c := mocks.NewMockClient(ctrl)
+	c.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "team-a"}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ client.ObjectKey, ns *corev1.Namespace, _ ...interface{}) error {
+			ns.Annotations = map[string]string{mapper.AnnotationPrimaryGeoTag: "eu"}
+			return nil
+		}).Times(1)
+	v.Defaults = mapper.NewDefaultsResolver(c, v.Config)
+	ing := &netv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "team-a",
+		Annotations: map[string]string{mapper.AnnotationStrategy: depresolver.FailoverStrategy}}}
+	// act
+	err := v.ValidateCreate(context.TODO(), ing)
+	// assert
+	assert.NoError(t, err)
+}
+
+func fakeIngressValidator() *IngressValidator {
+	config := &depresolver.Config{ClusterGeoTag: "us", ExtClustersGeoTags: []string{"eu"}, WatchNamespaces: []string{"team-a"}}
+	return &IngressValidator{
+		Config:   config,
+		Defaults: mapper.NewDefaultsResolver(nil, config),
+		Log:      logging.Logger(),
+	}
+}
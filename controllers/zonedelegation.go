@@ -0,0 +1,184 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/dns"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ZoneDelegationReconciler owns the delegated zone and split brain heartbeat in Edge DNS. Delegated zone
+// is shared by all annotated resources within the cluster, so it is reconciled in its own loop every
+// ZONE_DELEGATION_REQUEUE_SECONDS instead of within each ingress reconciliation
+type ZoneDelegationReconciler struct {
+	Client      client.Reader
+	Config      *depresolver.Config
+	DNSProvider dns.Provider
+	Mapper      mapper.ProviderMapper
+	Tracer      trace.Tracer
+	Log         *zerolog.Logger
+	Metrics     metrics.Metrics
+	Recorder    record.EventRecorder
+	// Shutdown is closed when the operator starts terminating
+	Shutdown <-chan struct{}
+	// delegatedIPs exposed IPs of the last successful zone delegation
+	delegatedIPs []string
+}
+
+// Start implements manager.Runnable
+func (r *ZoneDelegationReconciler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(time.Duration(r.Config.ZoneDelegationRequeueSeconds) * time.Second)
+	defer ticker.Stop()
+	for {
+		r.reconcile(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable. Only the leader writes to Edge DNS
+func (r *ZoneDelegationReconciler) NeedLeaderElection() bool {
+	return true
+}
+
+// SetupWithManager adds zone delegation loop to the Manager.
+func (r *ZoneDelegationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(r)
+}
+
+func (r *ZoneDelegationReconciler) reconcile(ctx context.Context) {
+	ctx, span := r.Tracer.Start(ctx, "CreateZoneDelegationForExternalDNS")
+	defer span.End()
+	ctx = depresolver.WithConfig(ctx, snapshotConfig(r.Config))
+
+	withdrawn, err := decommissioned(ctx, r.Client, r.Config, r.Shutdown)
+	if err != nil {
+		r.Log.Err(err).Msg("Unable to read decommission annotation")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		r.Metrics.IncrementZoneDelegationError()
+		return
+	}
+	if withdrawn {
+		r.Log.Info().
+			Str("DNSZone", r.Config.DNSZone).
+			Str("annotation", mapper.AnnotationDecommission).
+			Msg("Cluster is decommissioned, skipping zone delegation")
+		return
+	}
+
+	states, err := r.Mapper.List(ctx)
+	if err != nil {
+		r.Log.Err(err).Msg("Unable to list annotated resources")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		r.Metrics.IncrementZoneDelegationError()
+		return
+	}
+	if len(states) == 0 {
+		r.Log.Debug().
+			Str("DNSZone", r.Config.DNSZone).
+			Msg("No annotated resources found, skipping zone delegation")
+		return
+	}
+	exposedIPs, err := r.exposedIPs(ctx, states)
+	if err != nil {
+		r.Log.Err(err).Msg("Unable to resolve exposed IPs")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		r.Metrics.IncrementZoneDelegationError()
+		return
+	}
+	err = r.DNSProvider.CreateZoneDelegationForExternalDNS(ctx, exposedIPs, resourceNames(states))
+	if err != nil {
+		r.Log.Err(err).Msg("Unable to create zone delegation")
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		r.Metrics.IncrementZoneDelegationError()
+		return
+	}
+	r.Metrics.IncrementZoneDelegation()
+	r.recordDelegation(states, exposedIPs)
+}
+
+// recordDelegation emits event on every annotated resource when exposed IPs of the delegated zone change.
+// The first delegation after the operator starts is not reported, the zone is usually unchanged
+func (r *ZoneDelegationReconciler) recordDelegation(states []*mapper.LoopState, exposedIPs []string) {
+	changed := r.delegatedIPs != nil && strings.Join(r.delegatedIPs, ",") != strings.Join(exposedIPs, ",")
+	r.delegatedIPs = exposedIPs
+	if !changed {
+		return
+	}
+	for _, rs := range states {
+		r.Recorder.Eventf(rs.Ingress, corev1.EventTypeNormal, EventReasonZoneDelegationUpdated,
+			"Zone %s delegated to %s", r.Config.DNSZone, strings.Join(exposedIPs, ", "))
+	}
+}
+
+// exposedIPs returns sorted union of IPs exposed by all annotated resources
+func (r *ZoneDelegationReconciler) exposedIPs(ctx context.Context, states []*mapper.LoopState) ([]string, error) {
+	set := map[string]bool{}
+	for _, rs := range states {
+		ips, err := rs.GetExposedIPs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			set[ip] = true
+		}
+	}
+	exposedIPs := make([]string, 0, len(set))
+	for ip := range set {
+		exposedIPs = append(exposedIPs, ip)
+	}
+	sort.Strings(exposedIPs)
+	return exposedIPs, nil
+}
+
+// resourceNames returns sorted unique names of annotated resources
+func resourceNames(states []*mapper.LoopState) []string {
+	set := map[string]bool{}
+	for _, rs := range states {
+		set[rs.NamespacedName.Name] = true
+	}
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
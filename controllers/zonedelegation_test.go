@@ -0,0 +1,148 @@
+package controllers
+
+/*
+Copyright 2022 The k8gb Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+
+Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
+*/
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
+	"github.com/k8gb-io/k8gb-light/controllers/logging"
+	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	mocks "github.com/k8gb-io/k8gb-light/controllers/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestZoneDelegationReconcile(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m1 := mocks.NewMockMapper(ctrl)
+	m2 := mocks.NewMockMapper(ctrl)
+	m1.EXPECT().GetExposedIPs(gomock.Any()).Return([]string{"10.0.0.2", "10.0.0.1"}, nil).Times(1)
+	m2.EXPECT().GetExposedIPs(gomock.Any()).Return([]string{"10.0.0.1", "10.0.0.3"}, nil).Times(1)
+	r := fakeZoneDelegation(ctrl)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).
+		Return([]*mapper.LoopState{{Mapper: m1, NamespacedName: types.NamespacedName{Namespace: "b", Name: "web"}},
+			{Mapper: m2, NamespacedName: types.NamespacedName{Namespace: "a", Name: "app"}}}, nil).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().
+		CreateZoneDelegationForExternalDNS(gomock.Any(), []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, []string{"app", "web"}).Return(nil).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementZoneDelegation().Times(1)
+	// act
+	r.reconcile(context.TODO())
+}
+
+func TestZoneDelegationReconcileWithoutAnnotatedResources(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeZoneDelegation(ctrl)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Return(nil, nil).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().CreateZoneDelegationForExternalDNS(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	// act
+	r.reconcile(context.TODO())
+}
+
+func TestZoneDelegationReconcileError(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockMapper(ctrl)
+	m.EXPECT().GetExposedIPs(gomock.Any()).Return([]string{"10.0.0.1"}, nil).Times(1)
+	r := fakeZoneDelegation(ctrl)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Return([]*mapper.LoopState{{Mapper: m}}, nil).Times(1)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().CreateZoneDelegationForExternalDNS(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(fmt.Errorf("infoblox error")).Times(1)
+	r.Metrics.(*mocks.MockMetrics).EXPECT().IncrementZoneDelegationError().Times(1)
+	// act
+	r.reconcile(context.TODO())
+}
+
+func TestZoneDelegationReconcileDecommissioned(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeZoneDelegation(ctrl)
+	r.Client.(*mocks.MockClient).EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "k8gb"}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ types.NamespacedName, ns *corev1.Namespace, _ ...interface{}) error {
+			ns.Annotations = map[string]string{mapper.AnnotationDecommission: "true"}
+			return nil
+		}).Times(1)
+	r.Mapper.(*mocks.MockProviderMapper).EXPECT().List(gomock.Any()).Times(0)
+	r.DNSProvider.(*mocks.MockProvider).EXPECT().CreateZoneDelegationForExternalDNS(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	// act
+	r.reconcile(context.TODO())
+}
+
+func TestZoneDelegationUpdatedEvent(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeZoneDelegation(ctrl)
+	r.Config.DNSZone = "cloud.example.com"
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	states := []*mapper.LoopState{{Ingress: &netv1.Ingress{}}, {Ingress: &netv1.Ingress{}}}
+	// act
+	r.recordDelegation(states, []string{"10.0.0.1"})
+	r.recordDelegation(states, []string{"10.0.0.1"})
+	r.recordDelegation(states, []string{"10.0.0.1", "10.0.0.2"})
+	// assert
+	assert.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Normal ZoneDelegationUpdated Zone cloud.example.com delegated to 10.0.0.1, 10.0.0.2", <-recorder.Events)
+}
+
+func fakeZoneDelegation(ctrl *gomock.Controller) *ZoneDelegationReconciler {
+	tracer := mocks.NewMockTracer(ctrl)
+	span := mocks.NewMockSpan(ctrl)
+	span.EXPECT().End(gomock.Any()).Return().AnyTimes()
+	span.EXPECT().RecordError(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	span.EXPECT().SetStatus(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	tracer.EXPECT().Start(gomock.Any(), gomock.Any()).Return(context.TODO(), span).AnyTimes()
+	return &ZoneDelegationReconciler{
+		Client:      mocks.NewMockClient(ctrl),
+		Config:      &depresolver.Config{ZoneDelegationRequeueSeconds: 30, K8gbNamespace: "k8gb"},
+		DNSProvider: mocks.NewMockProvider(ctrl),
+		Mapper:      mocks.NewMockProviderMapper(ctrl),
+		Tracer:      tracer,
+		Log:         logging.Logger(),
+		Metrics:     mocks.NewMockMetrics(ctrl),
+		Recorder:    &record.FakeRecorder{},
+	}
+}
+
+func TestZoneDelegationNeedsLeaderElection(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	r := fakeZoneDelegation(ctrl)
+	// act
+	// assert
+	assert.True(t, r.NeedLeaderElection())
+}
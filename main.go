@@ -20,14 +20,18 @@ Generated by GoLic, for more details see: https://github.com/AbsaOSS/golic
 
 import (
 	"context"
+	"flag"
 	"os"
+	"time"
 
 	"github.com/k8gb-io/k8gb-light/controllers"
 	"github.com/k8gb-io/k8gb-light/controllers/depresolver"
 	"github.com/k8gb-io/k8gb-light/controllers/logging"
 	"github.com/k8gb-io/k8gb-light/controllers/mapper"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/audit"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/dns"
 	"github.com/k8gb-io/k8gb-light/controllers/providers/metrics"
+	"github.com/k8gb-io/k8gb-light/controllers/providers/notifier"
 	"github.com/k8gb-io/k8gb-light/controllers/tracing"
 	"github.com/k8gb-io/k8gb-light/controllers/utils"
 
@@ -58,7 +62,10 @@ func main() {
 }
 
 func run() error {
-	resolver := depresolver.NewDependencyResolver()
+	var configFile string
+	flag.StringVar(&configFile, "config-file", "", "Path to YAML or JSON configuration file; environment variables override its values")
+	flag.Parse()
+	resolver := depresolver.NewDependencyResolverWithConfigFile(configFile)
 	config, err := resolver.ResolveOperatorConfig()
 	deprecations := resolver.GetDeprecations()
 	// Initialize desired log or default log in case of configuration failed.
@@ -68,6 +75,10 @@ func run() error {
 		Str("version", version).
 		Str("commit", commit).
 		Msg("k8gb info")
+	// unknown keys are reported before validation errors, they are often the cause
+	for _, w := range resolver.GetConfigFileWarnings() {
+		log.Warn().Msg(w)
+	}
 	if err != nil {
 		log.Err(err).Msg("Can't resolve environment variables")
 		return err
@@ -78,12 +89,27 @@ func run() error {
 
 	ctrl.SetLogger(logging.NewLogrAdapter(log))
 
+	// informer caches are started on every replica, so non-leader replicas are ready to take over
+	// while controllers and zone delegation loop run on the leader only
+	leaseDuration := time.Duration(config.LeaderElection.LeaseDurationSeconds) * time.Second
+	renewDeadline := time.Duration(config.LeaderElection.RenewDeadlineSeconds) * time.Second
+	retryPeriod := time.Duration(config.LeaderElection.RetryPeriodSeconds) * time.Second
+	// decommission runs within the graceful shutdown, default controller-runtime timeout is 30s
+	gracefulShutdownTimeout := time.Duration(config.DecommissionTimeoutSeconds)*time.Second + 30*time.Second
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:             scheme,
-		MetricsBindAddress: config.MetricsAddress,
-		Port:               9443,
-		LeaderElection:     false,
-		LeaderElectionID:   "8020e9ff.absa.oss",
+		Scheme:                        scheme,
+		MetricsBindAddress:            config.MetricsAddress,
+		HealthProbeBindAddress:        config.HealthProbeAddress,
+		Port:                          9443,
+		LeaderElection:                config.LeaderElection.Enabled,
+		LeaderElectionID:              config.LeaderElection.ID,
+		LeaderElectionNamespace:       config.LeaderElection.Namespace,
+		LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
+		NewCache:                      controllers.NewCache(config),
 	})
 	if err != nil {
 		log.Err(err).Msg("Unable to create k8gb operator manager")
@@ -94,6 +120,10 @@ func run() error {
 		log.Warn().Msg(d)
 	}
 
+	if config.DryRun {
+		log.Warn().Msg("Dry run enabled, DNS changes are computed and reported but not applied")
+	}
+
 	log.Info().Msg("Registering components")
 
 	// Add external-dns DNSEndpoints resource
@@ -105,29 +135,48 @@ func run() error {
 		return err
 	}
 
-	log.Info().Msg("Starting metrics")
-	metrics.Init(config)
-	defer metrics.Prometheus().Unregister()
-	err = metrics.Prometheus().Register()
+	log.Info().
+		Str("exporter", config.MetricsExporter).
+		Msg("Starting metrics")
+	m, err := metrics.NewMetrics(context.Background(), config)
+	if err != nil {
+		log.Err(err).Msg("Unable to create metrics")
+		return err
+	}
+	defer m.Unregister()
+	err = m.Register()
 	if err != nil {
 		log.Err(err).Msg("Unable to register metrics")
 		return err
 	}
 
+	// reconcilers observe the start of shutdown, so they don't publish the cluster decommissioned on shutdown
+	ctx := ctrl.SetupSignalHandler()
+
+	// Kubernetes API calls of reconciliation are traced as children of the reconciliation span
+	tracedClient := tracing.NewClient(mgr.GetClient())
 	reconciler := &controllers.AnnoReconciler{
 		Config:           config,
-		Client:           mgr.GetClient(),
+		Client:           tracedClient,
 		DepResolver:      resolver,
 		Scheme:           mgr.GetScheme(),
-		Mapper:           mapper.NewCommonProvider(mgr.GetClient(), config),
-		ReconcilerResult: utils.NewReconcileResultHandler(config.ReconcileRequeueSeconds),
+		Mapper:           mapper.NewCommonProvider(tracedClient, mgr.GetAPIReader(), config),
+		ReconcilerResult: utils.NewReconcileResultHandler(config.ReconcileRequeueSeconds, config.ReconcileRequeueJitterPercent),
 		Log:              log,
-		Metrics:          metrics.Prometheus(),
+		Metrics:          m,
+		Recorder:         mgr.GetEventRecorderFor("k8gb"),
+		Notifier:         notifier.NewNotifier(config, log),
+		Shutdown:         ctx.Done(),
+	}
+	reconciler.Auditor, err = audit.NewAuditor(config, mgr.GetClient(), mgr.GetAPIReader(), log)
+	if err != nil {
+		log.Err(err).Msg("Unable to create auditor")
+		return err
 	}
 
 	log.Info().Msg("Resolving DNS provider")
 	var f *dns.ProviderFactory
-	f, err = dns.NewDNSProviderFactory(reconciler.Client, *reconciler.Config, log, reconciler.Metrics)
+	f, err = dns.NewDNSProviderFactory(reconciler.Client, reconciler.Config, log, reconciler.Metrics)
 	if err != nil {
 		log.Err(err).Msg("Unable to create DNS provider factory")
 		return err
@@ -136,28 +185,133 @@ func run() error {
 	log.Info().
 		Str("provider", reconciler.DNSProvider.String()).
 		Msg("Started DNS provider")
+	log.Info().
+		Str("notifier", reconciler.Notifier.String()).
+		Msg("Started notifier")
+	log.Info().
+		Str("auditor", reconciler.Auditor.String()).
+		Msg("Started auditor")
 
-	if err = reconciler.SetupWithManager(mgr); err != nil {
+	if err = reconciler.SetupWithManager(ctx, mgr); err != nil {
 		log.Err(err).Msg("Unable to create Gslb controller")
 		return err
 	}
-	metrics.Prometheus().SetRuntimeInfo(version, commit)
+	if err = mgr.Add(reconciler.Notifier); err != nil {
+		log.Err(err).Msg("Unable to start notifier")
+		return err
+	}
+	if err = mgr.Add(reconciler.Auditor); err != nil {
+		log.Err(err).Msg("Unable to start auditor")
+		return err
+	}
+	m.SetRuntimeInfo(version, commit)
 
 	// tracing
 	cfg := tracing.Settings{
-		Enabled:       config.TracingEnabled,
-		Endpoint:      config.OtelExporterOtlpEndpoint,
-		SamplingRatio: config.TracingSamplingRatio,
-		Commit:        commit,
-		AppVersion:    version,
+		Enabled:            config.TracingEnabled,
+		Endpoint:           config.OtelExporterOtlpEndpoint,
+		SamplingRatio:      config.TracingSamplingRatio,
+		Commit:             commit,
+		AppVersion:         version,
+		Protocol:           config.TracingProtocol,
+		Insecure:           config.TracingInsecure,
+		CAFile:             config.TracingCAFile,
+		ClientCertFile:     config.TracingClientCertFile,
+		ClientKeyFile:      config.TracingClientKeyFile,
+		Headers:            config.TracingHeaders,
+		ResourceAttributes: config.TracingResourceAttributes,
+		GeoTag:             config.ClusterGeoTag,
+		DNSZone:            config.DNSZone,
+	}
+	cleanup, tracer, err := tracing.SetupTracing(context.Background(), cfg, log)
+	if err != nil {
+		log.Err(err).Msg("Unable to set up tracing")
+		return err
 	}
-	cleanup, tracer := tracing.SetupTracing(context.Background(), cfg, log)
 	reconciler.Tracer = tracer
 	defer cleanup()
 
+	healthChecker := &controllers.HealthChecker{
+		Config:      config,
+		DNSProvider: reconciler.DNSProvider,
+		RESTMapper:  mgr.GetRESTMapper(),
+	}
+	if err = healthChecker.SetupWithManager(mgr); err != nil {
+		log.Err(err).Msg("Unable to register health checks")
+		return err
+	}
+
+	if config.DebugAddress != "" {
+		debugServer := &controllers.DebugServer{
+			Config:     config,
+			Reconciler: reconciler,
+			Log:        log,
+		}
+		if err = debugServer.SetupWithManager(mgr); err != nil {
+			log.Err(err).Msg("Unable to create debug server")
+			return err
+		}
+	}
+
+	zoneDelegation := &controllers.ZoneDelegationReconciler{
+		Client:      tracedClient,
+		Config:      config,
+		DNSProvider: reconciler.DNSProvider,
+		Mapper:      reconciler.Mapper,
+		Tracer:      tracer,
+		Log:         log,
+		Metrics:     reconciler.Metrics,
+		Recorder:    reconciler.Recorder,
+		Shutdown:    ctx.Done(),
+	}
+	if err = zoneDelegation.SetupWithManager(mgr); err != nil {
+		log.Err(err).Msg("Unable to create zone delegation loop")
+		return err
+	}
+
+	decommissioner := &controllers.Decommissioner{
+		Client:      tracedClient,
+		APIReader:   mgr.GetAPIReader(),
+		Config:      config,
+		DNSProvider: reconciler.DNSProvider,
+		Mapper:      reconciler.Mapper,
+		Log:         log,
+	}
+	if err = decommissioner.SetupWithManager(mgr); err != nil {
+		log.Err(err).Msg("Unable to create decommission hook")
+		return err
+	}
+
+	if config.ConfigMapName != "" {
+		configReloader := &controllers.ConfigReloader{
+			Client:      mgr.GetClient(),
+			Config:      config,
+			DepResolver: resolver,
+			Recorder:    reconciler.Recorder,
+			Log:         log,
+			Metrics:     reconciler.Metrics,
+		}
+		if err = configReloader.SetupWithManager(mgr); err != nil {
+			log.Err(err).Msg("Unable to create configuration reloader")
+			return err
+		}
+	}
+
+	if config.WebhookEnabled {
+		ingressValidator := &controllers.IngressValidator{
+			Config:   config,
+			Defaults: mapper.NewDefaultsResolver(mgr.GetClient(), config),
+			Log:      log,
+		}
+		if err = ingressValidator.SetupWebhookWithManager(mgr); err != nil {
+			log.Err(err).Msg("Unable to create ingress validating webhook")
+			return err
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 	log.Info().Msg("Starting k8gb")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		log.Err(err).Msg("Problem running k8gb")
 		return err
 	}